@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// dbCmd groups database related commands
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Database related subcommands",
+}
+
+// init adds the db command to the root command.
+func init() {
+	rootCmd.AddCommand(dbCmd)
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/cleanup"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/config"
+	"github.com/spf13/cobra"
+)
+
+// dbCleanupCmd represents the db cleanup command
+var dbCleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Deletes expired tokens",
+	Long: `Deletes expired access, refresh and password reset tokens
+as well as other stale auth state once, in batches.
+
+The server runs the same cleanup periodically unless
+SERVER_CLEANUP_ENABLED is false.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runCleanup(); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+// init adds the cleanup command to the db command.
+func init() {
+	dbCmd.AddCommand(dbCleanupCmd)
+}
+
+// runCleanup runs the cleanup once and prints the deleted rows per table.
+func runCleanup() error {
+	config := config.DefaultServiceConfigFromEnv()
+	s := server.New(config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := s.InitDB(ctx); err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer s.DB.Close()
+
+	res, err := cleanup.New(s.DB, config.Cleanup, cleanup.DefaultTargets(config.Auth)).Run(context.Background())
+
+	tables := make([]string, 0, len(res.Deleted))
+	for table := range res.Deleted {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TABLE\tDELETED")
+	for _, table := range tables {
+		fmt.Fprintf(w, "%s\t%d\n", table, res.Deleted[table])
+	}
+	if flushErr := w.Flush(); flushErr != nil {
+		return flushErr
+	}
+
+	fmt.Printf("Finished in %s\n", res.Duration)
+
+	return err
+}
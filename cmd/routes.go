@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/driif/echo-go-starter/internal/api/router"
+	"github.com/spf13/cobra"
+)
+
+// routesCmd represents the routes command
+var routesCmd = &cobra.Command{
+	Use:   "routes",
+	Short: "Lists all routes",
+	Long: `Lists all routes registered by our modules
+including their authentication requirements.
+
+Fails if the registered routes are invalid,
+e.g. if duplicate routes were registered.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := printRoutes(); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+// init adds the routes command to the root command.
+func init() {
+	rootCmd.AddCommand(routesCmd)
+}
+
+// printRoutes prints all registered routes as table, sorted by path.
+func printRoutes() error {
+	r, err := router.NewRegistry()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "METHOD\tPATH\tGROUP\tAUTH\tDESCRIPTION")
+
+	for _, route := range r.SortedRoutes() {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", route.Method, route.FullPath(), route.Group, route.AuthRequirement(), route.Description)
+	}
+
+	return w.Flush()
+}
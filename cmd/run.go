@@ -56,15 +56,46 @@ func runServer() {
 		cancel()
 		log.Fatal().Err(err).Msg("Failed to initialize database")
 	}
+
+	if err := s.InitDBRouter(ctx); err != nil {
+		cancel()
+		log.Fatal().Err(err).Msg("Failed to initialize database replicas")
+	}
 	cancel()
 
+	if err := s.InitMailer(); err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize mailer")
+	}
+
+	if err := s.InitJWT(); err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize JWT keys")
+	}
+
+	if err := s.InitOIDC(); err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize OIDC providers")
+	}
+
+	if err := s.InitCleanup(); err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize cleanup")
+	}
+
+	s.InitJobs()
+
+	s.InitOutbox()
+
+	if err := s.InitScheduler(); err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize scheduler")
+	}
+
 	if err := s.Initialize(); err != nil {
 		log.Fatal().Err(err).Msg("Failed to initialize server")
 		os.Exit(1)
 	}
 
 	router.InitGroups(s)
-	router.AttachRoutes(s)
+	if err := router.AttachRoutes(s); err != nil {
+		log.Fatal().Err(err).Msg("Failed to attach routes")
+	}
 
 	go func() {
 		if err := s.Start(); err != nil {
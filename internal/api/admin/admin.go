@@ -0,0 +1,98 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/driif/echo-go-starter/internal/api/module"
+	"github.com/driif/echo-go-starter/internal/server/net/auth"
+	mdwr "github.com/driif/echo-go-starter/internal/server/net/middleware"
+)
+
+// Register adds all admin routes to the registry, all of them require the admin scope.
+func Register(r *module.Registry) {
+	scopes := []auth.Scope{auth.AuthScopeAdmin}
+
+	r.Add(
+		module.Route{
+			Method:      http.MethodGet,
+			Path:        "/users",
+			Group:       module.GroupV1Admin,
+			Auth:        mdwr.AuthModeRequired,
+			Scopes:      scopes,
+			Handler:     getUsersHandler,
+			Description: "List users (paginated, filterable)",
+		},
+		module.Route{
+			Method:      http.MethodPost,
+			Path:        "/users",
+			Group:       module.GroupV1Admin,
+			Auth:        mdwr.AuthModeRequired,
+			Scopes:      scopes,
+			Handler:     postUserHandler,
+			Description: "Create a user",
+		},
+		module.Route{
+			Method:      http.MethodGet,
+			Path:        "/users/:id",
+			Group:       module.GroupV1Admin,
+			Auth:        mdwr.AuthModeRequired,
+			Scopes:      scopes,
+			Handler:     getUserHandler,
+			Description: "Get a user",
+		},
+		module.Route{
+			Method:      http.MethodPut,
+			Path:        "/users/:id/scopes",
+			Group:       module.GroupV1Admin,
+			Auth:        mdwr.AuthModeRequired,
+			Scopes:      scopes,
+			Handler:     putUserScopesHandler,
+			Description: "Replace the scopes of a user",
+		},
+		module.Route{
+			Method:      http.MethodPost,
+			Path:        "/users/:id/deactivate",
+			Group:       module.GroupV1Admin,
+			Auth:        mdwr.AuthModeRequired,
+			Scopes:      scopes,
+			Handler:     postDeactivateUserHandler,
+			Description: "Deactivate a user, revoking all sessions",
+		},
+		module.Route{
+			Method:      http.MethodPost,
+			Path:        "/users/:id/activate",
+			Group:       module.GroupV1Admin,
+			Auth:        mdwr.AuthModeRequired,
+			Scopes:      scopes,
+			Handler:     postActivateUserHandler,
+			Description: "Activate a user",
+		},
+		module.Route{
+			Method:      http.MethodPost,
+			Path:        "/users/:id/unlock",
+			Group:       module.GroupV1Admin,
+			Auth:        mdwr.AuthModeRequired,
+			Scopes:      scopes,
+			Handler:     postUnlockUserHandler,
+			Description: "Lift delays and lockouts caused by failed login attempts",
+		},
+		module.Route{
+			Method:      http.MethodDelete,
+			Path:        "/users/:id/mfa",
+			Group:       module.GroupV1Admin,
+			Auth:        mdwr.AuthModeRequired,
+			Scopes:      scopes,
+			Handler:     deleteUserMFAHandler,
+			Description: "Reset 2FA of a user",
+		},
+		module.Route{
+			Method:      http.MethodDelete,
+			Path:        "/users/:id",
+			Group:       module.GroupV1Admin,
+			Auth:        mdwr.AuthModeRequired,
+			Scopes:      scopes,
+			Handler:     deleteUserHandler,
+			Description: "Delete a user",
+		},
+	)
+}
@@ -0,0 +1,351 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/api/request"
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/net/auth"
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+	"github.com/driif/echo-go-starter/pkg/auth/hashing"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/driif/echo-go-starter/pkg/slices"
+	"github.com/driif/echo-go-starter/pkg/strs"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+var listUsersPagination = request.PaginationOptions{
+	DefaultLimit: 20,
+	MaxLimit:     100,
+	SortFields: map[string]string{
+		"created_at":            models.UserTableColumns.CreatedAt,
+		"updated_at":            models.UserTableColumns.UpdatedAt,
+		"username":              models.UserTableColumns.Username,
+		"last_authenticated_at": models.UserTableColumns.LastAuthenticatedAt,
+	},
+	DefaultSort:  "-created_at",
+	TieBreaker:   models.UserTableColumns.ID,
+	DefaultTotal: true,
+}
+
+var (
+	timeFilterOperators = []request.FilterOperator{request.FilterLt, request.FilterLte, request.FilterGt, request.FilterGte}
+
+	listUsersFilters = request.FilterFields{
+		"id": {
+			Column:    models.UserTableColumns.ID,
+			Type:      request.FilterUUID,
+			Operators: []request.FilterOperator{request.FilterEq, request.FilterIn},
+		},
+		"username": {
+			Column:    models.UserTableColumns.Username,
+			Operators: []request.FilterOperator{request.FilterEq, request.FilterNeq, request.FilterIn, request.FilterILike, request.FilterIsNull},
+		},
+		"username_search": {
+			Column:    db.TSVector(db.TSConfigSimple, db.TSColumn{Column: models.UserTableColumns.Username}),
+			Operators: []request.FilterOperator{request.FilterSearch},
+		},
+		"scope": {
+			Column:    models.UserTableColumns.Scopes,
+			Operators: []request.FilterOperator{request.FilterContains},
+			Values:    knownScopes(),
+		},
+		"is_active": {
+			Column:    models.UserTableColumns.IsActive,
+			Type:      request.FilterBool,
+			Operators: []request.FilterOperator{request.FilterEq},
+		},
+		"last_authenticated_at": {
+			Column:    models.UserTableColumns.LastAuthenticatedAt,
+			Type:      request.FilterTime,
+			Operators: append([]request.FilterOperator{request.FilterIsNull}, timeFilterOperators...),
+		},
+		"created_at": {
+			Column:    models.UserTableColumns.CreatedAt,
+			Type:      request.FilterTime,
+			Operators: timeFilterOperators,
+		},
+		"updated_at": {
+			Column:    models.UserTableColumns.UpdatedAt,
+			Type:      request.FilterTime,
+			Operators: timeFilterOperators,
+		},
+	}
+
+	// listUsersFilterAliases keeps the query parameters accepted before the filter DSL was introduced working.
+	listUsersFilterAliases = request.FilterAliases{
+		"scope":  {Field: "scope", Operator: request.FilterContains},
+		"active": {Field: "is_active", Operator: request.FilterEq},
+		"q":      {Field: "username", Operator: request.FilterILike},
+	}
+)
+
+type userResponse struct {
+	ID                  string     `json:"id"`
+	Username            *string    `json:"username"`
+	IsActive            bool       `json:"isActive"`
+	Scopes              []string   `json:"scopes"`
+	LastAuthenticatedAt *time.Time `json:"lastAuthenticatedAt"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	UpdatedAt           time.Time  `json:"updatedAt"`
+}
+
+type usersResponse struct {
+	Items []userResponse `json:"items"`
+	Page  int            `json:"page"`
+	Limit int            `json:"limit"`
+	Total null.Int64     `json:"total"`
+}
+
+type postUserPayload struct {
+	Username string   `json:"username"`
+	Password string   `json:"password"`
+	Scopes   []string `json:"scopes"`
+	IsActive *bool    `json:"isActive"`
+}
+
+func (p *postUserPayload) Validate() []*errs.HTTPValidationErrorDetail {
+	var details []*errs.HTTPValidationErrorDetail
+
+	p.Username = strs.ToUsernameFormat(p.Username)
+	if !auth.ValidUsername(p.Username) {
+		details = append(details, request.InvalidField("username", request.InBody, "username must be a valid email address"))
+	}
+
+	if !auth.ValidPasswordLength(p.Password) {
+		details = append(details, request.InvalidField("password", request.InBody,
+			fmt.Sprintf("password must be between %d and %d bytes long", auth.PasswordMinLength, auth.PasswordMaxLength)))
+	}
+
+	var d *errs.HTTPValidationErrorDetail
+	p.Scopes, d = validateScopes(p.Scopes)
+	if d != nil {
+		details = append(details, d)
+	}
+
+	return details
+}
+
+func getUsersHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := logs.LogFromContext(ctx)
+
+		pagination, filters, err := parseListUsersQuery(c)
+		if err != nil {
+			return err
+		}
+
+		// listing tolerates the replication lag, the request's own writes are read from the primary
+		reader := s.DBRouter.ReadOnly()
+
+		total, err := pagination.Total(func() (int64, error) {
+			return models.Users(filters...).Count(ctx, reader)
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to count users")
+			return err
+		}
+
+		users, err := models.Users(append(filters, pagination.QueryMods()...)...).All(ctx, reader)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to load users")
+			return err
+		}
+
+		res := usersResponse{
+			Items: make([]userResponse, 0, len(users)),
+			Page:  pagination.Page,
+			Limit: pagination.Limit,
+			Total: total,
+		}
+		for _, u := range users {
+			res.Items = append(res.Items, newUserResponse(u))
+		}
+
+		return c.JSON(http.StatusOK, res)
+	}
+}
+
+func getUserHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		id, err := userIDFromPath(c)
+		if err != nil {
+			return err
+		}
+
+		user, err := models.FindUser(ctx, s.DB, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apierrs.UserNotFound
+			}
+
+			logs.LogFromContext(ctx).Error().Err(err).Msg("Failed to load user")
+			return err
+		}
+
+		return c.JSON(http.StatusOK, newUserResponse(user))
+	}
+}
+
+// postUserHandler creates a user with the given scopes, which must not exceed the scopes of the admin.
+// Users with the app scope receive an empty profile.
+func postUserHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := logs.LogFromContext(ctx)
+		actor := auth.UserFromContext(ctx)
+
+		var body postUserPayload
+		if err := request.BindBody(c, &body); err != nil {
+			return err
+		}
+
+		if !auth.CanGrantScopes(actor, body.Scopes...) {
+			log.Debug().Strs("scopes", body.Scopes).Msg("Refusing to grant scopes beyond own scopes")
+			return apierrs.ScopeEscalation
+		}
+
+		hash, err := hashing.Hash(body.Password, s.Config.Hashing)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to hash password")
+			return err
+		}
+
+		user := &models.User{
+			Username: null.StringFrom(body.Username),
+			Password: null.StringFrom(hash),
+			IsActive: body.IsActive == nil || *body.IsActive,
+			Scopes:   types.StringArray(body.Scopes),
+		}
+
+		err = db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			exists, err := models.Users(models.UserWhere.Username.EQ(user.Username)).Exists(ctx, tx)
+			if err != nil {
+				return err
+			}
+			if exists {
+				return apierrs.UserExists
+			}
+
+			if err := user.Insert(ctx, tx, boil.Infer()); err != nil {
+				return err
+			}
+
+			if !slices.ContainsString(body.Scopes, auth.AuthScopeApp.String()) {
+				return nil
+			}
+
+			profile := &models.AppUserProfile{UserID: user.ID}
+			return profile.Insert(ctx, tx, boil.Infer())
+		})
+		if err != nil {
+			if errors.Is(err, apierrs.UserExists) || db.IsUniqueViolation(err) {
+				return apierrs.UserExists
+			}
+
+			log.Error().Err(err).Msg("Failed to create user")
+			return err
+		}
+
+		log.Info().Str("targetUserID", user.ID).Strs("scopes", body.Scopes).Msg("Created user")
+
+		return c.JSON(http.StatusCreated, newUserResponse(user))
+	}
+}
+
+func newUserResponse(user *models.User) userResponse {
+	res := userResponse{
+		ID:                  user.ID,
+		Username:            user.Username.Ptr(),
+		IsActive:            user.IsActive,
+		Scopes:              user.Scopes,
+		LastAuthenticatedAt: user.LastAuthenticatedAt.Ptr(),
+		CreatedAt:           user.CreatedAt,
+		UpdatedAt:           user.UpdatedAt,
+	}
+
+	if res.Scopes == nil {
+		res.Scopes = []string{}
+	}
+
+	return res
+}
+
+// parseListUsersQuery parses the pagination (`page`, `limit`, `sort` and `total`), the `filter[...]` parameters
+// and their deprecated aliases `scope`, `active` and `q`.
+func parseListUsersQuery(c echo.Context) (*request.Pagination, []qm.QueryMod, error) {
+	pagination, details := request.ParsePagination(c, listUsersPagination)
+
+	filters, filterDetails := request.ParseFilters(c, listUsersFilters)
+	details = append(details, filterDetails...)
+
+	aliasFilters, aliasDetails := request.ParseFilterAliases(c, listUsersFilters, listUsersFilterAliases)
+	filters = append(filters, aliasFilters...)
+	details = append(details, aliasDetails...)
+
+	if len(details) > 0 {
+		return nil, nil, request.NewValidationError(details...)
+	}
+
+	return pagination, filters, nil
+}
+
+func userIDFromPath(c echo.Context) (string, error) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return "", apierrs.NotUUID
+	}
+
+	return id, nil
+}
+
+func knownScopes() []string {
+	scopes := make([]string, 0, len(auth.KnownScopes()))
+	for _, s := range auth.KnownScopes() {
+		scopes = append(scopes, s.String())
+	}
+
+	return scopes
+}
+
+// validateScopes removes duplicate scopes and checks all scopes to be known.
+func validateScopes(scopes []string) ([]string, *errs.HTTPValidationErrorDetail) {
+	scopes = slices.UniqueString(scopes)
+
+	for _, scope := range scopes {
+		if !auth.IsKnownScope(scope) {
+			return scopes, request.InvalidField("scopes", request.InBody, fmt.Sprintf("scope %q is unknown", scope))
+		}
+	}
+
+	return scopes, nil
+}
+
+// findUserForUpdate loads and locks the user for the current transaction.
+func findUserForUpdate(ctx context.Context, tx boil.ContextExecutor, id string) (*models.User, error) {
+	user, err := models.Users(models.UserWhere.ID.EQ(id), qm.For("UPDATE")).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apierrs.UserNotFound
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,50 @@
+package admin
+
+import (
+	"net/http"
+
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/api/mfa"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/net/auth"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// deleteUserMFAHandler removes the TOTP secret and recovery codes of a user who lost access to them.
+// Users possessing a scope requiring 2FA have to set it up again on their next login.
+func deleteUserMFAHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		actor := auth.UserFromContext(ctx)
+
+		id, err := userIDFromPath(c)
+		if err != nil {
+			return err
+		}
+
+		var removed bool
+		err = db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			user, err := findUserForUpdate(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+
+			if !auth.CanManageUser(actor, user) {
+				return apierrs.UserNotManageable
+			}
+
+			removed, err = mfa.Disable(ctx, tx, user.ID)
+			return err
+		})
+		if err != nil {
+			return handleModifyError(ctx, err, "Failed to reset 2FA of user")
+		}
+
+		logs.LogFromContext(ctx).Info().Str("targetUserID", id).Bool("hadMFA", removed).Msg("Reset 2FA of user")
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
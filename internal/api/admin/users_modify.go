@@ -0,0 +1,257 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/api/request"
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/net/auth"
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/driif/echo-go-starter/pkg/slices"
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+type putUserScopesPayload struct {
+	Scopes []string `json:"scopes"`
+}
+
+func (p *putUserScopesPayload) Validate() []*errs.HTTPValidationErrorDetail {
+	var d *errs.HTTPValidationErrorDetail
+	if p.Scopes, d = validateScopes(p.Scopes); d != nil {
+		return []*errs.HTTPValidationErrorDetail{d}
+	}
+
+	return nil
+}
+
+// putUserScopesHandler replaces the scopes of a user. Admins may only modify users whose scopes they possess
+// themselves and may only grant their own scopes.
+func putUserScopesHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		actor := auth.UserFromContext(ctx)
+
+		id, err := userIDFromPath(c)
+		if err != nil {
+			return err
+		}
+
+		var body putUserScopesPayload
+		if err := request.BindBody(c, &body); err != nil {
+			return err
+		}
+
+		if !auth.CanGrantScopes(actor, body.Scopes...) {
+			return apierrs.ScopeEscalation
+		}
+
+		var user *models.User
+		err = db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			user, err = findUserForUpdate(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+
+			if !auth.CanManageUser(actor, user) {
+				return apierrs.UserNotManageable
+			}
+
+			if !slices.ContainsString(body.Scopes, auth.AuthScopeSuperAdmin.String()) {
+				if err := ensureSuperAdminRemains(ctx, tx, user); err != nil {
+					return err
+				}
+			}
+
+			user.Scopes = types.StringArray(body.Scopes)
+			_, err := user.Update(ctx, tx, boil.Whitelist(models.UserColumns.Scopes, models.UserColumns.UpdatedAt))
+			return err
+		})
+		if err != nil {
+			return handleModifyError(ctx, err, "Failed to update user scopes")
+		}
+
+		logs.LogFromContext(ctx).Info().Str("targetUserID", user.ID).Strs("scopes", body.Scopes).Msg("Updated user scopes")
+
+		return c.JSON(http.StatusOK, newUserResponse(user))
+	}
+}
+
+// postDeactivateUserHandler deactivates a user and revokes all of the user's access and refresh tokens. Pending email
+// verification tokens are removed as well, so verifying the email doesn't reactivate the user.
+func postDeactivateUserHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		actor := auth.UserFromContext(ctx)
+
+		id, err := userIDFromPath(c)
+		if err != nil {
+			return err
+		}
+
+		if id == actor.ID {
+			return apierrs.SelfModification
+		}
+
+		var user *models.User
+		err = db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			user, err = findUserForUpdate(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+
+			if !auth.CanManageUser(actor, user) {
+				return apierrs.UserNotManageable
+			}
+
+			if err := ensureSuperAdminRemains(ctx, tx, user); err != nil {
+				return err
+			}
+
+			user.IsActive = false
+			if _, err := user.Update(ctx, tx, boil.Whitelist(models.UserColumns.IsActive, models.UserColumns.UpdatedAt)); err != nil {
+				return err
+			}
+
+			if _, err := models.AccessTokens(models.AccessTokenWhere.UserID.EQ(user.ID)).DeleteAll(ctx, tx); err != nil {
+				return err
+			}
+
+			if _, err := models.RefreshTokens(models.RefreshTokenWhere.UserID.EQ(user.ID)).DeleteAll(ctx, tx); err != nil {
+				return err
+			}
+
+			_, err := models.EmailVerificationTokens(models.EmailVerificationTokenWhere.UserID.EQ(user.ID)).DeleteAll(ctx, tx)
+			return err
+		})
+		if err != nil {
+			return handleModifyError(ctx, err, "Failed to deactivate user")
+		}
+
+		logs.LogFromContext(ctx).Info().Str("targetUserID", user.ID).Msg("Deactivated user")
+
+		return c.JSON(http.StatusOK, newUserResponse(user))
+	}
+}
+
+func postActivateUserHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		actor := auth.UserFromContext(ctx)
+
+		id, err := userIDFromPath(c)
+		if err != nil {
+			return err
+		}
+
+		var user *models.User
+		err = db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			user, err = findUserForUpdate(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+
+			if !auth.CanManageUser(actor, user) {
+				return apierrs.UserNotManageable
+			}
+
+			user.IsActive = true
+			_, err := user.Update(ctx, tx, boil.Whitelist(models.UserColumns.IsActive, models.UserColumns.UpdatedAt))
+			return err
+		})
+		if err != nil {
+			return handleModifyError(ctx, err, "Failed to activate user")
+		}
+
+		logs.LogFromContext(ctx).Info().Str("targetUserID", user.ID).Msg("Activated user")
+
+		return c.JSON(http.StatusOK, newUserResponse(user))
+	}
+}
+
+// deleteUserHandler deletes a user, all related rows (profile, tokens) are removed via cascade.
+func deleteUserHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		actor := auth.UserFromContext(ctx)
+
+		id, err := userIDFromPath(c)
+		if err != nil {
+			return err
+		}
+
+		if id == actor.ID {
+			return apierrs.SelfModification
+		}
+
+		err = db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			user, err := findUserForUpdate(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+
+			if !auth.CanManageUser(actor, user) {
+				return apierrs.UserNotManageable
+			}
+
+			if err := ensureSuperAdminRemains(ctx, tx, user); err != nil {
+				return err
+			}
+
+			_, err = user.Delete(ctx, tx)
+			return err
+		})
+		if err != nil {
+			return handleModifyError(ctx, err, "Failed to delete user")
+		}
+
+		logs.LogFromContext(ctx).Info().Str("targetUserID", id).Msg("Deleted user")
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// ensureSuperAdminRemains returns LastSuperAdmin if the user is the only active superadmin left.
+// All active superadmins are locked for the transaction, preventing concurrent removals.
+func ensureSuperAdminRemains(ctx context.Context, tx boil.ContextExecutor, user *models.User) error {
+	if !user.IsActive || !auth.HasScopes(user, auth.AuthScopeSuperAdmin) {
+		return nil
+	}
+
+	superAdmins, err := models.Users(
+		models.UserWhere.IsActive.EQ(true),
+		qm.Where("? = ANY("+models.UserTableColumns.Scopes+")", auth.AuthScopeSuperAdmin.String()),
+		qm.For("UPDATE"),
+	).All(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	for _, u := range superAdmins {
+		if u.ID != user.ID {
+			return nil
+		}
+	}
+
+	return apierrs.LastSuperAdmin
+}
+
+// handleModifyError passes our typed errors through and logs all unexpected ones.
+func handleModifyError(ctx context.Context, err error, msg string) error {
+	var httpErr *errs.HTTPError
+	if errors.As(err, &httpErr) {
+		logs.LogFromContext(ctx).Debug().Err(err).Msg(msg)
+		return err
+	}
+
+	logs.LogFromContext(ctx).Error().Err(err).Msg(msg)
+	return err
+}
@@ -0,0 +1,148 @@
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/net/middleware"
+	"github.com/driif/echo-go-starter/internal/server/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type userResponse struct {
+	ID       string   `json:"id"`
+	IsActive bool     `json:"isActive"`
+	Scopes   []string `json:"scopes"`
+}
+
+func TestAdminRequiresAdminScope(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		fix := test.Fixtures()
+
+		res := test.PerformRequest(t, s, "GET", "/v1/admin/users", nil, test.HeadersWithAuth(t, fix.User1AccessToken1.Token))
+		test.RequireHTTPError(t, res, middleware.ErrAuthMissingScopes)
+
+		res = test.PerformRequest(t, s, "GET", "/v1/admin/users", nil, test.HeadersWithAuth(t, fix.Admin1AccessToken1.Token))
+		require.Equal(t, http.StatusOK, res.Result().StatusCode)
+	})
+}
+
+func TestPutUserScopes(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		fix := test.Fixtures()
+
+		res := test.PerformRequest(t, s, "PUT", "/v1/admin/users/"+fix.User1.ID+"/scopes", test.GenericPayload{
+			"scopes": []string{"app", "admin"},
+		}, test.HeadersWithAuth(t, fix.Admin1AccessToken1.Token))
+		require.Equal(t, http.StatusOK, res.Result().StatusCode)
+
+		var response userResponse
+		test.ParseResponseBody(t, res, &response)
+		assert.ElementsMatch(t, []string{"app", "admin"}, response.Scopes)
+
+		// the stale API key of User1 gains access once the user possesses the scope again
+		res = test.PerformRequest(t, s, "GET", "/v1/admin/users", nil, test.HeadersWithAPIKey(t, test.PlainUser1APIKeyStale))
+		require.Equal(t, http.StatusOK, res.Result().StatusCode)
+	})
+}
+
+func TestPutUserScopesEscalation(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		fix := test.Fixtures()
+		headers := test.HeadersWithAuth(t, fix.Admin1AccessToken1.Token)
+
+		// admins can't grant scopes beyond their own
+		res := test.PerformRequest(t, s, "PUT", "/v1/admin/users/"+fix.User1.ID+"/scopes", test.GenericPayload{
+			"scopes": []string{"app", "superadmin"},
+		}, headers)
+		test.RequireHTTPError(t, res, apierrs.ScopeEscalation)
+
+		res = test.PerformRequest(t, s, "POST", "/v1/admin/users", test.GenericPayload{
+			"username": "escalation@example.com",
+			"password": test.PlainTestUserPassword,
+			"scopes":   []string{"app", "superadmin"},
+		}, headers)
+		test.RequireHTTPError(t, res, apierrs.ScopeEscalation)
+
+		// nor manage users possessing scopes beyond their own
+		res = test.PerformRequest(t, s, "PUT", "/v1/admin/users/"+fix.SuperAdmin1.ID+"/scopes", test.GenericPayload{
+			"scopes": []string{"app"},
+		}, headers)
+		test.RequireHTTPError(t, res, apierrs.UserNotManageable)
+
+		res = test.PerformRequest(t, s, "POST", "/v1/admin/users/"+fix.SuperAdmin1.ID+"/deactivate", nil, headers)
+		test.RequireHTTPError(t, res, apierrs.UserNotManageable)
+
+		res = test.PerformRequest(t, s, "DELETE", "/v1/admin/users/"+fix.SuperAdmin1.ID, nil, headers)
+		test.RequireHTTPError(t, res, apierrs.UserNotManageable)
+	})
+}
+
+func TestLastSuperAdmin(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		ctx := context.Background()
+		fix := test.Fixtures()
+		headers := test.HeadersWithAuth(t, fix.SuperAdmin1AccessToken1.Token)
+
+		res := test.PerformRequest(t, s, "PUT", "/v1/admin/users/"+fix.SuperAdmin1.ID+"/scopes", test.GenericPayload{
+			"scopes": []string{"app", "admin"},
+		}, headers)
+		test.RequireHTTPError(t, res, apierrs.LastSuperAdmin)
+
+		user, err := models.FindUser(ctx, s.DB, fix.SuperAdmin1.ID)
+		require.NoError(t, err)
+		assert.Contains(t, []string(user.Scopes), "superadmin")
+
+		// once a second superadmin exists, the scope may be removed
+		res = test.PerformRequest(t, s, "PUT", "/v1/admin/users/"+fix.Admin1.ID+"/scopes", test.GenericPayload{
+			"scopes": []string{"app", "admin", "superadmin"},
+		}, headers)
+		require.Equal(t, http.StatusOK, res.Result().StatusCode)
+
+		res = test.PerformRequest(t, s, "PUT", "/v1/admin/users/"+fix.SuperAdmin1.ID+"/scopes", test.GenericPayload{
+			"scopes": []string{"app", "admin"},
+		}, headers)
+		require.Equal(t, http.StatusOK, res.Result().StatusCode)
+	})
+}
+
+func TestSelfModification(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		fix := test.Fixtures()
+		headers := test.HeadersWithAuth(t, fix.Admin1AccessToken1.Token)
+
+		res := test.PerformRequest(t, s, "POST", "/v1/admin/users/"+fix.Admin1.ID+"/deactivate", nil, headers)
+		test.RequireHTTPError(t, res, apierrs.SelfModification)
+
+		res = test.PerformRequest(t, s, "DELETE", "/v1/admin/users/"+fix.Admin1.ID, nil, headers)
+		test.RequireHTTPError(t, res, apierrs.SelfModification)
+	})
+}
+
+func TestPostDeactivateUser(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		fix := test.Fixtures()
+
+		res := test.PerformRequest(t, s, "POST", "/v1/admin/users/"+fix.User1.ID+"/deactivate", nil, test.HeadersWithAuth(t, fix.Admin1AccessToken1.Token))
+		require.Equal(t, http.StatusOK, res.Result().StatusCode)
+
+		var response userResponse
+		test.ParseResponseBody(t, res, &response)
+		assert.False(t, response.IsActive)
+
+		// existing credentials of the user are rejected
+		res = test.PerformRequest(t, s, "GET", "/v1/users/me", nil, test.HeadersWithAuth(t, fix.User1AccessToken1.Token))
+		assert.NotEqual(t, http.StatusOK, res.Result().StatusCode)
+
+		res = test.PerformRequest(t, s, "POST", "/v1/auth/login", test.GenericPayload{
+			"username": fix.User1.Username.String,
+			"password": test.PlainTestUserPassword,
+		}, nil)
+		test.RequireHTTPError(t, res, apierrs.UserDeactivated)
+	})
+}
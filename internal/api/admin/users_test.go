@@ -0,0 +1,60 @@
+package admin_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type usersResponse struct {
+	Items []userResponse `json:"items"`
+}
+
+func TestGetUsersLegacyFilters(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		fix := test.Fixtures()
+		headers := test.HeadersWithAuth(t, fix.Admin1AccessToken1.Token)
+
+		// the parameters accepted before the filter DSL are compiled to the equivalent filters
+		for query, want := range map[string]string{
+			url.Values{"active": {"false"}}.Encode():                                      fix.UserDeactivated.ID,
+			url.Values{"scope": {"superadmin"}}.Encode():                                  fix.SuperAdmin1.ID,
+			url.Values{"q": {fix.UserMFA.Username.String}}.Encode():                       fix.UserMFA.ID,
+			url.Values{"filter[is_active]": {"false"}}.Encode():                           fix.UserDeactivated.ID,
+			url.Values{"filter[scope][contains]": {"superadmin"}}.Encode():                fix.SuperAdmin1.ID,
+			url.Values{"filter[username][ilike]": {fix.UserMFA.Username.String}}.Encode(): fix.UserMFA.ID,
+		} {
+			res := test.PerformRequest(t, s, "GET", "/v1/admin/users?"+query, nil, headers)
+			require.Equal(t, http.StatusOK, res.Result().StatusCode, query)
+
+			var response usersResponse
+			test.ParseResponseBody(t, res, &response)
+			require.Len(t, response.Items, 1, query)
+			assert.Equal(t, want, response.Items[0].ID, query)
+		}
+
+		res := test.PerformRequest(t, s, "GET", "/v1/admin/users?active=maybe", nil, headers)
+		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
+	})
+}
+
+func TestGetUsersSearchMetacharacters(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		fix := test.Fixtures()
+		headers := test.HeadersWithAuth(t, fix.Admin1AccessToken1.Token)
+
+		// tsquery syntax within the input is searched literally instead of failing the query
+		for _, search := range []string{`user1\`, `user1\\`, `user1:`, `user1 &`, `!user1`, `(user1`, `user1 | admin1:*`, `'user1`} {
+			res := test.PerformRequest(t, s, "GET", "/v1/admin/users?"+url.Values{"filter[username_search][search]": {search}}.Encode(), nil, headers)
+			require.Equal(t, http.StatusOK, res.Result().StatusCode, search)
+		}
+
+		res := test.PerformRequest(t, s, "GET", "/v1/admin/users?"+url.Values{"filter[username_search][search]": {`\' \`}}.Encode(), nil, headers)
+		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
+	})
+}
@@ -0,0 +1,50 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/driif/echo-go-starter/internal/api/attempts"
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/net/auth"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// postUnlockUserHandler forgets all failed login, forgot-password and resend verification attempts of the user's username,
+// lifting delays and lockouts. Attempts tracked for client IPs are not affected.
+func postUnlockUserHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		actor := auth.UserFromContext(ctx)
+
+		id, err := userIDFromPath(c)
+		if err != nil {
+			return err
+		}
+
+		var unlocked bool
+		err = db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			user, err := findUserForUpdate(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+
+			if !auth.CanManageUser(actor, user) {
+				return apierrs.UserNotManageable
+			}
+
+			unlocked, err = attempts.ResetUser(ctx, tx, user)
+			return err
+		})
+		if err != nil {
+			return handleModifyError(ctx, err, "Failed to unlock user")
+		}
+
+		logs.LogFromContext(ctx).Info().Str("targetUserID", id).Bool("hadAttempts", unlocked).Msg("Unlocked user")
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,290 @@
+package attempts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server/config"
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+	"github.com/driif/echo-go-starter/pkg/auth/lockout"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// lockClass is the first key of the advisory locks held by Guard, separating them from other advisory locks.
+const lockClass = 0x61747470
+
+// Action separates the attempts of different endpoints, e.g. forgot-password requests don't lock logins.
+type Action string
+
+const (
+	ActionLogin              Action = "login"
+	ActionForgotPassword     Action = "forgot-password"
+	ActionResendVerification Action = "resend-verification"
+	// ActionMFA tracks wrong 2FA codes of authenticated users managing their 2FA, keyed by user ID (see UserKey).
+	ActionMFA Action = "mfa"
+	// ActionPassword tracks wrong current passwords of authenticated users changing their password, keyed by user ID.
+	ActionPassword Action = "password"
+)
+
+// Actions returns all known actions tracked per username.
+func Actions() []Action {
+	return []Action{ActionLogin, ActionForgotPassword, ActionResendVerification}
+}
+
+type kind string
+
+const (
+	kindUsername kind = "username"
+	kindIP       kind = "ip"
+	kindUser     kind = "user"
+)
+
+// Key identifies the tracked attempts of a single username or client IP for an action.
+type Key struct {
+	action Action
+	kind   kind
+	value  string
+}
+
+// UsernameKey tracks attempts for the (normalized) username.
+func UsernameKey(action Action, username string) Key {
+	return Key{action: action, kind: kindUsername, value: username}
+}
+
+// IPKey tracks attempts for the client IP.
+func IPKey(action Action, ip string) Key {
+	return Key{action: action, kind: kindIP, value: ip}
+}
+
+// UserKey tracks attempts for the ID of an authenticated user, using the username policy.
+func UserKey(action Action, userID string) Key {
+	return Key{action: action, kind: kindUser, value: userID}
+}
+
+// Keys returns the username and client IP key for the action.
+func Keys(c echo.Context, action Action, username string) []Key {
+	return []Key{UsernameKey(action, username), IPKey(action, c.RealIP())}
+}
+
+func (k Key) String() string {
+	return string(k.action) + ":" + string(k.kind) + ":" + k.value
+}
+
+func (k Key) policy(cfg config.AuthAttempts) lockout.Policy {
+	if k.kind == kindIP {
+		return cfg.IP
+	}
+
+	return cfg.Username
+}
+
+func keyStrings(keys []Key) []string {
+	res := make([]string, 0, len(keys))
+	for _, k := range keys {
+		res = append(res, k.String())
+	}
+
+	return res
+}
+
+func stateFromModel(a *models.AuthAttempt) lockout.State {
+	return lockout.State{
+		Failures:      a.Failures,
+		LastFailureAt: a.LastFailureAt,
+		LockedUntil:   a.LockedUntil.Time,
+	}
+}
+
+// Check returns apierrs.TooManyAttempts or apierrs.TemporarilyLockedOut if any of the keys has to wait before
+// attempting again, the Retry-After header of the response is set accordingly.
+func Check(c echo.Context, exec boil.ContextExecutor, cfg config.AuthAttempts, keys ...Key) error {
+	if !cfg.Enabled || len(keys) == 0 {
+		return nil
+	}
+
+	ctx := c.Request().Context()
+	records, err := models.AuthAttempts(models.AuthAttemptWhere.Key.IN(keyStrings(keys))).All(ctx, exec)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var (
+		wait   time.Duration
+		locked bool
+	)
+	for _, k := range keys {
+		for _, r := range records {
+			if r.Key != k.String() {
+				continue
+			}
+
+			w, l := k.policy(cfg).RetryAfter(stateFromModel(r), now)
+			if w > wait {
+				wait = w
+			}
+			locked = locked || l
+		}
+	}
+
+	if wait <= 0 {
+		return nil
+	}
+
+	retryAfter := int(math.Ceil(wait.Seconds()))
+	c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(retryAfter))
+
+	base := apierrs.TooManyAttempts
+	if locked {
+		base = apierrs.TemporarilyLockedOut
+	}
+
+	httpErr := *base
+	httpErr.AdditionalData = map[string]interface{}{"retryAfter": retryAfter}
+
+	return &httpErr
+}
+
+// IsThrottled reports whether err was returned by Check due to a pending delay or lockout.
+func IsThrottled(err error) bool {
+	var httpErr *errs.HTTPError
+	if !errors.As(err, &httpErr) {
+		return false
+	}
+
+	return *httpErr.Type == *apierrs.TooManyAttempts.Type || *httpErr.Type == *apierrs.TemporarilyLockedOut.Type
+}
+
+// RegisterFailure records a failed attempt for all keys. Each key is updated within its own row lock,
+// so concurrent failures across replicas are counted correctly.
+func RegisterFailure(ctx context.Context, sqlDB *sql.DB, cfg config.AuthAttempts, keys ...Key) error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	now := time.Now()
+	for _, k := range keys {
+		err := db.WithTransaction(ctx, sqlDB, func(tx boil.ContextExecutor) error {
+			return registerFailure(ctx, tx, cfg, k, now)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func registerFailure(ctx context.Context, tx boil.ContextExecutor, cfg config.AuthAttempts, k Key, now time.Time) error {
+	if _, err := tx.ExecContext(ctx, `INSERT INTO auth_attempts (key, failures, last_failure_at, created_at, updated_at)
+		VALUES ($1, 0, $2, $2, $2) ON CONFLICT (key) DO NOTHING`, k.String(), now); err != nil {
+		return err
+	}
+
+	record, err := models.AuthAttempts(
+		models.AuthAttemptWhere.Key.EQ(k.String()),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	state := k.policy(cfg).RegisterFailure(stateFromModel(record), now)
+	record.Failures = state.Failures
+	record.LastFailureAt = state.LastFailureAt
+	record.LockedUntil = null.NewTime(state.LockedUntil, !state.LockedUntil.IsZero())
+
+	_, err = record.Update(ctx, tx, boil.Infer())
+	return err
+}
+
+// Guard runs the attempt fn for the keys, returning the error of Check if any of the keys has to wait instead.
+// A failure is registered for all keys if fn reports one, fn's error is returned as is.
+//
+// While checking, running fn and registering its failure, Postgres advisory locks of all keys are held, thus
+// concurrent attempts of the same username or client IP (across replicas) are run one after another, each observing
+// the failures of the previous ones. Keep fn short, e.g. only verifying the credentials.
+func Guard(c echo.Context, sqlDB *sql.DB, cfg config.AuthAttempts, keys []Key, fn func() (failed bool, err error)) error {
+	if !cfg.Enabled || len(keys) == 0 {
+		_, err := fn()
+		return err
+	}
+
+	ctx := c.Request().Context()
+
+	// locked in a consistent order, preventing deadlocks of attempts with overlapping keys
+	lockKeys := keyStrings(keys)
+	sort.Strings(lockKeys)
+
+	var fnErr error
+	err := db.WithTransaction(ctx, sqlDB, func(tx boil.ContextExecutor) error {
+		for _, k := range lockKeys {
+			if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1, hashtext($2))", lockClass, k); err != nil {
+				return err
+			}
+		}
+
+		if err := Check(c, tx, cfg, keys...); err != nil {
+			return err
+		}
+
+		var failed bool
+		failed, fnErr = fn()
+		if !failed {
+			return nil
+		}
+
+		now := time.Now()
+		for _, k := range keys {
+			if err := registerFailure(ctx, tx, cfg, k, now); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return fnErr
+}
+
+// Reset forgets all failed attempts of the keys.
+func Reset(ctx context.Context, exec boil.ContextExecutor, keys ...Key) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	_, err := models.AuthAttempts(models.AuthAttemptWhere.Key.IN(keyStrings(keys))).DeleteAll(ctx, exec)
+	return err
+}
+
+// ResetUser forgets all failed attempts of the user's username for all actions and of the user's 2FA codes and
+// current passwords, unlocking the user. Returns whether any attempts were tracked.
+func ResetUser(ctx context.Context, exec boil.ContextExecutor, user *models.User) (bool, error) {
+	keys := []Key{UserKey(ActionMFA, user.ID), UserKey(ActionPassword, user.ID)}
+	if user.Username.Valid {
+		for _, action := range Actions() {
+			keys = append(keys, UsernameKey(action, user.Username.String))
+		}
+	}
+
+	n, err := models.AuthAttempts(models.AuthAttemptWhere.Key.IN(keyStrings(keys))).DeleteAll(ctx, exec)
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
@@ -0,0 +1,49 @@
+package attempts_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/driif/echo-go-starter/internal/api/attempts"
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/server/config"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeys(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	keys := attempts.Keys(c, attempts.ActionLogin, "user1@example.com")
+	require.Len(t, keys, 2)
+	assert.Equal(t, "login:username:user1@example.com", keys[0].String())
+	assert.Equal(t, "login:ip:192.0.2.1", keys[1].String())
+
+	assert.Equal(t, "forgot-password:username:user1@example.com", attempts.UsernameKey(attempts.ActionForgotPassword, "user1@example.com").String())
+}
+
+func TestIsThrottled(t *testing.T) {
+	locked := *apierrs.TemporarilyLockedOut
+	assert.True(t, attempts.IsThrottled(&locked))
+	assert.True(t, attempts.IsThrottled(apierrs.TooManyAttempts))
+	assert.False(t, attempts.IsThrottled(apierrs.InvalidCredentials))
+	assert.False(t, attempts.IsThrottled(nil))
+}
+
+func TestGuardDisabled(t *testing.T) {
+	e := echo.New()
+	c := e.NewContext(httptest.NewRequest(http.MethodPost, "/", nil), httptest.NewRecorder())
+
+	var called bool
+	err := attempts.Guard(c, nil, config.AuthAttempts{}, attempts.Keys(c, attempts.ActionLogin, "user1@example.com"), func() (bool, error) {
+		called = true
+		return true, apierrs.InvalidCredentials
+	})
+	assert.True(t, called)
+	assert.ErrorIs(t, err, apierrs.InvalidCredentials)
+}
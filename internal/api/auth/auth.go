@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/driif/echo-go-starter/internal/api/module"
+	mdwr "github.com/driif/echo-go-starter/internal/server/net/middleware"
+)
+
+// Register adds all authentication routes to the registry.
+func Register(r *module.Registry) {
+	r.Add(
+		module.Route{
+			Method:      http.MethodPost,
+			Path:        "/login",
+			Group:       module.GroupV1Auth,
+			Handler:     postLoginHandler,
+			Description: "Log in via username and password",
+		},
+		module.Route{
+			Method:      http.MethodPost,
+			Path:        "/login/mfa",
+			Group:       module.GroupV1Auth,
+			Handler:     postLoginMFAHandler,
+			Description: "Complete a login challenged for 2FA",
+		},
+		module.Route{
+			Method:      http.MethodPost,
+			Path:        "/login/mfa/enroll",
+			Group:       module.GroupV1Auth,
+			Handler:     postLoginMFAEnrollHandler,
+			Description: "Set up 2FA during a login requiring it",
+		},
+		module.Route{
+			Method:      http.MethodGet,
+			Path:        "/oidc/providers",
+			Group:       module.GroupV1Auth,
+			Handler:     getOIDCProvidersHandler,
+			Description: "Configured external identity providers",
+		},
+		module.Route{
+			Method:      http.MethodPost,
+			Path:        "/oidc/:provider/authorize",
+			Group:       module.GroupV1Auth,
+			Handler:     postOIDCAuthorizeHandler,
+			Description: "Start a login via an external identity provider",
+		},
+		module.Route{
+			Method:      http.MethodPost,
+			Path:        "/oidc/:provider/callback",
+			Group:       module.GroupV1Auth,
+			Handler:     postOIDCCallbackHandler,
+			Description: "Complete a login via an external identity provider using the authorization code",
+		},
+		module.Route{
+			Method:      http.MethodPost,
+			Path:        "/refresh",
+			Group:       module.GroupV1Auth,
+			Handler:     postRefreshHandler,
+			Description: "Exchange a refresh token for a new token pair",
+		},
+		module.Route{
+			Method:      http.MethodPost,
+			Path:        "/logout",
+			Group:       module.GroupV1Auth,
+			Auth:        mdwr.AuthModeRequired,
+			Handler:     postLogoutHandler,
+			Description: "Revoke the current access token and optionally a refresh token",
+		},
+		module.Route{
+			Method:      http.MethodPost,
+			Path:        "/register",
+			Group:       module.GroupV1Auth,
+			Handler:     postRegisterHandler,
+			Description: "Register a new app user",
+		},
+		module.Route{
+			Method:      http.MethodPost,
+			Path:        "/register/verify",
+			Group:       module.GroupV1Auth,
+			Handler:     postVerifyEmailHandler,
+			Description: "Activate a registered user by confirming the emailed verification token",
+		},
+		module.Route{
+			Method:      http.MethodPost,
+			Path:        "/register/resend",
+			Group:       module.GroupV1Auth,
+			Handler:     postResendVerificationHandler,
+			Description: "Resend the email verification of a pending registration",
+		},
+		module.Route{
+			Method:      http.MethodPost,
+			Path:        "/forgot-password",
+			Group:       module.GroupV1Auth,
+			Handler:     postForgotPasswordHandler,
+			Description: "Send a password reset link to the user",
+		},
+		module.Route{
+			Method:      http.MethodPost,
+			Path:        "/forgot-password/complete",
+			Group:       module.GroupV1Auth,
+			Handler:     postForgotPasswordCompleteHandler,
+			Description: "Set a new password using a password reset token",
+		},
+		// public, thus attached to the root group instead of the secret protected management group
+		module.Route{
+			Method:      http.MethodGet,
+			Path:        "/-/jwks.json",
+			Group:       module.GroupRoot,
+			Handler:     getJWKSHandler,
+			Middleware:  []module.MiddlewareFactory{module.Middleware(mdwr.NoCache())},
+			Description: "Public keys used to verify JWT access tokens",
+		},
+	)
+}
@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/api/attempts"
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/api/request"
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/net/auth"
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+	"github.com/driif/echo-go-starter/pkg/auth/hashing"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/driif/echo-go-starter/pkg/strs"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+type postForgotPasswordPayload struct {
+	Username string `json:"username"`
+}
+
+func (p *postForgotPasswordPayload) Validate() []*errs.HTTPValidationErrorDetail {
+	p.Username = strs.ToUsernameFormat(p.Username)
+	if len(p.Username) == 0 {
+		return []*errs.HTTPValidationErrorDetail{request.InvalidField("username", request.InBody, "username is required")}
+	}
+
+	return nil
+}
+
+type postForgotPasswordCompletePayload struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+func (p *postForgotPasswordCompletePayload) Validate() []*errs.HTTPValidationErrorDetail {
+	var details []*errs.HTTPValidationErrorDetail
+
+	if _, err := uuid.Parse(p.Token); err != nil {
+		details = append(details, request.InvalidField("token", request.InBody, "token must be a valid UUID"))
+	}
+
+	if !auth.ValidPasswordLength(p.Password) {
+		details = append(details, request.InvalidField("password", request.InBody,
+			fmt.Sprintf("password must be between %d and %d bytes long", auth.PasswordMinLength, auth.PasswordMaxLength)))
+	}
+
+	return details
+}
+
+// postForgotPasswordHandler mails a password reset link to active users with a password.
+// Every request counts as an attempt for the username and client IP to limit the mails sent,
+// the handler always responds with 204 to prevent user enumeration.
+func postForgotPasswordHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := logs.LogFromContext(ctx)
+
+		var body postForgotPasswordPayload
+		if err := request.BindBody(c, &body); err != nil {
+			return err
+		}
+
+		// every request counts as failure, registered before checking the next request
+		err := attempts.Guard(c, s.DB, s.Config.Auth.Attempts, attempts.Keys(c, attempts.ActionForgotPassword, body.Username), func() (bool, error) {
+			return true, nil
+		})
+		if err != nil {
+			if attempts.IsThrottled(err) {
+				log.Debug().Err(err).Msg("Throttling forgot password request")
+				return err
+			}
+
+			log.Error().Err(err).Msg("Failed to register forgot password attempt")
+			return err
+		}
+
+		var (
+			user  *models.User
+			token *models.PasswordResetToken
+		)
+		err = db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			var err error
+			user, err = models.Users(
+				models.UserWhere.Username.EQ(null.StringFrom(body.Username)),
+				models.UserWhere.IsActive.EQ(true),
+				models.UserWhere.Password.IsNotNull(),
+				qm.For("UPDATE"),
+			).One(ctx, tx)
+			if err != nil {
+				return err
+			}
+
+			token = &models.PasswordResetToken{
+				UserID:     user.ID,
+				ValidUntil: time.Now().Add(s.Config.Auth.PasswordResetTokenValidity),
+			}
+
+			return token.Insert(ctx, tx, boil.Infer())
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				log.Debug().Msg("No active user with password for username, not sending password reset")
+				return c.NoContent(http.StatusNoContent)
+			}
+
+			log.Error().Err(err).Msg("Failed to create password reset token")
+			return err
+		}
+
+		if err := sendPasswordReset(ctx, s, user.Username.String, token.Token); err != nil {
+			return err
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// postForgotPasswordCompleteHandler sets a new password using an emailed password reset token.
+// All tokens of the user are revoked and the user's failed login attempts are reset.
+func postForgotPasswordCompleteHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := logs.LogFromContext(ctx)
+
+		var body postForgotPasswordCompletePayload
+		if err := request.BindBody(c, &body); err != nil {
+			return err
+		}
+
+		hash, err := hashing.Hash(body.Password, s.Config.Hashing)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to hash password")
+			return err
+		}
+
+		err = db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			token, err := models.PasswordResetTokens(
+				models.PasswordResetTokenWhere.Token.EQ(body.Token),
+				qm.Load(models.PasswordResetTokenRels.User),
+				qm.For("UPDATE"),
+			).One(ctx, tx)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return apierrs.PasswordResetTokenNotFound
+				}
+				return err
+			}
+
+			if time.Now().After(token.ValidUntil) {
+				return apierrs.PasswordResetTokenExpired
+			}
+
+			user := token.R.User
+			if !user.IsActive {
+				return apierrs.UserDeactivated
+			}
+
+			user.Password = null.StringFrom(hash)
+			if _, err := user.Update(ctx, tx, boil.Whitelist(models.UserColumns.Password, models.UserColumns.UpdatedAt)); err != nil {
+				return err
+			}
+
+			if _, err := models.PasswordResetTokens(models.PasswordResetTokenWhere.UserID.EQ(user.ID)).DeleteAll(ctx, tx); err != nil {
+				return err
+			}
+			if _, err := models.AccessTokens(models.AccessTokenWhere.UserID.EQ(user.ID)).DeleteAll(ctx, tx); err != nil {
+				return err
+			}
+			if _, err := models.RefreshTokens(models.RefreshTokenWhere.UserID.EQ(user.ID)).DeleteAll(ctx, tx); err != nil {
+				return err
+			}
+
+			return attempts.Reset(ctx, tx, attempts.UsernameKey(attempts.ActionLogin, user.Username.String))
+		})
+		if err != nil {
+			if errors.Is(err, apierrs.PasswordResetTokenNotFound) ||
+				errors.Is(err, apierrs.PasswordResetTokenExpired) ||
+				errors.Is(err, apierrs.UserDeactivated) {
+				log.Debug().Err(err).Msg("Refusing to reset password")
+				return err
+			}
+
+			log.Error().Err(err).Msg("Failed to reset password")
+			return err
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+func sendPasswordReset(ctx context.Context, s *server.Server, to string, token string) error {
+	link, err := url.Parse(s.Config.Frontend.BaseURL)
+	if err != nil {
+		logs.LogFromContext(ctx).Error().Err(err).Msg("Failed to parse frontend base URL")
+		return err
+	}
+
+	link = link.JoinPath(s.Config.Frontend.PasswordResetEndpoint)
+	link.RawQuery = url.Values{"token": []string{token}}.Encode()
+
+	if err := s.Mailer.SendPasswordReset(ctx, to, link.String()); err != nil {
+		logs.LogFromContext(ctx).Error().Err(err).Msg("Failed to send password reset")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,321 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/api/attempts"
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/api/mfa"
+	"github.com/driif/echo-go-starter/internal/api/request"
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/net/auth"
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+	"github.com/driif/echo-go-starter/pkg/auth/hashing"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/driif/echo-go-starter/pkg/strs"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+type postLoginPayload struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func (p *postLoginPayload) Validate() []*errs.HTTPValidationErrorDetail {
+	var details []*errs.HTTPValidationErrorDetail
+
+	p.Username = strs.ToUsernameFormat(p.Username)
+	if len(p.Username) == 0 {
+		details = append(details, request.InvalidField("username", request.InBody, "username is required"))
+	}
+	if len(p.Password) == 0 {
+		details = append(details, request.InvalidField("password", request.InBody, "password is required"))
+	}
+
+	return details
+}
+
+type postRefreshPayload struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+func (p *postRefreshPayload) Validate() []*errs.HTTPValidationErrorDetail {
+	if _, err := uuid.Parse(p.RefreshToken); err != nil {
+		return []*errs.HTTPValidationErrorDetail{request.InvalidField("refreshToken", request.InBody, "refreshToken must be a valid UUID")}
+	}
+
+	return nil
+}
+
+type postLogoutPayload struct {
+	RefreshToken *string `json:"refreshToken"`
+}
+
+func (p *postLogoutPayload) Validate() []*errs.HTTPValidationErrorDetail {
+	if p.RefreshToken == nil {
+		return nil
+	}
+
+	if _, err := uuid.Parse(*p.RefreshToken); err != nil {
+		return []*errs.HTTPValidationErrorDetail{request.InvalidField("refreshToken", request.InBody, "refreshToken must be a valid UUID")}
+	}
+
+	return nil
+}
+
+// dummyHash is verified against for unknown usernames, so responses take the same time regardless of the user's existence.
+var dummyHash struct {
+	once sync.Once
+	hash string
+}
+
+// postLoginHandler authenticates the user via username and password and issues a new token pair.
+// Failed attempts are tracked per username and client IP, throttled clients receive a 429 with Retry-After.
+// Users with 2FA enabled or possessing a scope requiring 2FA receive an MFA challenge instead, see postLoginMFAHandler.
+// Password hashes using a different algorithm or weaker parameters than configured are replaced on success.
+func postLoginHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := logs.LogFromContext(ctx)
+
+		var body postLoginPayload
+		if err := request.BindBody(c, &body); err != nil {
+			return err
+		}
+
+		var user *models.User
+		err := attempts.Guard(c, s.DB, s.Config.Auth.Attempts, attempts.Keys(c, attempts.ActionLogin, body.Username), func() (bool, error) {
+			var err error
+			user, err = models.Users(models.UserWhere.Username.EQ(null.StringFrom(body.Username))).One(ctx, s.DB)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return false, err
+			}
+
+			if user == nil || !user.Password.Valid {
+				dummyHash.once.Do(func() {
+					dummyHash.hash, _ = hashing.Hash("dummy-password", s.Config.Hashing)
+				})
+				_, _ = hashing.Verify(body.Password, dummyHash.hash)
+
+				log.Debug().Msg("User not found or without password")
+				return true, apierrs.InvalidCredentials
+			}
+
+			ok, err := hashing.Verify(body.Password, user.Password.String)
+			if err != nil {
+				// e.g. a legacy hash, the user has to reset the password
+				log.Warn().Err(err).Str("userID", user.ID).Msg("Unsupported password hash, treating as invalid password")
+			}
+			if !ok {
+				log.Debug().Str("userID", user.ID).Msg("Invalid password")
+				return true, apierrs.InvalidCredentials
+			}
+
+			return false, nil
+		})
+		if err != nil {
+			if attempts.IsThrottled(err) {
+				log.Debug().Err(err).Msg("Throttling login attempt")
+				return err
+			}
+			if errors.Is(err, apierrs.InvalidCredentials) {
+				return err
+			}
+
+			log.Error().Err(err).Msg("Failed to verify login attempt")
+			return err
+		}
+
+		if !user.IsActive {
+			log.Debug().Str("userID", user.ID).Msg("User is deactivated")
+			return apierrs.UserDeactivated
+		}
+
+		var rehash string
+		if hashing.NeedsRehash(user.Password.String, s.Config.Hashing) {
+			if rehash, err = hashing.Hash(body.Password, s.Config.Hashing); err != nil {
+				// the login may still succeed, we'll try again next time
+				log.Error().Err(err).Str("userID", user.ID).Msg("Failed to rehash password")
+				rehash = ""
+			}
+		}
+
+		var (
+			res       tokenResponse
+			challenge *mfaChallengeResponse
+		)
+		err = db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			if len(rehash) > 0 {
+				// only replace the hash verified above, the password might have been changed concurrently
+				n, err := models.Users(
+					models.UserWhere.ID.EQ(user.ID),
+					models.UserWhere.Password.EQ(user.Password),
+				).UpdateAll(ctx, tx, models.M{models.UserColumns.Password: rehash})
+				if err != nil {
+					return err
+				}
+				if n > 0 {
+					log.Info().Str("userID", user.ID).Str("algorithm", s.Config.Hashing.Algorithm.String()).Msg("Upgraded password hash")
+				}
+			}
+
+			res, challenge, err = completeOrChallengeLogin(ctx, tx, s, user)
+			return err
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to log in user")
+			return err
+		}
+
+		if challenge != nil {
+			log.Debug().Str("userID", user.ID).Bool("enrollmentRequired", challenge.EnrollmentRequired).Msg("Password verified, requiring 2FA")
+			return c.JSON(http.StatusOK, challenge)
+		}
+
+		return c.JSON(http.StatusOK, res)
+	}
+}
+
+// completeOrChallengeLogin completes the login of an authenticated user, unless the user has 2FA enabled or possesses
+// a scope requiring 2FA, in which case an MFA challenge is returned instead of a token pair.
+func completeOrChallengeLogin(ctx context.Context, tx boil.ContextExecutor, s *server.Server, user *models.User) (tokenResponse, *mfaChallengeResponse, error) {
+	enabled, err := mfa.Enabled(ctx, tx, user.ID)
+	if err != nil {
+		return tokenResponse{}, nil, err
+	}
+	if enabled || mfa.Required(s.Config.Auth.MFA, user) {
+		challenge, err := issueMFAChallenge(ctx, tx, s, user.ID, !enabled)
+		return tokenResponse{}, challenge, err
+	}
+
+	res, err := completeLogin(ctx, tx, s, user)
+	return res, nil, err
+}
+
+// completeLogin records the authentication and issues a new token pair.
+// Failed logins of the username are only reset here, so passing the password alone doesn't grant new 2FA attempts.
+func completeLogin(ctx context.Context, tx boil.ContextExecutor, s *server.Server, user *models.User) (tokenResponse, error) {
+	if user.Username.Valid {
+		// only the username is reset, an attacker owning a valid account must not reset the IP's failures
+		if err := attempts.Reset(ctx, tx, attempts.UsernameKey(attempts.ActionLogin, user.Username.String)); err != nil {
+			return tokenResponse{}, err
+		}
+	}
+
+	user.LastAuthenticatedAt = null.TimeFrom(time.Now())
+	if _, err := user.Update(ctx, tx, boil.Whitelist(models.UserColumns.LastAuthenticatedAt, models.UserColumns.UpdatedAt)); err != nil {
+		return tokenResponse{}, err
+	}
+
+	return issueTokens(ctx, tx, s, user)
+}
+
+// registerLoginFailure tracks the failed login, errors are only logged as the login fails anyway.
+func registerLoginFailure(ctx context.Context, s *server.Server, keys []attempts.Key) {
+	if err := attempts.RegisterFailure(ctx, s.DB, s.Config.Auth.Attempts, keys...); err != nil {
+		logs.LogFromContext(ctx).Error().Err(err).Msg("Failed to register failed login attempt")
+	}
+}
+
+// postRefreshHandler exchanges a refresh token for a new token pair, the refresh token is consumed.
+// Refresh tokens older than the configured validity are rejected.
+func postRefreshHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := logs.LogFromContext(ctx)
+
+		var body postRefreshPayload
+		if err := request.BindBody(c, &body); err != nil {
+			return err
+		}
+
+		var res tokenResponse
+		err := db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			refreshToken, err := models.RefreshTokens(
+				models.RefreshTokenWhere.Token.EQ(body.RefreshToken),
+				models.RefreshTokenWhere.CreatedAt.GT(time.Now().Add(-s.Config.Auth.RefreshTokenValidity)),
+				qm.Load(models.RefreshTokenRels.User),
+				qm.For("UPDATE"),
+			).One(ctx, tx)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return apierrs.RefreshTokenInvalid
+				}
+				return err
+			}
+
+			user := refreshToken.R.User
+			if !user.IsActive {
+				return apierrs.UserDeactivated
+			}
+
+			if _, err := refreshToken.Delete(ctx, tx); err != nil {
+				return err
+			}
+
+			res, err = issueTokens(ctx, tx, s, user)
+			return err
+		})
+		if err != nil {
+			if errors.Is(err, apierrs.RefreshTokenInvalid) || errors.Is(err, apierrs.UserDeactivated) {
+				log.Debug().Err(err).Msg("Refusing to refresh tokens")
+				return err
+			}
+
+			log.Error().Err(err).Msg("Failed to refresh tokens")
+			return err
+		}
+
+		return c.JSON(http.StatusOK, res)
+	}
+}
+
+// postLogoutHandler revokes the opaque access token used for the request and the (optionally) provided refresh token.
+func postLogoutHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user := auth.UserFromContext(ctx)
+		accessToken := auth.AccessTokenFromContext(ctx)
+
+		var body postLogoutPayload
+		if err := request.BindBody(c, &body); err != nil {
+			return err
+		}
+
+		err := db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			// JWTs can't be revoked and stay valid until they expire
+			if accessToken != nil {
+				if _, err := accessToken.Delete(ctx, tx); err != nil {
+					return err
+				}
+			}
+
+			if body.RefreshToken == nil {
+				return nil
+			}
+
+			_, err := models.RefreshTokens(
+				models.RefreshTokenWhere.Token.EQ(*body.RefreshToken),
+				models.RefreshTokenWhere.UserID.EQ(user.ID),
+			).DeleteAll(ctx, tx)
+			return err
+		})
+		if err != nil {
+			logs.LogFromContext(ctx).Error().Err(err).Msg("Failed to log out user")
+			return err
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
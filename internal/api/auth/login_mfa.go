@@ -0,0 +1,261 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/api/attempts"
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/api/mfa"
+	"github.com/driif/echo-go-starter/internal/api/request"
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+type mfaChallengeResponse struct {
+	MFARequired bool   `json:"mfaRequired"`
+	MFAToken    string `json:"mfaToken"`
+	// EnrollmentRequired is set if the user has to set up 2FA first, see postLoginMFAEnrollHandler.
+	EnrollmentRequired bool      `json:"enrollmentRequired"`
+	ValidUntil         time.Time `json:"validUntil"`
+}
+
+type postLoginMFAPayload struct {
+	MFAToken     string `json:"mfaToken"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recoveryCode"`
+}
+
+func (p *postLoginMFAPayload) Validate() []*errs.HTTPValidationErrorDetail {
+	var details []*errs.HTTPValidationErrorDetail
+
+	if _, err := uuid.Parse(p.MFAToken); err != nil {
+		details = append(details, request.InvalidField("mfaToken", request.InBody, "mfaToken must be a valid UUID"))
+	}
+
+	if (len(p.Code) == 0) == (len(p.RecoveryCode) == 0) {
+		details = append(details, request.InvalidField("code", request.InBody, "exactly one of code and recoveryCode is required"))
+	}
+
+	return details
+}
+
+type postLoginMFAEnrollPayload struct {
+	MFAToken string `json:"mfaToken"`
+}
+
+func (p *postLoginMFAEnrollPayload) Validate() []*errs.HTTPValidationErrorDetail {
+	if _, err := uuid.Parse(p.MFAToken); err != nil {
+		return []*errs.HTTPValidationErrorDetail{request.InvalidField("mfaToken", request.InBody, "mfaToken must be a valid UUID")}
+	}
+
+	return nil
+}
+
+// postLoginMFAHandler completes a login challenged for 2FA using a TOTP or recovery code and issues the token pair.
+// If the challenge required enrollment, the code confirms the enrollment and the recovery codes are returned as well.
+// The MFA token is invalidated after too many wrong codes, wrong codes count as failed logins of the user and are throttled alike.
+func postLoginMFAHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := logs.LogFromContext(ctx)
+
+		var body postLoginMFAPayload
+		if err := request.BindBody(c, &body); err != nil {
+			return err
+		}
+
+		var res tokenResponse
+		err := db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			token, err := findMFATokenForUpdate(ctx, tx, body.MFAToken)
+			if err != nil {
+				return err
+			}
+
+			user := token.R.User
+
+			// wrong codes count as failed logins, throttled users must not keep guessing using pending challenges
+			if err := attempts.Check(c, tx, s.Config.Auth.Attempts, attempts.Keys(c, attempts.ActionLogin, user.Username.String)...); err != nil {
+				return err
+			}
+
+			enabled, err := mfa.Enabled(ctx, tx, user.ID)
+			if err != nil {
+				return err
+			}
+
+			if enabled {
+				err = mfa.Verify(ctx, tx, user.ID, body.Code, body.RecoveryCode)
+			} else if len(body.Code) > 0 {
+				res.RecoveryCodes, err = mfa.Confirm(ctx, tx, s.Config.Auth.MFA, user.ID, body.Code)
+			} else {
+				// recovery codes only exist once enrollment was confirmed
+				err = apierrs.MFAInvalidCode
+			}
+			if err != nil {
+				return err
+			}
+
+			if _, err := token.Delete(ctx, tx); err != nil {
+				return err
+			}
+
+			recoveryCodes := res.RecoveryCodes
+			res, err = completeLogin(ctx, tx, s, user)
+			res.RecoveryCodes = recoveryCodes
+
+			return err
+		})
+		if err != nil {
+			if errors.Is(err, apierrs.MFAInvalidCode) || errors.Is(err, apierrs.MFANotEnrolled) {
+				log.Debug().Err(err).Msg("Invalid 2FA code")
+				registerMFAFailure(c, s, body.MFAToken)
+				return apierrs.MFAInvalidCode
+			}
+
+			if attempts.IsThrottled(err) {
+				log.Debug().Err(err).Msg("Throttling 2FA attempt")
+				return err
+			}
+
+			if errors.Is(err, apierrs.MFATokenInvalid) || errors.Is(err, apierrs.UserDeactivated) {
+				log.Debug().Err(err).Msg("Refusing to complete 2FA login")
+				return err
+			}
+
+			log.Error().Err(err).Msg("Failed to complete 2FA login")
+			return err
+		}
+
+		return c.JSON(http.StatusOK, res)
+	}
+}
+
+// postLoginMFAEnrollHandler starts the 2FA enrollment of a user challenged for 2FA without having set it up.
+func postLoginMFAEnrollHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := logs.LogFromContext(ctx)
+
+		var body postLoginMFAEnrollPayload
+		if err := request.BindBody(c, &body); err != nil {
+			return err
+		}
+
+		var enrollment mfa.Enrollment
+		err := db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			token, err := findMFATokenForUpdate(ctx, tx, body.MFAToken)
+			if err != nil {
+				return err
+			}
+
+			enrollment, err = mfa.Enroll(ctx, tx, s.Config.Auth.MFA, token.R.User)
+			return err
+		})
+		if err != nil {
+			if errors.Is(err, apierrs.MFATokenInvalid) || errors.Is(err, apierrs.UserDeactivated) || errors.Is(err, apierrs.MFAAlreadyEnabled) {
+				log.Debug().Err(err).Msg("Refusing to start 2FA enrollment")
+				return err
+			}
+
+			log.Error().Err(err).Msg("Failed to start 2FA enrollment")
+			return err
+		}
+
+		return c.JSON(http.StatusOK, enrollment)
+	}
+}
+
+// issueMFAChallenge creates a short-lived MFA token, replacing all previous MFA tokens of the user.
+func issueMFAChallenge(ctx context.Context, tx boil.ContextExecutor, s *server.Server, userID string, enrollmentRequired bool) (*mfaChallengeResponse, error) {
+	if _, err := models.MfaTokens(models.MfaTokenWhere.UserID.EQ(userID)).DeleteAll(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	token := &models.MfaToken{
+		UserID:     userID,
+		ValidUntil: time.Now().Add(s.Config.Auth.MFA.TokenValidity),
+	}
+	if err := token.Insert(ctx, tx, boil.Infer()); err != nil {
+		return nil, err
+	}
+
+	return &mfaChallengeResponse{
+		MFARequired:        true,
+		MFAToken:           token.Token,
+		EnrollmentRequired: enrollmentRequired,
+		ValidUntil:         token.ValidUntil,
+	}, nil
+}
+
+func findMFATokenForUpdate(ctx context.Context, tx boil.ContextExecutor, mfaToken string) (*models.MfaToken, error) {
+	token, err := models.MfaTokens(
+		models.MfaTokenWhere.Token.EQ(mfaToken),
+		models.MfaTokenWhere.ValidUntil.GT(time.Now()),
+		qm.Load(models.MfaTokenRels.User),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apierrs.MFATokenInvalid
+		}
+		return nil, err
+	}
+
+	if !token.R.User.IsActive {
+		return nil, apierrs.UserDeactivated
+	}
+
+	return token, nil
+}
+
+// registerMFAFailure counts a wrong code for the MFA token, deleting it once the maximum attempts are reached.
+// The failure is tracked as failed login attempt as well, so new challenges can't be used to guess codes.
+// Errors are only logged as the request fails anyway.
+func registerMFAFailure(c echo.Context, s *server.Server, mfaToken string) {
+	ctx := c.Request().Context()
+
+	var username string
+	err := db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+		token, err := models.MfaTokens(
+			models.MfaTokenWhere.Token.EQ(mfaToken),
+			qm.Load(models.MfaTokenRels.User),
+			qm.For("UPDATE"),
+		).One(ctx, tx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+
+		username = token.R.User.Username.String
+
+		token.Attempts++
+		if token.Attempts >= s.Config.Auth.MFA.TokenMaxAttempts {
+			_, err = token.Delete(ctx, tx)
+			return err
+		}
+
+		_, err = token.Update(ctx, tx, boil.Whitelist(models.MfaTokenColumns.Attempts, models.MfaTokenColumns.UpdatedAt))
+		return err
+	})
+	if err != nil {
+		logs.LogFromContext(ctx).Error().Err(err).Msg("Failed to register failed 2FA attempt")
+		return
+	}
+
+	if len(username) > 0 {
+		registerLoginFailure(ctx, s, attempts.Keys(c, attempts.ActionLogin, username))
+	}
+}
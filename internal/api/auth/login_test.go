@@ -0,0 +1,242 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/test"
+	"github.com/driif/echo-go-starter/pkg/auth/lockout"
+	"github.com/driif/echo-go-starter/pkg/auth/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tokenResponse struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	TokenType    string `json:"tokenType"`
+}
+
+type mfaChallengeResponse struct {
+	MFARequired        bool   `json:"mfaRequired"`
+	MFAToken           string `json:"mfaToken"`
+	EnrollmentRequired bool   `json:"enrollmentRequired"`
+}
+
+func TestPostLoginSuccess(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		fix := test.Fixtures()
+		payload := test.GenericPayload{
+			"username": fix.User1.Username.String,
+			"password": test.PlainTestUserPassword,
+		}
+
+		res := test.PerformRequest(t, s, "POST", "/v1/auth/login", payload, nil)
+		require.Equal(t, http.StatusOK, res.Result().StatusCode)
+
+		var response tokenResponse
+		test.ParseResponseBody(t, res, &response)
+		assert.NotEmpty(t, response.AccessToken)
+		assert.NotEmpty(t, response.RefreshToken)
+		assert.Equal(t, "bearer", response.TokenType)
+	})
+}
+
+func TestPostLoginInvalidCredentials(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		fix := test.Fixtures()
+		payload := test.GenericPayload{
+			"username": fix.User1.Username.String,
+			"password": "not my password",
+		}
+
+		res := test.PerformRequest(t, s, "POST", "/v1/auth/login", payload, nil)
+		test.RequireHTTPError(t, res, apierrs.InvalidCredentials)
+
+		payload["username"] = "unknown@example.com"
+		res = test.PerformRequest(t, s, "POST", "/v1/auth/login", payload, nil)
+		test.RequireHTTPError(t, res, apierrs.InvalidCredentials)
+	})
+}
+
+func TestPostLoginDeactivated(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		fix := test.Fixtures()
+		payload := test.GenericPayload{
+			"username": fix.UserDeactivated.Username.String,
+			"password": test.PlainTestUserPassword,
+		}
+
+		res := test.PerformRequest(t, s, "POST", "/v1/auth/login", payload, nil)
+		test.RequireHTTPError(t, res, apierrs.UserDeactivated)
+	})
+}
+
+func TestPostLoginThrottled(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		fix := test.Fixtures()
+		payload := test.GenericPayload{
+			"username": fix.User1.Username.String,
+			"password": "not my password",
+		}
+
+		for i := 0; i < s.Config.Auth.Attempts.Username.FreeAttempts; i++ {
+			res := test.PerformRequest(t, s, "POST", "/v1/auth/login", payload, nil)
+			test.RequireHTTPError(t, res, apierrs.InvalidCredentials)
+		}
+
+		// even the correct password is rejected until the delay has passed
+		payload["password"] = test.PlainTestUserPassword
+		res := test.PerformRequest(t, s, "POST", "/v1/auth/login", payload, nil)
+		test.RequireHTTPError(t, res, apierrs.TooManyAttempts)
+		assert.NotEmpty(t, res.Header().Get("Retry-After"))
+	})
+}
+
+func TestPostLoginLockout(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		s.Config.Auth.Attempts.Username = lockout.Policy{
+			FreeAttempts:     10,
+			LockoutThreshold: 3,
+			LockoutDuration:  time.Minute,
+		}
+
+		fix := test.Fixtures()
+		payload := test.GenericPayload{
+			"username": fix.User1.Username.String,
+			"password": "not my password",
+		}
+
+		for i := 0; i < 3; i++ {
+			res := test.PerformRequest(t, s, "POST", "/v1/auth/login", payload, nil)
+			test.RequireHTTPError(t, res, apierrs.InvalidCredentials)
+		}
+
+		payload["password"] = test.PlainTestUserPassword
+		res := test.PerformRequest(t, s, "POST", "/v1/auth/login", payload, nil)
+		test.RequireHTTPError(t, res, apierrs.TemporarilyLockedOut)
+	})
+}
+
+func TestPostLoginRehash(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		ctx := context.Background()
+		fix := test.Fixtures()
+		payload := test.GenericPayload{
+			"username": fix.User2.Username.String,
+			"password": test.PlainTestUserPassword,
+		}
+
+		res := test.PerformRequest(t, s, "POST", "/v1/auth/login", payload, nil)
+		require.Equal(t, http.StatusOK, res.Result().StatusCode)
+
+		user, err := models.FindUser(ctx, s.DB, fix.User2.ID)
+		require.NoError(t, err)
+		assert.NotEqual(t, test.BcryptTestUserPassword, user.Password.String)
+		assert.True(t, strings.HasPrefix(user.Password.String, "$argon2id$"))
+
+		// the upgraded hash still verifies the password
+		res = test.PerformRequest(t, s, "POST", "/v1/auth/login", payload, nil)
+		require.Equal(t, http.StatusOK, res.Result().StatusCode)
+	})
+}
+
+func TestPostLoginMFAChallenge(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		fix := test.Fixtures()
+		payload := test.GenericPayload{
+			"username": fix.UserMFA.Username.String,
+			"password": test.PlainTestUserPassword,
+		}
+
+		res := test.PerformRequest(t, s, "POST", "/v1/auth/login", payload, nil)
+		require.Equal(t, http.StatusOK, res.Result().StatusCode)
+
+		var challenge mfaChallengeResponse
+		test.ParseResponseBody(t, res, &challenge)
+		require.True(t, challenge.MFARequired)
+		require.NotEmpty(t, challenge.MFAToken)
+		assert.False(t, challenge.EnrollmentRequired)
+
+		res = test.PerformRequest(t, s, "POST", "/v1/auth/login/mfa", test.GenericPayload{
+			"mfaToken": challenge.MFAToken,
+			"code":     "000000",
+		}, nil)
+		test.RequireHTTPError(t, res, apierrs.MFAInvalidCode)
+
+		code, err := totp.Code(test.TestUserTOTPSecret, totp.Step(time.Now()))
+		require.NoError(t, err)
+
+		res = test.PerformRequest(t, s, "POST", "/v1/auth/login/mfa", test.GenericPayload{
+			"mfaToken": challenge.MFAToken,
+			"code":     code,
+		}, nil)
+		require.Equal(t, http.StatusOK, res.Result().StatusCode)
+
+		var response tokenResponse
+		test.ParseResponseBody(t, res, &response)
+		assert.NotEmpty(t, response.AccessToken)
+		assert.NotEmpty(t, response.RefreshToken)
+	})
+}
+
+func TestPostLoginMFALockout(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		s.Config.Auth.Attempts.Username = lockout.Policy{
+			FreeAttempts:     10,
+			LockoutThreshold: 3,
+			LockoutDuration:  time.Minute,
+		}
+
+		fix := test.Fixtures()
+		payload := test.GenericPayload{
+			"username": fix.UserMFA.Username.String,
+			"password": test.PlainTestUserPassword,
+		}
+
+		login := func() mfaChallengeResponse {
+			t.Helper()
+
+			res := test.PerformRequest(t, s, "POST", "/v1/auth/login", payload, nil)
+			require.Equal(t, http.StatusOK, res.Result().StatusCode)
+
+			var challenge mfaChallengeResponse
+			test.ParseResponseBody(t, res, &challenge)
+			require.True(t, challenge.MFARequired)
+
+			return challenge
+		}
+
+		pending := login()
+
+		// passing the password again must not reset the failures of wrong codes
+		for i := 0; i < 3; i++ {
+			challenge := login()
+
+			res := test.PerformRequest(t, s, "POST", "/v1/auth/login/mfa", test.GenericPayload{
+				"mfaToken": challenge.MFAToken,
+				"code":     "000000",
+			}, nil)
+			test.RequireHTTPError(t, res, apierrs.MFAInvalidCode)
+		}
+
+		res := test.PerformRequest(t, s, "POST", "/v1/auth/login", payload, nil)
+		test.RequireHTTPError(t, res, apierrs.TemporarilyLockedOut)
+
+		// challenges issued before are throttled as well
+		code, err := totp.Code(test.TestUserTOTPSecret, totp.Step(time.Now()))
+		require.NoError(t, err)
+
+		res = test.PerformRequest(t, s, "POST", "/v1/auth/login/mfa", test.GenericPayload{
+			"mfaToken": pending.MFAToken,
+			"code":     code,
+		}, nil)
+		test.RequireHTTPError(t, res, apierrs.TemporarilyLockedOut)
+	})
+}
@@ -0,0 +1,316 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/api/request"
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/net/auth"
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+	"github.com/driif/echo-go-starter/pkg/auth/oidc"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/driif/echo-go-starter/pkg/strs"
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+type oidcProviderResponse struct {
+	Name string `json:"name"`
+}
+
+type oidcProvidersResponse struct {
+	Items []oidcProviderResponse `json:"items"`
+}
+
+type oidcAuthorizeResponse struct {
+	AuthorizationURL string    `json:"authorizationUrl"`
+	State            string    `json:"state"`
+	ValidUntil       time.Time `json:"validUntil"`
+}
+
+type postOIDCCallbackPayload struct {
+	Code  string `json:"code"`
+	State string `json:"state"`
+}
+
+func (p *postOIDCCallbackPayload) Validate() []*errs.HTTPValidationErrorDetail {
+	var details []*errs.HTTPValidationErrorDetail
+
+	if len(p.Code) == 0 {
+		details = append(details, request.InvalidField("code", request.InBody, "code is required"))
+	}
+	if len(p.State) == 0 {
+		details = append(details, request.InvalidField("state", request.InBody, "state is required"))
+	}
+
+	return details
+}
+
+// getOIDCProvidersHandler lists the names of the configured identity providers.
+func getOIDCProvidersHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		res := oidcProvidersResponse{Items: make([]oidcProviderResponse, 0, len(s.OIDC))}
+		for name := range s.OIDC {
+			res.Items = append(res.Items, oidcProviderResponse{Name: name})
+		}
+
+		sort.Slice(res.Items, func(i, j int) bool { return res.Items[i].Name < res.Items[j].Name })
+
+		return c.JSON(http.StatusOK, res)
+	}
+}
+
+// postOIDCAuthorizeHandler starts a login via the identity provider, returning the URL the user has to be redirected to.
+// The state, nonce and PKCE code verifier are persisted until the login is completed via postOIDCCallbackHandler.
+func postOIDCAuthorizeHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := logs.LogFromContext(ctx)
+
+		provider, ok := s.OIDC[c.Param("provider")]
+		if !ok {
+			return apierrs.OIDCProviderNotFound
+		}
+
+		state, err := oidc.GenerateRandomString()
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to generate OIDC state")
+			return err
+		}
+		nonce, err := oidc.GenerateRandomString()
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to generate OIDC nonce")
+			return err
+		}
+		verifier, challenge, err := oidc.GeneratePKCE()
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to generate PKCE code verifier")
+			return err
+		}
+
+		authorizationURL, err := provider.AuthCodeURL(ctx, state, nonce, challenge)
+		if err != nil {
+			log.Error().Err(err).Str("provider", provider.Config.Name).Msg("Failed to build OIDC authorization URL")
+			return apierrs.OIDCProviderUnavailable
+		}
+
+		authRequest := &models.OidcAuthRequest{
+			State:        state,
+			Provider:     provider.Config.Name,
+			Nonce:        nonce,
+			CodeVerifier: verifier,
+			ValidUntil:   time.Now().Add(s.Config.Auth.OIDC.AuthRequestValidity),
+		}
+		if err := authRequest.Insert(ctx, s.DB, boil.Infer()); err != nil {
+			log.Error().Err(err).Msg("Failed to insert OIDC auth request")
+			return err
+		}
+
+		return c.JSON(http.StatusOK, oidcAuthorizeResponse{
+			AuthorizationURL: authorizationURL,
+			State:            state,
+			ValidUntil:       authRequest.ValidUntil,
+		})
+	}
+}
+
+// postOIDCCallbackHandler completes a login via the identity provider by exchanging the authorization code and
+// verifying the ID token. The external identity is resolved to a user, which is linked by a verified email address
+// or provisioned if configured. Like postLoginHandler, users requiring 2FA receive an MFA challenge instead of tokens.
+func postOIDCCallbackHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := logs.LogFromContext(ctx)
+
+		provider, ok := s.OIDC[c.Param("provider")]
+		if !ok {
+			return apierrs.OIDCProviderNotFound
+		}
+
+		var body postOIDCCallbackPayload
+		if err := request.BindBody(c, &body); err != nil {
+			return err
+		}
+
+		// the auth request is consumed before contacting the provider, so the state can't be replayed
+		var authRequest *models.OidcAuthRequest
+		err := db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			var err error
+			authRequest, err = models.OidcAuthRequests(
+				models.OidcAuthRequestWhere.State.EQ(body.State),
+				models.OidcAuthRequestWhere.Provider.EQ(provider.Config.Name),
+				models.OidcAuthRequestWhere.ValidUntil.GT(time.Now()),
+				qm.For("UPDATE"),
+			).One(ctx, tx)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return apierrs.OIDCStateInvalid
+				}
+				return err
+			}
+
+			_, err = authRequest.Delete(ctx, tx)
+			return err
+		})
+		if err != nil {
+			if errors.Is(err, apierrs.OIDCStateInvalid) {
+				log.Debug().Err(err).Msg("Invalid OIDC state")
+				return err
+			}
+
+			log.Error().Err(err).Msg("Failed to consume OIDC auth request")
+			return err
+		}
+
+		tokens, err := provider.Exchange(ctx, body.Code, authRequest.CodeVerifier)
+		if err != nil {
+			if errors.Is(err, oidc.ErrExchangeFailed) {
+				log.Warn().Err(err).Str("provider", provider.Config.Name).Msg("Failed to exchange OIDC authorization code")
+				return apierrs.OIDCAuthenticationFailed
+			}
+
+			log.Error().Err(err).Str("provider", provider.Config.Name).Msg("Failed to reach OIDC provider")
+			return apierrs.OIDCProviderUnavailable
+		}
+
+		claims, err := provider.VerifyIDToken(ctx, tokens.IDToken, authRequest.Nonce, time.Now())
+		if err != nil {
+			if errors.Is(err, oidc.ErrInvalidIDToken) || errors.Is(err, oidc.ErrUnknownKey) {
+				log.Warn().Err(err).Str("provider", provider.Config.Name).Msg("Invalid OIDC ID token")
+				return apierrs.OIDCAuthenticationFailed
+			}
+
+			log.Error().Err(err).Str("provider", provider.Config.Name).Msg("Failed to verify OIDC ID token")
+			return apierrs.OIDCProviderUnavailable
+		}
+
+		var (
+			user      *models.User
+			res       tokenResponse
+			challenge *mfaChallengeResponse
+		)
+		err = db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			var err error
+			user, err = resolveOIDCUser(ctx, tx, s, provider.Config, claims)
+			if err != nil {
+				return err
+			}
+
+			if !user.IsActive {
+				return apierrs.UserDeactivated
+			}
+
+			res, challenge, err = completeOrChallengeLogin(ctx, tx, s, user)
+			return err
+		})
+		if err != nil {
+			var httpErr *errs.HTTPError
+			if errors.As(err, &httpErr) {
+				log.Debug().Err(err).Str("provider", provider.Config.Name).Msg("Refusing OIDC login")
+				return err
+			}
+
+			log.Error().Err(err).Str("provider", provider.Config.Name).Msg("Failed to log in user via OIDC")
+			return err
+		}
+
+		if challenge != nil {
+			log.Debug().Str("userID", user.ID).Bool("enrollmentRequired", challenge.EnrollmentRequired).Msg("OIDC identity verified, requiring 2FA")
+			return c.JSON(http.StatusOK, challenge)
+		}
+
+		return c.JSON(http.StatusOK, res)
+	}
+}
+
+// resolveOIDCUser returns the user linked to the external identity. Unknown identities are linked to an existing user
+// with the same (verified) email address if the provider is trusted to do so, or otherwise provisioned as new user.
+func resolveOIDCUser(ctx context.Context, tx boil.ContextExecutor, s *server.Server, provider oidc.ProviderConfig, claims *oidc.Claims) (*models.User, error) {
+	log := logs.LogFromContext(ctx)
+	now := time.Now()
+
+	identity, err := models.Identities(
+		models.IdentityWhere.Provider.EQ(provider.Name),
+		models.IdentityWhere.Subject.EQ(claims.Subject),
+		qm.Load(models.IdentityRels.User),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if identity != nil {
+		identity.Email = null.NewString(claims.Email, len(claims.Email) > 0)
+		identity.LastLoginAt = null.TimeFrom(now)
+		if _, err := identity.Update(ctx, tx, boil.Whitelist(models.IdentityColumns.Email, models.IdentityColumns.LastLoginAt, models.IdentityColumns.UpdatedAt)); err != nil {
+			return nil, err
+		}
+
+		return identity.R.User, nil
+	}
+
+	username := strs.ToUsernameFormat(claims.Email)
+	verified := bool(claims.EmailVerified) && auth.ValidUsername(username)
+
+	var user *models.User
+	if verified {
+		user, err = models.Users(models.UserWhere.Username.EQ(null.StringFrom(username))).One(ctx, tx)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+	}
+
+	switch {
+	case user != nil && !provider.LinkByEmail:
+		return nil, apierrs.OIDCUserExists
+	case user != nil:
+		log.Info().Str("userID", user.ID).Str("provider", provider.Name).Msg("Linking OIDC identity to existing user by email")
+	case !s.Config.Auth.OIDC.AutoProvision:
+		return nil, apierrs.OIDCIdentityNotLinked
+	case !verified:
+		return nil, apierrs.OIDCEmailNotVerified
+	default:
+		user = &models.User{
+			Username: null.StringFrom(username),
+			IsActive: true,
+			Scopes:   types.StringArray(s.Config.Auth.OIDC.DefaultUserScopes),
+		}
+		if err := user.Insert(ctx, tx, boil.Infer()); err != nil {
+			return nil, err
+		}
+
+		profile := &models.AppUserProfile{
+			UserID:      user.ID,
+			DisplayName: null.NewString(claims.Name, len(claims.Name) > 0),
+		}
+		if err := profile.Insert(ctx, tx, boil.Infer()); err != nil {
+			return nil, err
+		}
+
+		log.Info().Str("userID", user.ID).Str("provider", provider.Name).Msg("Provisioned user for OIDC identity")
+	}
+
+	identity = &models.Identity{
+		UserID:      user.ID,
+		Provider:    provider.Name,
+		Subject:     claims.Subject,
+		Email:       null.NewString(claims.Email, len(claims.Email) > 0),
+		LastLoginAt: null.TimeFrom(now),
+	}
+	if err := identity.Insert(ctx, tx, boil.Infer()); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
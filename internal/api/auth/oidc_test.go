@@ -0,0 +1,67 @@
+package auth_test
+
+import (
+	"net/http"
+	"testing"
+
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type oidcAuthorizeResponse struct {
+	AuthorizationURL string `json:"authorizationUrl"`
+	State            string `json:"state"`
+}
+
+func TestPostOIDCCallbackStateConsumed(t *testing.T) {
+	provider := test.NewOIDCProvider(t)
+
+	test.E2e(t, func(s *server.Server) {
+		s.Config.Auth.OIDC.AutoProvision = true
+		provider.Register(s, "test")
+
+		res := test.PerformRequest(t, s, "POST", "/v1/auth/oidc/test/authorize", nil, nil)
+		require.Equal(t, http.StatusOK, res.Result().StatusCode)
+
+		var authorize oidcAuthorizeResponse
+		test.ParseResponseBody(t, res, &authorize)
+		require.NotEmpty(t, authorize.State)
+
+		code, state := provider.Authorize(authorize.AuthorizationURL)
+		require.Equal(t, authorize.State, state)
+
+		payload := test.GenericPayload{
+			"code":  code,
+			"state": state,
+		}
+
+		res = test.PerformRequest(t, s, "POST", "/v1/auth/oidc/test/callback", payload, nil)
+		require.Equal(t, http.StatusOK, res.Result().StatusCode)
+
+		var response tokenResponse
+		test.ParseResponseBody(t, res, &response)
+		assert.NotEmpty(t, response.AccessToken)
+		assert.NotEmpty(t, response.RefreshToken)
+
+		// the state was consumed by the first callback and can't be replayed
+		res = test.PerformRequest(t, s, "POST", "/v1/auth/oidc/test/callback", payload, nil)
+		test.RequireHTTPError(t, res, apierrs.OIDCStateInvalid)
+	})
+}
+
+func TestPostOIDCCallbackUnknownState(t *testing.T) {
+	provider := test.NewOIDCProvider(t)
+
+	test.E2e(t, func(s *server.Server) {
+		provider.Register(s, "test")
+
+		res := test.PerformRequest(t, s, "POST", "/v1/auth/oidc/test/callback", test.GenericPayload{
+			"code":  "code",
+			"state": "unknown",
+		}, nil)
+		test.RequireHTTPError(t, res, apierrs.OIDCStateInvalid)
+	})
+}
@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/api/request"
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/net/auth"
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+	"github.com/driif/echo-go-starter/pkg/auth/hashing"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/driif/echo-go-starter/pkg/strs"
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+type postRegisterPayload struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// Validate normalizes the username and checks it to be a valid email address.
+func (p *postRegisterPayload) Validate() []*errs.HTTPValidationErrorDetail {
+	var details []*errs.HTTPValidationErrorDetail
+
+	p.Username = strs.ToUsernameFormat(p.Username)
+	if !auth.ValidUsername(p.Username) {
+		details = append(details, request.InvalidField("username", request.InBody, "username must be a valid email address"))
+	}
+
+	if !auth.ValidPasswordLength(p.Password) {
+		details = append(details, request.InvalidField("password", request.InBody,
+			fmt.Sprintf("password must be between %d and %d bytes long", auth.PasswordMinLength, auth.PasswordMaxLength)))
+	}
+
+	return details
+}
+
+type registerResponse struct {
+	ID                   string `json:"id"`
+	Username             string `json:"username"`
+	RequiresVerification bool   `json:"requiresVerification"`
+}
+
+// postRegisterHandler creates a new user with the default user scopes and an empty profile. If email verification
+// is required, the user stays inactive until the token sent via mail is confirmed.
+func postRegisterHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := logs.LogFromContext(ctx)
+
+		var body postRegisterPayload
+		if err := request.BindBody(c, &body); err != nil {
+			return err
+		}
+
+		hash, err := hashing.Hash(body.Password, s.Config.Hashing)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to hash password")
+			return err
+		}
+
+		requiresVerification := s.Config.Auth.RegistrationRequiresVerification
+
+		user := &models.User{
+			Username: null.StringFrom(body.Username),
+			Password: null.StringFrom(hash),
+			IsActive: !requiresVerification,
+			Scopes:   types.StringArray(s.Config.Auth.DefaultUserScopes),
+		}
+
+		err = db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			exists, err := models.Users(models.UserWhere.Username.EQ(user.Username)).Exists(ctx, tx)
+			if err != nil {
+				return err
+			}
+			if exists {
+				return apierrs.UserExists
+			}
+
+			if err := user.Insert(ctx, tx, boil.Infer()); err != nil {
+				return err
+			}
+
+			profile := &models.AppUserProfile{UserID: user.ID}
+			if err := profile.Insert(ctx, tx, boil.Infer()); err != nil {
+				return err
+			}
+
+			if !requiresVerification {
+				return nil
+			}
+
+			token, err := createEmailVerificationToken(ctx, tx, s, user.ID)
+			if err != nil {
+				return err
+			}
+
+			return db.OnCommit(ctx, func(ctx context.Context) {
+				if err := sendEmailVerification(ctx, s, user.Username.String, token.Token); err != nil {
+					// the registration succeeded nevertheless, the user may request another mail
+					log.Warn().Err(err).Str("userID", user.ID).Msg("Registered user without sending email verification")
+				}
+			})
+		})
+		if err != nil {
+			if errors.Is(err, apierrs.UserExists) || db.IsUniqueViolation(err) {
+				log.Debug().Msg("User with given username already exists")
+				return apierrs.UserExists
+			}
+
+			log.Error().Err(err).Msg("Failed to register user")
+			return err
+		}
+
+		log.Info().Str("userID", user.ID).Bool("requiresVerification", requiresVerification).Msg("Registered user")
+
+		return c.JSON(http.StatusCreated, registerResponse{
+			ID:                   user.ID,
+			Username:             user.Username.String,
+			RequiresVerification: requiresVerification,
+		})
+	}
+}
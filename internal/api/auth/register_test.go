@@ -0,0 +1,104 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/mailer/transport"
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type registerResponse struct {
+	ID                   string `json:"id"`
+	Username             string `json:"username"`
+	RequiresVerification bool   `json:"requiresVerification"`
+}
+
+func TestPostRegisterSuccess(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		payload := test.GenericPayload{
+			"username": "  New.User@Example.com ",
+			"password": test.PlainTestUserPassword + "123",
+		}
+
+		res := test.PerformRequest(t, s, "POST", "/v1/auth/register", payload, nil)
+		require.Equal(t, http.StatusCreated, res.Result().StatusCode)
+
+		var response registerResponse
+		test.ParseResponseBody(t, res, &response)
+		assert.Equal(t, "new.user@example.com", response.Username)
+		assert.False(t, response.RequiresVerification)
+
+		res = test.PerformRequest(t, s, "POST", "/v1/auth/login", payload, nil)
+		require.Equal(t, http.StatusOK, res.Result().StatusCode)
+
+		// registering the same username again is refused
+		res = test.PerformRequest(t, s, "POST", "/v1/auth/register", payload, nil)
+		test.RequireHTTPError(t, res, apierrs.UserExists)
+	})
+}
+
+func TestPostRegisterVerify(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		ctx := context.Background()
+		s.Config.Auth.RegistrationRequiresVerification = true
+
+		payload := test.GenericPayload{
+			"username": "verify@example.com",
+			"password": test.PlainTestUserPassword + "123",
+		}
+
+		res := test.PerformRequest(t, s, "POST", "/v1/auth/register", payload, nil)
+		require.Equal(t, http.StatusCreated, res.Result().StatusCode)
+
+		var response registerResponse
+		test.ParseResponseBody(t, res, &response)
+		require.True(t, response.RequiresVerification)
+
+		token, err := models.EmailVerificationTokens(models.EmailVerificationTokenWhere.UserID.EQ(response.ID)).One(ctx, s.DB)
+		require.NoError(t, err)
+
+		// the verification is mailed once the registration committed
+		mail := s.Mailer.Transport.(*transport.MockMailTransport).GetLastSentMail()
+		require.NotNil(t, mail)
+		assert.Equal(t, []string{"verify@example.com"}, mail.To)
+		assert.Contains(t, string(mail.HTML), token.Token)
+
+		// unverified users stay inactive
+		res = test.PerformRequest(t, s, "POST", "/v1/auth/login", payload, nil)
+		test.RequireHTTPError(t, res, apierrs.UserDeactivated)
+
+		res = test.PerformRequest(t, s, "POST", "/v1/auth/register/verify", test.GenericPayload{"token": token.Token}, nil)
+		require.Equal(t, http.StatusNoContent, res.Result().StatusCode)
+
+		res = test.PerformRequest(t, s, "POST", "/v1/auth/login", payload, nil)
+		require.Equal(t, http.StatusOK, res.Result().StatusCode)
+
+		// tokens can only be used once
+		res = test.PerformRequest(t, s, "POST", "/v1/auth/register/verify", test.GenericPayload{"token": token.Token}, nil)
+		test.RequireHTTPError(t, res, apierrs.EmailVerificationTokenNotFound)
+	})
+}
+
+func TestPostResendVerificationThrottled(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		payload := test.GenericPayload{
+			"username": "resend@example.com",
+		}
+
+		for i := 0; i < s.Config.Auth.Attempts.Username.FreeAttempts; i++ {
+			res := test.PerformRequest(t, s, "POST", "/v1/auth/register/resend", payload, nil)
+			require.Equal(t, http.StatusNoContent, res.Result().StatusCode)
+		}
+
+		// throttled regardless of whether a pending registration exists
+		res := test.PerformRequest(t, s, "POST", "/v1/auth/register/resend", payload, nil)
+		test.RequireHTTPError(t, res, apierrs.TooManyAttempts)
+	})
+}
@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/config"
+	"github.com/driif/echo-go-starter/pkg/auth/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+const tokenTypeBearer = "bearer"
+
+type tokenResponse struct {
+	AccessToken  string    `json:"accessToken"`
+	RefreshToken string    `json:"refreshToken"`
+	TokenType    string    `json:"tokenType"`
+	ExpiresIn    int64     `json:"expiresIn"`
+	ValidUntil   time.Time `json:"validUntil"`
+	// RecoveryCodes are only returned once after completing a 2FA enrollment during login.
+	RecoveryCodes []string `json:"recoveryCodes,omitempty"`
+}
+
+// issueTokens creates a new access and refresh token pair for the user. Depending on the configured
+// token strategy, the access token is either persisted (opaque) or a signed JWT, refresh tokens are always persisted.
+func issueTokens(ctx context.Context, tx boil.ContextExecutor, s *server.Server, user *models.User) (tokenResponse, error) {
+	var (
+		res tokenResponse
+		err error
+	)
+	if s.Config.Auth.TokenStrategy == config.TokenStrategyJWT {
+		res, err = issueJWT(s, user)
+	} else {
+		res, err = issueOpaqueToken(ctx, tx, s, user)
+	}
+	if err != nil {
+		return tokenResponse{}, err
+	}
+
+	refreshToken := &models.RefreshToken{
+		UserID: user.ID,
+	}
+	if err := refreshToken.Insert(ctx, tx, boil.Infer()); err != nil {
+		return tokenResponse{}, err
+	}
+
+	res.RefreshToken = refreshToken.Token
+	res.TokenType = tokenTypeBearer
+
+	return res, nil
+}
+
+func issueOpaqueToken(ctx context.Context, tx boil.ContextExecutor, s *server.Server, user *models.User) (tokenResponse, error) {
+	accessToken := &models.AccessToken{
+		ValidUntil: time.Now().Add(s.Config.Auth.AccessTokenValidity),
+		UserID:     user.ID,
+	}
+	if err := accessToken.Insert(ctx, tx, boil.Infer()); err != nil {
+		return tokenResponse{}, err
+	}
+
+	return tokenResponse{
+		AccessToken: accessToken.Token,
+		ExpiresIn:   int64(s.Config.Auth.AccessTokenValidity.Seconds()),
+		ValidUntil:  accessToken.ValidUntil,
+	}, nil
+}
+
+func issueJWT(s *server.Server, user *models.User) (tokenResponse, error) {
+	token, claims, err := s.JWT.Issue(user.ID, user.Username.String, user.Scopes, time.Now())
+	if err != nil {
+		return tokenResponse{}, err
+	}
+
+	return tokenResponse{
+		AccessToken: token,
+		ExpiresIn:   int64(s.JWT.Validity().Seconds()),
+		ValidUntil:  time.Unix(claims.ExpiresAt, 0),
+	}, nil
+}
+
+// getJWKSHandler publishes the public keys used to verify JWTs, an empty set is returned for HS256 or without JWT keys.
+func getJWKSHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if s.JWT == nil {
+			return c.JSON(http.StatusOK, jwt.JSONWebKeySet{Keys: []jwt.JSONWebKey{}})
+		}
+
+		return c.JSON(http.StatusOK, s.JWT.JWKS())
+	}
+}
@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/api/attempts"
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/api/request"
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/driif/echo-go-starter/pkg/strs"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+type postVerifyEmailPayload struct {
+	Token string `json:"token"`
+}
+
+func (p *postVerifyEmailPayload) Validate() []*errs.HTTPValidationErrorDetail {
+	if _, err := uuid.Parse(p.Token); err != nil {
+		return []*errs.HTTPValidationErrorDetail{request.InvalidField("token", request.InBody, "token must be a valid UUID")}
+	}
+
+	return nil
+}
+
+type postResendVerificationPayload struct {
+	Username string `json:"username"`
+}
+
+func (p *postResendVerificationPayload) Validate() []*errs.HTTPValidationErrorDetail {
+	p.Username = strs.ToUsernameFormat(p.Username)
+	if len(p.Username) == 0 {
+		return []*errs.HTTPValidationErrorDetail{request.InvalidField("username", request.InBody, "username is required")}
+	}
+
+	return nil
+}
+
+// postVerifyEmailHandler activates the user owning the verification token and removes all of the user's verification tokens.
+func postVerifyEmailHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := logs.LogFromContext(ctx)
+
+		var body postVerifyEmailPayload
+		if err := request.BindBody(c, &body); err != nil {
+			return err
+		}
+
+		err := db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			token, err := models.EmailVerificationTokens(
+				models.EmailVerificationTokenWhere.Token.EQ(body.Token),
+				qm.Load(models.EmailVerificationTokenRels.User),
+				qm.For("UPDATE"),
+			).One(ctx, tx)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return apierrs.EmailVerificationTokenNotFound
+				}
+				return err
+			}
+
+			if time.Now().After(token.ValidUntil) {
+				return apierrs.EmailVerificationTokenExpired
+			}
+
+			user := token.R.User
+			user.IsActive = true
+			if _, err := user.Update(ctx, tx, boil.Whitelist(models.UserColumns.IsActive, models.UserColumns.UpdatedAt)); err != nil {
+				return err
+			}
+
+			_, err = models.EmailVerificationTokens(models.EmailVerificationTokenWhere.UserID.EQ(user.ID)).DeleteAll(ctx, tx)
+			return err
+		})
+		if err != nil {
+			if errors.Is(err, apierrs.EmailVerificationTokenNotFound) || errors.Is(err, apierrs.EmailVerificationTokenExpired) {
+				log.Debug().Err(err).Msg("Invalid email verification token")
+				return err
+			}
+
+			log.Error().Err(err).Msg("Failed to verify email")
+			return err
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// postResendVerificationHandler replaces the pending verification token of the user and sends a new mail once committed.
+// Only users with a pending registration (inactive and owning a verification token) are considered.
+// Every request counts as an attempt for the username and client IP to limit the mails sent,
+// the handler always responds with 204 to prevent user enumeration.
+func postResendVerificationHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := logs.LogFromContext(ctx)
+
+		var body postResendVerificationPayload
+		if err := request.BindBody(c, &body); err != nil {
+			return err
+		}
+
+		// every request counts as failure, registered before checking the next request
+		err := attempts.Guard(c, s.DB, s.Config.Auth.Attempts, attempts.Keys(c, attempts.ActionResendVerification, body.Username), func() (bool, error) {
+			return true, nil
+		})
+		if err != nil {
+			if attempts.IsThrottled(err) {
+				log.Debug().Err(err).Msg("Throttling resend verification request")
+				return err
+			}
+
+			log.Error().Err(err).Msg("Failed to register resend verification attempt")
+			return err
+		}
+
+		err = db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			user, err := models.Users(
+				models.UserWhere.Username.EQ(null.StringFrom(body.Username)),
+				models.UserWhere.IsActive.EQ(false),
+				qm.For("UPDATE"),
+			).One(ctx, tx)
+			if err != nil {
+				return err
+			}
+
+			deleted, err := models.EmailVerificationTokens(models.EmailVerificationTokenWhere.UserID.EQ(user.ID)).DeleteAll(ctx, tx)
+			if err != nil {
+				return err
+			}
+			if deleted == 0 {
+				// deactivated user without a pending registration
+				return sql.ErrNoRows
+			}
+
+			token, err := createEmailVerificationToken(ctx, tx, s, user.ID)
+			if err != nil {
+				return err
+			}
+
+			// only mailed once the previous tokens are revoked, failing to send is logged by sendEmailVerification
+			return db.OnCommit(ctx, func(ctx context.Context) {
+				_ = sendEmailVerification(ctx, s, user.Username.String, token.Token)
+			})
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				log.Debug().Msg("No pending registration for username, not resending email verification")
+				return c.NoContent(http.StatusNoContent)
+			}
+
+			log.Error().Err(err).Msg("Failed to renew email verification token")
+			return err
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+func createEmailVerificationToken(ctx context.Context, tx boil.ContextExecutor, s *server.Server, userID string) (*models.EmailVerificationToken, error) {
+	token := &models.EmailVerificationToken{
+		UserID:     userID,
+		ValidUntil: time.Now().Add(s.Config.Auth.EmailVerificationTokenValidity),
+	}
+
+	if err := token.Insert(ctx, tx, boil.Infer()); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func sendEmailVerification(ctx context.Context, s *server.Server, to string, token string) error {
+	link, err := url.Parse(s.Config.Frontend.BaseURL)
+	if err != nil {
+		logs.LogFromContext(ctx).Error().Err(err).Msg("Failed to parse frontend base URL")
+		return err
+	}
+
+	link = link.JoinPath(s.Config.Frontend.EmailVerificationEndpoint)
+	link.RawQuery = url.Values{"token": []string{token}}.Encode()
+
+	if err := s.Mailer.SendEmailVerification(ctx, to, link.String()); err != nil {
+		logs.LogFromContext(ctx).Error().Err(err).Msg("Failed to send email verification")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package errs
+
+import (
+	"net/http"
+
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+)
+
+var (
+	LastSuperAdmin    = errs.NewHTTPError(http.StatusConflict, "LAST_SUPERADMIN", "Cannot remove the last active superadmin.")
+	ScopeEscalation   = errs.NewHTTPError(http.StatusForbidden, "SCOPE_ESCALATION", "Cannot grant scopes beyond your own.")
+	UserNotManageable = errs.NewHTTPError(http.StatusForbidden, "USER_NOT_MANAGEABLE", "Cannot manage users with scopes beyond your own.")
+	SelfModification  = errs.NewHTTPError(http.StatusConflict, "CANNOT_MODIFY_SELF", "Cannot deactivate or delete yourself.")
+)
@@ -0,0 +1,28 @@
+package errs
+
+import (
+	"net/http"
+
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+)
+
+var (
+	EmailVerificationTokenNotFound = errs.NewHTTPError(http.StatusNotFound, "EMAIL_VERIFICATION_TOKEN_NOT_FOUND", "Email verification token not found.")
+	EmailVerificationTokenExpired  = errs.NewHTTPError(http.StatusConflict, "EMAIL_VERIFICATION_TOKEN_EXPIRED", "Email verification token expired.")
+)
+
+var (
+	InvalidCredentials  = errs.NewHTTPError(http.StatusUnauthorized, "INVALID_CREDENTIALS", "Invalid username or password.")
+	UserDeactivated     = errs.NewHTTPError(http.StatusForbidden, "USER_DEACTIVATED", "User is deactivated.")
+	RefreshTokenInvalid = errs.NewHTTPError(http.StatusUnauthorized, "REFRESH_TOKEN_INVALID", "Refresh token is invalid.")
+)
+
+var (
+	PasswordResetTokenNotFound = errs.NewHTTPError(http.StatusNotFound, "PASSWORD_RESET_TOKEN_NOT_FOUND", "Password reset token not found.")
+	PasswordResetTokenExpired  = errs.NewHTTPError(http.StatusConflict, "PASSWORD_RESET_TOKEN_EXPIRED", "Password reset token expired.")
+)
+
+var (
+	TooManyAttempts      = errs.NewHTTPError(http.StatusTooManyRequests, "TOO_MANY_ATTEMPTS", "Too many failed attempts, retry later.")
+	TemporarilyLockedOut = errs.NewHTTPError(http.StatusTooManyRequests, "TEMPORARILY_LOCKED_OUT", "Temporarily locked out due to too many failed attempts.")
+)
@@ -0,0 +1,16 @@
+package errs
+
+import (
+	"net/http"
+
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+)
+
+var (
+	MFAAlreadyEnabled = errs.NewHTTPError(http.StatusConflict, "MFA_ALREADY_ENABLED", "Two-factor authentication is already enabled.")
+	MFANotEnabled     = errs.NewHTTPError(http.StatusConflict, "MFA_NOT_ENABLED", "Two-factor authentication is not enabled.")
+	MFANotEnrolled    = errs.NewHTTPError(http.StatusConflict, "MFA_NOT_ENROLLED", "Two-factor authentication enrollment was not started.")
+	MFARequired       = errs.NewHTTPError(http.StatusConflict, "MFA_REQUIRED", "Two-factor authentication is required for the user's scopes.")
+	MFAInvalidCode    = errs.NewHTTPError(http.StatusUnauthorized, "MFA_INVALID_CODE", "Invalid two-factor authentication code.")
+	MFATokenInvalid   = errs.NewHTTPError(http.StatusUnauthorized, "MFA_TOKEN_INVALID", "MFA token is invalid or expired.")
+)
@@ -0,0 +1,17 @@
+package errs
+
+import (
+	"net/http"
+
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+)
+
+var (
+	OIDCProviderNotFound     = errs.NewHTTPError(http.StatusNotFound, "OIDC_PROVIDER_NOT_FOUND", "Identity provider not found.")
+	OIDCProviderUnavailable  = errs.NewHTTPError(http.StatusBadGateway, "OIDC_PROVIDER_UNAVAILABLE", "Identity provider is unavailable.")
+	OIDCStateInvalid         = errs.NewHTTPError(http.StatusUnauthorized, "OIDC_STATE_INVALID", "OIDC state is invalid or expired.")
+	OIDCAuthenticationFailed = errs.NewHTTPError(http.StatusUnauthorized, "OIDC_AUTHENTICATION_FAILED", "Authentication with the identity provider failed.")
+	OIDCEmailNotVerified     = errs.NewHTTPError(http.StatusForbidden, "OIDC_EMAIL_NOT_VERIFIED", "The identity provider did not confirm a verified email address.")
+	OIDCIdentityNotLinked    = errs.NewHTTPError(http.StatusForbidden, "OIDC_IDENTITY_NOT_LINKED", "The external identity is not linked to any user.")
+	OIDCUserExists           = errs.NewHTTPError(http.StatusConflict, "OIDC_USER_EXISTS", "A user with the identity's email address already exists.")
+)
@@ -10,3 +10,13 @@ var (
 	UserExists   = errs.NewHTTPError(http.StatusConflict, "USER_ALREADY_EXISTS", "User already exists.")
 	UserNotFound = errs.NewHTTPError(http.StatusNotFound, "USER_NOT_FOUND", "User not found.")
 )
+
+var (
+	InvalidPassword = errs.NewHTTPError(http.StatusUnauthorized, "INVALID_PASSWORD", "Invalid password.")
+)
+
+var (
+	APIKeyNotFound     = errs.NewHTTPError(http.StatusNotFound, "API_KEY_NOT_FOUND", "API key not found.")
+	APIKeyLimitReached = errs.NewHTTPError(http.StatusConflict, "API_KEY_LIMIT_REACHED", "Maximum number of API keys reached.")
+	APIKeyNotAllowed   = errs.NewHTTPError(http.StatusForbidden, "API_KEY_NOT_ALLOWED", "Credentials can't be managed using an API key.")
+)
@@ -0,0 +1,133 @@
+package management
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/config"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/labstack/echo/v4"
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+type migrationStatus string
+
+const (
+	// migrationStatusApplied: migration file exists and was applied.
+	migrationStatusApplied migrationStatus = "applied"
+	// migrationStatusPending: migration file exists but was not applied yet.
+	migrationStatusPending migrationStatus = "pending"
+	// migrationStatusUnknown: migration was applied but no migration file exists (e.g. newer or removed migration).
+	migrationStatusUnknown migrationStatus = "unknown"
+)
+
+type migration struct {
+	ID        string          `json:"id"`
+	AppliedAt *time.Time      `json:"appliedAt"`
+	Status    migrationStatus `json:"status"`
+}
+
+type dbVersionResponse struct {
+	Migrations []migration `json:"migrations"`
+	Applied    int         `json:"applied"`
+	Pending    int         `json:"pending"`
+	Unknown    int         `json:"unknown"`
+	UpToDate   bool        `json:"upToDate"`
+}
+
+type migrationRecord struct {
+	ID        string
+	AppliedAt time.Time
+}
+
+func getDBVersionHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := logs.LogFromContext(ctx)
+
+		files, err := (&migrate.FileMigrationSource{Dir: config.DatabaseMigrationFolder}).FindMigrations()
+		if err != nil {
+			log.Error().Err(err).Str("dir", config.DatabaseMigrationFolder).Msg("Failed to read migration files")
+			return err
+		}
+
+		ids := make([]string, 0, len(files))
+		for _, f := range files {
+			ids = append(ids, f.Id)
+		}
+
+		records, err := appliedMigrations(ctx, s.DB)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to load applied migrations")
+			return err
+		}
+
+		return c.JSON(http.StatusOK, compareMigrations(ids, records))
+	}
+}
+
+// appliedMigrations loads all migrations recorded by sql-migrate. We intentionally don't use
+// migrate.GetMigrationRecords here, as it creates the migration table if it does not exist yet.
+func appliedMigrations(ctx context.Context, db *sql.DB) ([]migrationRecord, error) {
+	rows, err := db.QueryContext(ctx, "SELECT id, applied_at FROM "+config.DatabaseMigrationTable+" ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]migrationRecord, 0)
+	for rows.Next() {
+		var r migrationRecord
+		if err := rows.Scan(&r.ID, &r.AppliedAt); err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+
+	return records, rows.Err()
+}
+
+// compareMigrations matches the migration files (ids in order) against the applied migration records.
+// Files are listed first in their order, followed by applied migrations without a matching file.
+func compareMigrations(files []string, records []migrationRecord) dbVersionResponse {
+	applied := make(map[string]time.Time, len(records))
+	for _, r := range records {
+		applied[r.ID] = r.AppliedAt
+	}
+
+	res := dbVersionResponse{Migrations: make([]migration, 0, len(files)+len(records))}
+	known := make(map[string]struct{}, len(files))
+
+	for _, id := range files {
+		known[id] = struct{}{}
+
+		m := migration{ID: id, Status: migrationStatusPending}
+		if at, ok := applied[id]; ok {
+			at := at
+			m.AppliedAt = &at
+			m.Status = migrationStatusApplied
+			res.Applied++
+		} else {
+			res.Pending++
+		}
+
+		res.Migrations = append(res.Migrations, m)
+	}
+
+	for _, r := range records {
+		if _, ok := known[r.ID]; ok {
+			continue
+		}
+
+		at := r.AppliedAt
+		res.Migrations = append(res.Migrations, migration{ID: r.ID, AppliedAt: &at, Status: migrationStatusUnknown})
+		res.Unknown++
+	}
+
+	res.UpToDate = res.Pending == 0 && res.Unknown == 0
+
+	return res
+}
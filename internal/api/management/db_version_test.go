@@ -0,0 +1,38 @@
+package management
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareMigrations(t *testing.T) {
+	at := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	res := compareMigrations(
+		[]string{"20230101000000-a.sql", "20230102000000-b.sql", "20230103000000-c.sql"},
+		[]migrationRecord{
+			{ID: "20230101000000-a.sql", AppliedAt: at},
+			{ID: "20230102000000-b.sql", AppliedAt: at},
+			{ID: "20230104000000-removed.sql", AppliedAt: at},
+		},
+	)
+
+	require.Len(t, res.Migrations, 4)
+	assert.Equal(t, migrationStatusApplied, res.Migrations[0].Status)
+	assert.Equal(t, migrationStatusApplied, res.Migrations[1].Status)
+	assert.Equal(t, migrationStatusPending, res.Migrations[2].Status)
+	assert.Nil(t, res.Migrations[2].AppliedAt)
+	assert.Equal(t, "20230104000000-removed.sql", res.Migrations[3].ID)
+	assert.Equal(t, migrationStatusUnknown, res.Migrations[3].Status)
+	assert.Equal(t, 2, res.Applied)
+	assert.Equal(t, 1, res.Pending)
+	assert.Equal(t, 1, res.Unknown)
+	assert.False(t, res.UpToDate)
+
+	res = compareMigrations([]string{"20230101000000-a.sql"}, []migrationRecord{{ID: "20230101000000-a.sql", AppliedAt: at}})
+	assert.True(t, res.UpToDate)
+	assert.Equal(t, at, *res.Migrations[0].AppliedAt)
+}
@@ -0,0 +1,55 @@
+package management
+
+import (
+	"net/http"
+
+	"github.com/driif/echo-go-starter/internal/api/module"
+)
+
+// Register adds all management routes to the registry.
+func Register(r *module.Registry) {
+	r.Add(
+		module.Route{
+			Method:      http.MethodGet,
+			Path:        "/version",
+			Group:       module.GroupManagement,
+			Handler:     getVersionHandler,
+			Description: "Build information and Go version",
+		},
+		module.Route{
+			Method:      http.MethodGet,
+			Path:        "/db/version",
+			Group:       module.GroupManagement,
+			Handler:     getDBVersionHandler,
+			Description: "Applied database migrations compared to migration files",
+		},
+		module.Route{
+			Method:      http.MethodGet,
+			Path:        "/scheduler/tasks",
+			Group:       module.GroupManagement,
+			Handler:     getSchedulerTasksHandler,
+			Description: "Periodic tasks and their last run",
+		},
+		module.Route{
+			Method:      http.MethodGet,
+			Path:        "/openapi.json",
+			Group:       module.GroupManagement,
+			Handler:     getOpenAPIJSONHandler,
+			Description: "Merged OpenAPI document (JSON)",
+		},
+		module.Route{
+			Method:      http.MethodGet,
+			Path:        "/openapi.yaml",
+			Group:       module.GroupManagement,
+			Handler:     getOpenAPIYAMLHandler,
+			Description: "Merged OpenAPI document (YAML)",
+		},
+		module.Route{
+			Method:      http.MethodGet,
+			Path:        "/docs",
+			Group:       module.GroupManagement,
+			Handler:     getDocsHandler,
+			Description: "Interactive API documentation",
+		},
+	)
+}
@@ -0,0 +1,66 @@
+package management
+
+import (
+	_ "embed"
+	"net/http"
+	"path/filepath"
+
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/driif/echo-go-starter/pkg/openapi"
+	"github.com/labstack/echo/v4"
+)
+
+// docsHTML is a self-contained (offline) documentation page, rendering the merged OpenAPI document.
+//
+//go:embed docs.html
+var docsHTML []byte
+
+func getOpenAPIJSONHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		doc, err := loadOpenAPIDocument(s)
+		if err != nil {
+			logs.LogFromEchoContext(c).Error().Err(err).Msg("Failed to load OpenAPI document")
+			return err
+		}
+
+		return c.JSON(http.StatusOK, doc)
+	}
+}
+
+func getOpenAPIYAMLHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		doc, err := loadOpenAPIDocument(s)
+		if err != nil {
+			logs.LogFromEchoContext(c).Error().Err(err).Msg("Failed to load OpenAPI document")
+			return err
+		}
+
+		b, err := doc.YAML()
+		if err != nil {
+			logs.LogFromEchoContext(c).Error().Err(err).Msg("Failed to encode OpenAPI document")
+			return err
+		}
+
+		return c.Blob(http.StatusOK, "application/yaml", b)
+	}
+}
+
+func getDocsHandler(_ *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.HTMLBlob(http.StatusOK, docsHTML)
+	}
+}
+
+// loadOpenAPIDocument merges all OpenAPI documents in <APIBaseDirAbs>/paths and points its server to our BaseURL.
+// Documents are read on each request, our management endpoints are not considered hot paths.
+func loadOpenAPIDocument(s *server.Server) (*openapi.Document, error) {
+	doc, err := openapi.LoadDir(filepath.Join(s.Config.Paths.APIBaseDirAbs, "paths"))
+	if err != nil {
+		return nil, err
+	}
+
+	doc.SetServerURL(s.Config.Echo.BaseURL)
+
+	return doc, nil
+}
@@ -0,0 +1,66 @@
+package management
+
+import (
+	"net/http"
+
+	"github.com/driif/echo-go-starter/internal/scheduler"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/null/v8"
+)
+
+type schedulerTask struct {
+	Name            string      `json:"name"`
+	Schedule        string      `json:"schedule"`
+	NextRunAt       null.Time   `json:"nextRunAt"`
+	Running         bool        `json:"running"`
+	LastRunAt       null.Time   `json:"lastRunAt"`
+	LastDurationMs  null.Int64  `json:"lastDurationMs"`
+	LastError       null.String `json:"lastError"`
+	LastSucceededAt null.Time   `json:"lastSucceededAt"`
+}
+
+type schedulerTasksResponse struct {
+	Enabled bool            `json:"enabled"`
+	Items   []schedulerTask `json:"items"`
+}
+
+// getSchedulerTasksHandler lists the registered periodic tasks with their last run, which might have happened
+// on any replica.
+func getSchedulerTasksHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := logs.LogFromContext(ctx)
+
+		res := schedulerTasksResponse{Enabled: s.Config.Scheduler.Enabled, Items: make([]schedulerTask, 0)}
+		if s.Scheduler == nil {
+			return c.JSON(http.StatusOK, res)
+		}
+
+		status, err := s.Scheduler.Status(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to load scheduler status")
+			return err
+		}
+
+		for _, t := range status {
+			res.Items = append(res.Items, toSchedulerTask(t))
+		}
+
+		return c.JSON(http.StatusOK, res)
+	}
+}
+
+func toSchedulerTask(t scheduler.TaskStatus) schedulerTask {
+	return schedulerTask{
+		Name:            t.Name,
+		Schedule:        t.Schedule,
+		NextRunAt:       null.NewTime(t.NextRunAt, !t.NextRunAt.IsZero()),
+		Running:         t.Running,
+		LastRunAt:       t.LastRunAt,
+		LastDurationMs:  null.NewInt64(t.LastDuration.Milliseconds(), t.LastRunAt.Valid && !t.Running),
+		LastError:       t.LastError,
+		LastSucceededAt: t.LastSucceededAt,
+	}
+}
@@ -0,0 +1,28 @@
+package management
+
+import (
+	"net/http"
+	"runtime"
+
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/config"
+	"github.com/labstack/echo/v4"
+)
+
+type versionResponse struct {
+	ModuleName string `json:"moduleName"`
+	Commit     string `json:"commit"`
+	BuildDate  string `json:"buildDate"`
+	GoVersion  string `json:"goVersion"`
+}
+
+func getVersionHandler(_ *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, versionResponse{
+			ModuleName: config.ModuleName,
+			Commit:     config.Commit,
+			BuildDate:  config.BuildDate,
+			GoVersion:  runtime.Version(),
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package mfa
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server/config"
+	"github.com/driif/echo-go-starter/pkg/auth/totp"
+	"github.com/driif/echo-go-starter/pkg/slices"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// Enrollment holds the secret of a pending TOTP enrollment.
+type Enrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// Required reports whether the user possesses any of the scopes requiring 2FA.
+func Required(cfg config.AuthMFA, user *models.User) bool {
+	for _, scope := range cfg.RequiredScopes {
+		if slices.ContainsString(user.Scopes, scope) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Enabled reports whether the user has confirmed a TOTP enrollment.
+func Enabled(ctx context.Context, exec boil.ContextExecutor, userID string) (bool, error) {
+	return models.UserTotps(
+		models.UserTotpWhere.UserID.EQ(userID),
+		models.UserTotpWhere.ConfirmedAt.IsNotNull(),
+	).Exists(ctx, exec)
+}
+
+// Enroll generates a new TOTP secret for the user, replacing any pending enrollment.
+// Returns apierrs.MFAAlreadyEnabled if the user has already confirmed an enrollment.
+func Enroll(ctx context.Context, tx boil.ContextExecutor, cfg config.AuthMFA, user *models.User) (Enrollment, error) {
+	t, err := findTOTPForUpdate(ctx, tx, user.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return Enrollment{}, err
+	}
+	if t != nil && t.ConfirmedAt.Valid {
+		return Enrollment{}, apierrs.MFAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return Enrollment{}, err
+	}
+
+	if t == nil {
+		t = &models.UserTotp{UserID: user.ID, Secret: secret}
+		if err := t.Insert(ctx, tx, boil.Infer()); err != nil {
+			return Enrollment{}, err
+		}
+	} else {
+		t.Secret = secret
+		t.LastUsedStep = 0
+		if _, err := t.Update(ctx, tx, boil.Infer()); err != nil {
+			return Enrollment{}, err
+		}
+	}
+
+	return Enrollment{
+		Secret: secret,
+		URI:    totp.URI(cfg.Issuer, user.Username.String, secret),
+	}, nil
+}
+
+// Confirm validates the code against the pending enrollment, enabling 2FA for the user and generating
+// recovery codes. Returns apierrs.MFANotEnrolled, apierrs.MFAAlreadyEnabled or apierrs.MFAInvalidCode.
+func Confirm(ctx context.Context, tx boil.ContextExecutor, cfg config.AuthMFA, userID string, code string) ([]string, error) {
+	t, err := findTOTPForUpdate(ctx, tx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apierrs.MFANotEnrolled
+		}
+		return nil, err
+	}
+	if t.ConfirmedAt.Valid {
+		return nil, apierrs.MFAAlreadyEnabled
+	}
+
+	if err := validateCode(ctx, tx, t, code); err != nil {
+		return nil, err
+	}
+
+	t.ConfirmedAt = null.TimeFrom(time.Now())
+	if _, err := t.Update(ctx, tx, boil.Whitelist(models.UserTotpColumns.ConfirmedAt, models.UserTotpColumns.UpdatedAt)); err != nil {
+		return nil, err
+	}
+
+	return RegenerateRecoveryCodes(ctx, tx, cfg, userID)
+}
+
+// Verify checks the TOTP code or, if code is empty, the one-time recovery code of a user with 2FA enabled.
+// Returns apierrs.MFANotEnabled or apierrs.MFAInvalidCode.
+func Verify(ctx context.Context, tx boil.ContextExecutor, userID string, code string, recoveryCode string) error {
+	t, err := findTOTPForUpdate(ctx, tx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apierrs.MFANotEnabled
+		}
+		return err
+	}
+	if !t.ConfirmedAt.Valid {
+		return apierrs.MFANotEnabled
+	}
+
+	if len(code) > 0 {
+		return validateCode(ctx, tx, t, code)
+	}
+
+	rc, err := models.MfaRecoveryCodes(
+		models.MfaRecoveryCodeWhere.UserID.EQ(userID),
+		models.MfaRecoveryCodeWhere.CodeHash.EQ(totp.HashRecoveryCode(recoveryCode)),
+		models.MfaRecoveryCodeWhere.UsedAt.IsNull(),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apierrs.MFAInvalidCode
+		}
+		return err
+	}
+
+	rc.UsedAt = null.TimeFrom(time.Now())
+	_, err = rc.Update(ctx, tx, boil.Whitelist(models.MfaRecoveryCodeColumns.UsedAt, models.MfaRecoveryCodeColumns.UpdatedAt))
+	return err
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes of the user, only the hashes are persisted.
+func RegenerateRecoveryCodes(ctx context.Context, tx boil.ContextExecutor, cfg config.AuthMFA, userID string) ([]string, error) {
+	if _, err := models.MfaRecoveryCodes(models.MfaRecoveryCodeWhere.UserID.EQ(userID)).DeleteAll(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	codes, err := totp.GenerateRecoveryCodes(cfg.RecoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, code := range codes {
+		rc := &models.MfaRecoveryCode{UserID: userID, CodeHash: totp.HashRecoveryCode(code)}
+		if err := rc.Insert(ctx, tx, boil.Infer()); err != nil {
+			return nil, err
+		}
+	}
+
+	return codes, nil
+}
+
+// RemainingRecoveryCodes returns the number of unused recovery codes of the user.
+func RemainingRecoveryCodes(ctx context.Context, exec boil.ContextExecutor, userID string) (int64, error) {
+	return models.MfaRecoveryCodes(
+		models.MfaRecoveryCodeWhere.UserID.EQ(userID),
+		models.MfaRecoveryCodeWhere.UsedAt.IsNull(),
+	).Count(ctx, exec)
+}
+
+// Disable removes the TOTP secret and all recovery codes of the user. Returns whether 2FA was set up at all.
+func Disable(ctx context.Context, tx boil.ContextExecutor, userID string) (bool, error) {
+	if _, err := models.MfaRecoveryCodes(models.MfaRecoveryCodeWhere.UserID.EQ(userID)).DeleteAll(ctx, tx); err != nil {
+		return false, err
+	}
+
+	n, err := models.UserTotps(models.UserTotpWhere.UserID.EQ(userID)).DeleteAll(ctx, tx)
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+func findTOTPForUpdate(ctx context.Context, tx boil.ContextExecutor, userID string) (*models.UserTotp, error) {
+	return models.UserTotps(models.UserTotpWhere.UserID.EQ(userID), qm.For("UPDATE")).One(ctx, tx)
+}
+
+// validateCode checks the code and persists its time step, so it can't be used again.
+func validateCode(ctx context.Context, tx boil.ContextExecutor, t *models.UserTotp, code string) error {
+	step, ok, err := totp.Validate(t.Secret, code, time.Now(), t.LastUsedStep)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return apierrs.MFAInvalidCode
+	}
+
+	t.LastUsedStep = step
+	_, err = t.Update(ctx, tx, boil.Whitelist(models.UserTotpColumns.LastUsedStep, models.UserTotpColumns.UpdatedAt))
+	return err
+}
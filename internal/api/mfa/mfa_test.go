@@ -0,0 +1,20 @@
+package mfa_test
+
+import (
+	"testing"
+
+	"github.com/driif/echo-go-starter/internal/api/mfa"
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequired(t *testing.T) {
+	cfg := config.AuthMFA{RequiredScopes: []string{"superadmin", "admin"}}
+
+	assert.True(t, mfa.Required(cfg, &models.User{Scopes: []string{"app", "superadmin"}}))
+	assert.True(t, mfa.Required(cfg, &models.User{Scopes: []string{"admin"}}))
+	assert.False(t, mfa.Required(cfg, &models.User{Scopes: []string{"app", "cms"}}))
+	assert.False(t, mfa.Required(cfg, &models.User{}))
+	assert.False(t, mfa.Required(config.AuthMFA{}, &models.User{Scopes: []string{"superadmin"}}))
+}
@@ -0,0 +1,181 @@
+package module
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/net/auth"
+	mdwr "github.com/driif/echo-go-starter/internal/server/net/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// Group identifies the route group (and thus path prefix and group middleware) a route is attached to.
+type Group string
+
+const (
+	GroupRoot       Group = "root"
+	GroupManagement Group = "management"
+	GroupV1Auth     Group = "v1-auth"
+	GroupV1User     Group = "v1-user"
+	GroupV1Admin    Group = "v1-admin"
+)
+
+// Prefix returns the path prefix of the group.
+func (g Group) Prefix() string {
+	switch g {
+	case GroupManagement:
+		return "/-"
+	case GroupV1Auth:
+		return "/v1/auth"
+	case GroupV1User:
+		return "/v1/users"
+	case GroupV1Admin:
+		return "/v1/admin"
+	default:
+		return ""
+	}
+}
+
+// HandlerFactory creates the handler of a route once the server has been initialized.
+type HandlerFactory func(s *server.Server) echo.HandlerFunc
+
+// MiddlewareFactory creates a route specific middleware once the server has been initialized.
+type MiddlewareFactory func(s *server.Server) echo.MiddlewareFunc
+
+// Middleware wraps a middleware not depending on the server as MiddlewareFactory.
+func Middleware(m echo.MiddlewareFunc) MiddlewareFactory {
+	return func(_ *server.Server) echo.MiddlewareFunc {
+		return m
+	}
+}
+
+// Route describes a single route provided by a feature module.
+type Route struct {
+	Method string
+	// Path relative to the group's prefix, using echo's syntax for path params (e.g. `/:id`).
+	Path  string
+	Group Group
+	// Auth controls whether an access token is required to access the route.
+	Auth mdwr.AuthMode
+	// Scopes the authenticated user must possess, only applied if Auth is not AuthModeNone.
+	Scopes []auth.Scope
+	// Handler creates the route's handler.
+	Handler HandlerFactory
+	// Middleware applied after authentication, in order.
+	Middleware []MiddlewareFactory
+	// Description is a short, human-readable summary of the route.
+	Description string
+}
+
+// FullPath returns the full path of the route including its group's prefix.
+func (r Route) FullPath() string {
+	return r.Group.Prefix() + r.Path
+}
+
+// AuthRequirement returns a human-readable description of the authentication required by the route.
+func (r Route) AuthRequirement() string {
+	if r.Group == GroupManagement {
+		return "management-secret"
+	}
+
+	if r.Auth == mdwr.AuthModeNone {
+		return r.Auth.String()
+	}
+
+	if len(r.Scopes) == 0 {
+		return r.Auth.String()
+	}
+
+	scopes := make([]string, 0, len(r.Scopes))
+	for _, s := range r.Scopes {
+		scopes = append(scopes, s.String())
+	}
+
+	return fmt.Sprintf("%s [%s]", r.Auth, strings.Join(scopes, ", "))
+}
+
+// Registry collects the routes of all feature modules.
+type Registry struct {
+	routes []Route
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		routes: make([]Route, 0),
+	}
+}
+
+// Add registers the routes provided. Routes are attached in the order they have been added.
+func (r *Registry) Add(routes ...Route) {
+	r.routes = append(r.routes, routes...)
+}
+
+// Routes returns all routes registered, in the order they have been added.
+func (r *Registry) Routes() []Route {
+	res := make([]Route, len(r.routes))
+	copy(res, r.routes)
+	return res
+}
+
+// SortedRoutes returns all routes registered, sorted by full path and method.
+func (r *Registry) SortedRoutes() []Route {
+	res := r.Routes()
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].FullPath() != res[j].FullPath() {
+			return res[i].FullPath() < res[j].FullPath()
+		}
+		return res[i].Method < res[j].Method
+	})
+	return res
+}
+
+// Validate checks all routes registered for missing fields and duplicates. Two routes are considered
+// duplicates if they share the same method and their full paths only differ by the names of path params.
+func (r *Registry) Validate() error {
+	seen := make(map[string]Route, len(r.routes))
+
+	for _, route := range r.routes {
+		if len(route.Method) == 0 || route.Handler == nil {
+			return fmt.Errorf("route %s %s is missing its method or handler", route.Method, route.FullPath())
+		}
+
+		if !isKnownMethod(route.Method) {
+			return fmt.Errorf("route %s %s uses an unknown method", route.Method, route.FullPath())
+		}
+
+		key := route.Method + " " + normalizePath(route.FullPath())
+		if existing, ok := seen[key]; ok {
+			return fmt.Errorf("duplicate route %s %s (conflicts with %s %s)", route.Method, route.FullPath(), existing.Method, existing.FullPath())
+		}
+
+		seen[key] = route
+	}
+
+	return nil
+}
+
+func isKnownMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace, echo.PROPFIND, echo.REPORT:
+		return true
+	default:
+		return false
+	}
+}
+
+// normalizePath replaces the names of all path params, as echo's router matches `/:id` and `/:userId` identically.
+func normalizePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") {
+			segments[i] = ":"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,83 @@
+package module_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/driif/echo-go-starter/internal/api/module"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/net/auth"
+	mdwr "github.com/driif/echo-go-starter/internal/server/net/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func noopHandler(_ *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+func TestRegistryValidate(t *testing.T) {
+	r := module.NewRegistry()
+	r.Add(
+		module.Route{Method: http.MethodGet, Path: "/me", Group: module.GroupV1User, Handler: noopHandler},
+		module.Route{Method: http.MethodGet, Path: "/:id", Group: module.GroupV1User, Handler: noopHandler},
+		module.Route{Method: http.MethodPost, Path: "/:id", Group: module.GroupV1User, Handler: noopHandler},
+		module.Route{Method: http.MethodGet, Path: "/users/:id", Group: module.GroupRoot, Handler: noopHandler},
+	)
+
+	require.NoError(t, r.Validate())
+}
+
+func TestRegistryValidateDuplicate(t *testing.T) {
+	r := module.NewRegistry()
+	r.Add(
+		module.Route{Method: http.MethodGet, Path: "/:id", Group: module.GroupV1User, Handler: noopHandler},
+		module.Route{Method: http.MethodGet, Path: "/v1/users/:userId", Group: module.GroupRoot, Handler: noopHandler},
+	)
+
+	assert.EqualError(t, r.Validate(), "duplicate route GET /v1/users/:userId (conflicts with GET /v1/users/:id)")
+}
+
+func TestRegistryValidateMissingHandler(t *testing.T) {
+	r := module.NewRegistry()
+	r.Add(module.Route{Method: http.MethodGet, Path: "/me", Group: module.GroupV1User})
+
+	assert.Error(t, r.Validate())
+
+	r = module.NewRegistry()
+	r.Add(module.Route{Method: "FETCH", Path: "/me", Group: module.GroupV1User, Handler: noopHandler})
+
+	assert.Error(t, r.Validate())
+}
+
+func TestRegistrySortedRoutes(t *testing.T) {
+	r := module.NewRegistry()
+	r.Add(
+		module.Route{Method: http.MethodPost, Path: "/me", Group: module.GroupV1User, Handler: noopHandler},
+		module.Route{Method: http.MethodGet, Path: "/docs", Group: module.GroupManagement, Handler: noopHandler},
+		module.Route{Method: http.MethodGet, Path: "/me", Group: module.GroupV1User, Handler: noopHandler},
+	)
+
+	routes := r.SortedRoutes()
+	require.Len(t, routes, 3)
+	assert.Equal(t, "/-/docs", routes[0].FullPath())
+	assert.Equal(t, http.MethodGet, routes[1].Method)
+	assert.Equal(t, http.MethodPost, routes[2].Method)
+
+	// registration order must be kept for attaching routes
+	assert.Equal(t, http.MethodPost, r.Routes()[0].Method)
+}
+
+func TestRouteAuthRequirement(t *testing.T) {
+	assert.Equal(t, "management-secret", module.Route{Group: module.GroupManagement}.AuthRequirement())
+	assert.Equal(t, "none", module.Route{Group: module.GroupV1Auth}.AuthRequirement())
+	assert.Equal(t, "required", module.Route{Group: module.GroupV1User, Auth: mdwr.AuthModeRequired}.AuthRequirement())
+	assert.Equal(t, "required [app, admin]", module.Route{
+		Group:  module.GroupV1User,
+		Auth:   mdwr.AuthModeRequired,
+		Scopes: []auth.Scope{auth.AuthScopeApp, auth.AuthScopeAdmin},
+	}.AuthRequirement())
+}
@@ -0,0 +1,63 @@
+package request
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// InBody marks validation errors of fields provided in the request body.
+	InBody = "body"
+	// InQuery marks validation errors of fields provided as query parameters.
+	InQuery = "query"
+	// InPath marks validation errors of fields provided as path parameters.
+	InPath = "path"
+)
+
+// Validatable is implemented by request payloads validating themselves after being bound.
+type Validatable interface {
+	Validate() []*errs.HTTPValidationErrorDetail
+}
+
+// BindBody decodes the JSON request body into v, rejecting unknown fields.
+// If v implements Validatable, validation errors are returned as HTTPValidationError.
+func BindBody(c echo.Context, v interface{}) error {
+	dec := json.NewDecoder(c.Request().Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		logs.LogFromEchoContext(c).Debug().Err(err).Msg("Failed to parse request body")
+		e := *apierrs.ParseBody
+		e.Internal = err
+		return &e
+	}
+
+	if val, ok := v.(Validatable); ok {
+		if details := val.Validate(); len(details) > 0 {
+			return NewValidationError(details...)
+		}
+	}
+
+	return nil
+}
+
+// NewValidationError returns a HTTPValidationError for the invalid fields provided.
+func NewValidationError(details ...*errs.HTTPValidationErrorDetail) *errs.HTTPValidationError {
+	return errs.NewHTTPValidationError(http.StatusBadRequest, errs.HTTPErrorTypeGeneric, http.StatusText(http.StatusBadRequest), details)
+}
+
+// InvalidField describes a single field failing validation.
+func InvalidField(key, in, msg string) *errs.HTTPValidationErrorDetail {
+	return &errs.HTTPValidationErrorDetail{
+		Key:   &key,
+		In:    &in,
+		Error: &msg,
+	}
+}
@@ -0,0 +1,63 @@
+package request_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/driif/echo-go-starter/internal/api/request"
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type payload struct {
+	Name string `json:"name"`
+}
+
+func (p payload) Validate() []*errs.HTTPValidationErrorDetail {
+	if len(p.Name) == 0 {
+		return []*errs.HTTPValidationErrorDetail{request.InvalidField("name", request.InBody, "name is required")}
+	}
+
+	return nil
+}
+
+func newContext(body string) echo.Context {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	return echo.New().NewContext(req, httptest.NewRecorder())
+}
+
+func TestBindBody(t *testing.T) {
+	var p payload
+	require.NoError(t, request.BindBody(newContext(`{"name": "max"}`), &p))
+	assert.Equal(t, "max", p.Name)
+}
+
+func TestBindBodyInvalid(t *testing.T) {
+	var p payload
+	err := request.BindBody(newContext(`{"name": 1}`), &p)
+
+	var httpErr *errs.HTTPError
+	require.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, "PARSE_BODY", *httpErr.Type)
+
+	err = request.BindBody(newContext(`{"name": "max", "unknown": true}`), &p)
+	require.True(t, errors.As(err, &httpErr))
+}
+
+func TestBindBodyValidation(t *testing.T) {
+	var p payload
+	err := request.BindBody(newContext(`{}`), &p)
+
+	var valErr *errs.HTTPValidationError
+	require.True(t, errors.As(err, &valErr))
+	assert.Equal(t, http.StatusBadRequest, *valErr.Code)
+	require.Len(t, valErr.ValidationErrors, 1)
+	assert.Equal(t, "name", *valErr.ValidationErrors[0].Key)
+}
@@ -0,0 +1,364 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/driif/echo-go-starter/pkg/slices"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// FilterOperator is an operator accepted by `filter[<field>][<operator>]`.
+type FilterOperator string
+
+const (
+	FilterEq  FilterOperator = "eq"
+	FilterNeq FilterOperator = "neq"
+	// FilterIn matches any of the comma separated values.
+	FilterIn  FilterOperator = "in"
+	FilterLt  FilterOperator = "lt"
+	FilterLte FilterOperator = "lte"
+	FilterGt  FilterOperator = "gt"
+	FilterGte FilterOperator = "gte"
+	// FilterILike performs a case-insensitive substring search, wildcards within the value are escaped.
+	FilterILike FilterOperator = "ilike"
+	// FilterIsNull expects a boolean, filtering NULL (true) or NOT NULL (false) values.
+	FilterIsNull FilterOperator = "isnull"
+	// FilterSearch performs a full text search, matching rows containing words starting with every word of the value.
+	FilterSearch FilterOperator = "search"
+	// FilterContains matches array columns containing the value.
+	FilterContains FilterOperator = "contains"
+)
+
+// FilterType is the type the values of a filter are coerced to.
+type FilterType int
+
+const (
+	FilterString FilterType = iota
+	FilterInt
+	FilterFloat
+	FilterBool
+	// FilterTime expects RFC 3339 timestamps, e.g. `2026-10-19T12:00:00Z`.
+	FilterTime
+	FilterUUID
+)
+
+const (
+	filterMaxInValues      = 100
+	filterDefaultOperator  = FilterEq
+	filterParamPrefix      = "filter["
+	filterInValueSeparator = ","
+)
+
+var filterParamRegex = regexp.MustCompile(`^filter\[([^\[\]]+)\](?:\[([^\[\]]+)\])?$`)
+
+// FilterField describes a filterable field of a resource.
+type FilterField struct {
+	// Column is the (trusted) column filtered, e.g. models.UserTableColumns.CreatedAt.
+	// For FilterSearch, it's the tsvector searched, e.g. a generated column (see db.TSVectorColumn) or an expression
+	// built via db.TSVector.
+	Column string
+	Type   FilterType
+	// Operators allowed for the field, the operator may be omitted for FilterEq (`filter[<field>]=<value>`).
+	Operators []FilterOperator
+	// JSONKey filters the key of the JSONB column via db.WhereJSONKey (comparison operators and FilterILike only),
+	// Table and Column must then be provided separately, e.g. Table "app_user_profiles", Column "profile" and
+	// JSONKey "country".
+	JSONKey string
+	Table   string
+	// TSConfig is the text search configuration used by FilterSearch, defaults to db.TSConfigSimple.
+	TSConfig string
+	// Values restricts the accepted values, e.g. to the values of an enum. All values are accepted if empty.
+	Values []string
+}
+
+// FilterFields maps the field names accepted by `filter[<field>]` to their definition.
+type FilterFields map[string]FilterField
+
+// FilterAlias compiles a plain query parameter to the filter of a field, e.g. to keep accepting the parameters of an
+// endpoint migrated to ParseFilters (`?active=true` as `?filter[is_active][eq]=true`).
+type FilterAlias struct {
+	Field    string
+	Operator FilterOperator
+}
+
+// FilterAliases maps the names of the aliased query parameters to their filter.
+type FilterAliases map[string]FilterAlias
+
+func (f FilterField) allows(op FilterOperator) bool {
+	for _, o := range f.Operators {
+		if o == op {
+			return true
+		}
+	}
+
+	return false
+}
+
+// coerce checks the value to be one of Values and coerces it to the field's type.
+func (f FilterField) coerce(value string) (interface{}, error) {
+	if len(f.Values) > 0 && !slices.ContainsString(f.Values, value) {
+		return nil, fmt.Errorf("must be one of: %s", strings.Join(f.Values, ", "))
+	}
+
+	return coerceFilterValue(value, f.Type)
+}
+
+// ParseFilters compiles the query parameters `filter[<field>][<operator>]=<value>` into query mods, combined using
+// AND, e.g. `?filter[username][ilike]=max&filter[created_at][gte]=2026-10-19T00:00:00Z`. Unknown fields or
+// operators, repeated parameters and values failing type coercion are returned as details, so they can be
+// reported together with other invalid parameters via NewValidationError.
+func ParseFilters(c echo.Context, fields FilterFields) ([]qm.QueryMod, []*errs.HTTPValidationErrorDetail) {
+	var (
+		mods    []qm.QueryMod
+		details []*errs.HTTPValidationErrorDetail
+	)
+
+	params := c.QueryParams()
+
+	// sorted to build deterministic queries
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		if strings.HasPrefix(key, filterParamPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		mod, err := parseFilter(key, params[key], fields)
+		if err != nil {
+			details = append(details, InvalidField(key, InQuery, err.Error()))
+			continue
+		}
+
+		mods = append(mods, mod)
+	}
+
+	return mods, details
+}
+
+// ParseFilterAliases compiles the aliased query parameters into query mods like ParseFilters, invalid values are
+// reported using the parameter's name. Empty parameters are ignored.
+func ParseFilterAliases(c echo.Context, fields FilterFields, aliases FilterAliases) ([]qm.QueryMod, []*errs.HTTPValidationErrorDetail) {
+	var (
+		mods    []qm.QueryMod
+		details []*errs.HTTPValidationErrorDetail
+	)
+
+	params := c.QueryParams()
+
+	// sorted to build deterministic queries
+	keys := make([]string, 0, len(aliases))
+	for key := range aliases {
+		if len(params.Get(key)) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		alias := aliases[key]
+
+		op := alias.Operator
+		if len(op) == 0 {
+			op = filterDefaultOperator
+		}
+
+		mod, err := compileFilter(key, alias.Field, op, params[key], fields)
+		if err != nil {
+			details = append(details, InvalidField(key, InQuery, err.Error()))
+			continue
+		}
+
+		mods = append(mods, mod)
+	}
+
+	return mods, details
+}
+
+func parseFilter(key string, values []string, fields FilterFields) (qm.QueryMod, error) {
+	m := filterParamRegex.FindStringSubmatch(key)
+	if m == nil {
+		return nil, fmt.Errorf("%s must be formatted as filter[<field>][<operator>]", key)
+	}
+
+	name, op := m[1], FilterOperator(m[2])
+	if len(op) == 0 {
+		op = filterDefaultOperator
+	}
+
+	return compileFilter(key, name, op, values, fields)
+}
+
+// compileFilter compiles the values of the parameter key filtering the field name using op.
+func compileFilter(key string, name string, op FilterOperator, values []string, fields FilterFields) (qm.QueryMod, error) {
+	field, ok := fields[name]
+	if !ok {
+		allowed := make([]string, 0, len(fields))
+		for k := range fields {
+			allowed = append(allowed, k)
+		}
+		sort.Strings(allowed)
+
+		return nil, fmt.Errorf("filter field %q is unknown, allowed: %s", name, strings.Join(allowed, ", "))
+	}
+
+	if !field.allows(op) {
+		allowed := make([]string, 0, len(field.Operators))
+		for _, o := range field.Operators {
+			allowed = append(allowed, string(o))
+		}
+
+		return nil, fmt.Errorf("filter operator %q is not allowed for %s, allowed: %s", op, name, strings.Join(allowed, ", "))
+	}
+
+	if len(values) != 1 {
+		return nil, fmt.Errorf("%s is provided multiple times", key)
+	}
+	value := values[0]
+
+	if len(field.JSONKey) > 0 {
+		return parseJSONFilter(key, op, value, field)
+	}
+
+	switch op {
+	case FilterIsNull:
+		isNull, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a boolean", key)
+		}
+		if isNull {
+			return qm.Where(field.Column + " IS NULL"), nil
+		}
+		return qm.Where(field.Column + " IS NOT NULL"), nil
+
+	case FilterILike:
+		if len(value) == 0 {
+			return nil, fmt.Errorf("%s must not be empty", key)
+		}
+		return db.ILike("%"+db.EscapeLike(value)+"%", field.Column), nil
+
+	case FilterSearch:
+		search := db.TextSearch{Config: field.TSConfig, Vector: field.Column, Query: value}
+		if !search.Valid() {
+			return nil, fmt.Errorf("%s must contain at least one word", key)
+		}
+		return search.Where(), nil
+
+	case FilterIn:
+		parts := strings.Split(value, filterInValueSeparator)
+		if len(parts) > filterMaxInValues {
+			return nil, fmt.Errorf("%s must not contain more than %d values", key, filterMaxInValues)
+		}
+
+		args := make([]interface{}, 0, len(parts))
+		for _, part := range parts {
+			v, err := field.coerce(part)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s", key, err)
+			}
+			args = append(args, v)
+		}
+		return qm.Where(fmt.Sprintf("%s IN (%s)", field.Column, strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ")), args...), nil
+	}
+
+	v, err := field.coerce(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s", key, err)
+	}
+
+	var cmp string
+	switch op {
+	case FilterContains:
+		return qm.Where(fmt.Sprintf("? = ANY(%s)", field.Column), v), nil
+	case FilterEq:
+		cmp = "="
+	case FilterNeq:
+		cmp = "<>"
+	case FilterLt:
+		cmp = "<"
+	case FilterLte:
+		cmp = "<="
+	case FilterGt:
+		cmp = ">"
+	case FilterGte:
+		cmp = ">="
+	default:
+		return nil, fmt.Errorf("filter operator %q is unknown", op)
+	}
+
+	return qm.Where(fmt.Sprintf("%s %s ?", field.Column, cmp), v), nil
+}
+
+// parseJSONFilter filters the key of the JSONB column via db.WhereJSONKey, supporting the comparison operators and FilterILike.
+func parseJSONFilter(key string, op FilterOperator, value string, field FilterField) (qm.QueryMod, error) {
+	var v interface{}
+	switch op {
+	case FilterIsNull, FilterIn, FilterSearch, FilterContains:
+		return nil, fmt.Errorf("filter operator %q is not supported for JSON fields", op)
+	case FilterILike:
+		if len(value) == 0 {
+			return nil, fmt.Errorf("%s must not be empty", key)
+		}
+		v = "%" + db.EscapeLike(value) + "%"
+	default:
+		var err error
+		if v, err = field.coerce(value); err != nil {
+			return nil, fmt.Errorf("%s %s", key, err)
+		}
+	}
+
+	// the filter operators share their names with the operators of db.WhereJSON
+	mod, err := db.WhereJSONKey(field.Table, field.Column, []string{field.JSONKey}, string(op), v)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not supported: %s", key, err)
+	}
+
+	return mod, nil
+}
+
+func coerceFilterValue(value string, t FilterType) (interface{}, error) {
+	switch t {
+	case FilterInt:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, errors.New("must be an integer")
+		}
+		return v, nil
+	case FilterFloat:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, errors.New("must be a number")
+		}
+		return v, nil
+	case FilterBool:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, errors.New("must be a boolean")
+		}
+		return v, nil
+	case FilterTime:
+		v, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, errors.New("must be a RFC 3339 timestamp")
+		}
+		return v, nil
+	case FilterUUID:
+		if _, err := uuid.Parse(value); err != nil {
+			return nil, errors.New("must be a UUID")
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,196 @@
+package request_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/api/request"
+	models "github.com/driif/echo-go-starter/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var testFilters = request.FilterFields{
+	"id": {
+		Column:    "users.id",
+		Type:      request.FilterUUID,
+		Operators: []request.FilterOperator{request.FilterEq, request.FilterIn},
+	},
+	"username": {
+		Column:    "users.username",
+		Operators: []request.FilterOperator{request.FilterEq, request.FilterNeq, request.FilterILike, request.FilterIsNull},
+	},
+	"age": {
+		Column:    "users.age",
+		Type:      request.FilterInt,
+		Operators: []request.FilterOperator{request.FilterIn, request.FilterGt, request.FilterLte},
+	},
+	"created_at": {
+		Column:    "users.created_at",
+		Type:      request.FilterTime,
+		Operators: []request.FilterOperator{request.FilterGte, request.FilterLt},
+	},
+	"scope": {
+		Column:    "users.scopes",
+		Operators: []request.FilterOperator{request.FilterContains},
+		Values:    []string{"app", "cms"},
+	},
+	"search": {
+		Column:    "to_tsvector('simple', users.username)",
+		Operators: []request.FilterOperator{request.FilterSearch},
+	},
+	"country": {
+		Table:     "users",
+		Column:    "profile",
+		JSONKey:   "country",
+		Operators: []request.FilterOperator{request.FilterEq, request.FilterILike},
+	},
+	"rating": {
+		Table:     "users",
+		Column:    "profile",
+		JSONKey:   "rating",
+		Type:      request.FilterInt,
+		Operators: []request.FilterOperator{request.FilterGte, request.FilterIsNull},
+	},
+}
+
+func buildFilterQuery(t *testing.T, params url.Values) (string, []interface{}) {
+	t.Helper()
+
+	mods, details := request.ParseFilters(newQueryContext(params.Encode()), testFilters)
+	require.Empty(t, details)
+
+	return queries.BuildQuery(models.NewQuery(append([]qm.QueryMod{qm.Select("*"), qm.From("users")}, mods...)...))
+}
+
+func TestParseFilters(t *testing.T) {
+	sql, args := buildFilterQuery(t, url.Values{
+		"filter[username][ilike]":  {"max_"},
+		"filter[created_at][gte]":  {"2026-10-19T00:00:00Z"},
+		"filter[age][in]":          {"18,21"},
+		"filter[age][gt]":          {"16"},
+		"filter[country]":          {"Austria"},
+		"filter[search][search]":   {"max muster"},
+		"filter[username][isnull]": {"false"},
+		"filter[scope][contains]":  {"cms"},
+		"page":                     {"2"},
+	})
+
+	assert.Equal(t, `SELECT * FROM "users" WHERE (users.age > $1) AND (users.age IN ($2, $3)) AND (users.profile->>'country' = $4) AND (users.created_at >= $5) AND ($6 = ANY(users.scopes)) AND (to_tsvector('simple', users.username) @@ to_tsquery('simple', $7)) AND (users.username ILIKE $8) AND (users.username IS NOT NULL);`, sql)
+	assert.Equal(t, []interface{}{
+		int64(16),
+		int64(18), int64(21),
+		"Austria",
+		time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		"cms",
+		"'max':* & 'muster':*",
+		`%max\_%`,
+	}, args)
+}
+
+func TestParseFiltersJSON(t *testing.T) {
+	sql, args := buildFilterQuery(t, url.Values{
+		"filter[country][ilike]": {"aus"},
+		"filter[rating][gte]":    {"4"},
+	})
+
+	assert.Equal(t, `SELECT * FROM "users" WHERE (users.profile->>'country' ILIKE $1) AND ((users.profile->>'rating')::numeric >= $2);`, sql)
+	assert.Equal(t, []interface{}{"%aus%", int64(4)}, args)
+
+	_, details := request.ParseFilters(newQueryContext("filter[rating][isnull]=true"), testFilters)
+	require.Len(t, details, 1)
+	assert.Equal(t, `filter operator "isnull" is not supported for JSON fields`, *details[0].Error)
+
+	_, details = request.ParseFilters(newQueryContext("filter[verified][gt]=true"), request.FilterFields{
+		"verified": {Table: "users", Column: "profile", JSONKey: "verified", Type: request.FilterBool, Operators: []request.FilterOperator{request.FilterGt}},
+	})
+	require.Len(t, details, 1)
+	assert.Equal(t, `filter[verified][gt] is not supported: operator "gt" is not supported for bool`, *details[0].Error)
+}
+
+func TestParseFiltersSearchMetacharacters(t *testing.T) {
+	tests := []struct {
+		value string
+		query string
+	}{
+		{`max\`, `'max':*`},
+		{`max\' muster`, `'max':* & 'muster':*`},
+		{`max: & !muster (`, `'max:':* & '&':* & '!muster':* & '(':*`},
+		{`max|muster:*`, `'max|muster:*':*`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			_, args := buildFilterQuery(t, url.Values{"filter[search][search]": {tt.value}})
+			assert.Equal(t, []interface{}{tt.query}, args)
+		})
+	}
+}
+
+func TestParseFiltersEmpty(t *testing.T) {
+	mods, details := request.ParseFilters(newQueryContext("page=1&sort=-created_at"), testFilters)
+	assert.Empty(t, mods)
+	assert.Empty(t, details)
+}
+
+func TestParseFiltersInvalid(t *testing.T) {
+	tests := []struct {
+		key   string
+		value string
+		msg   string
+	}{
+		{"filter[password]", "secret", `filter field "password" is unknown, allowed: age, country, created_at, id, rating, scope, search, username`},
+		{"filter[username][gt]", "max", `filter operator "gt" is not allowed for username, allowed: eq, neq, ilike, isnull`},
+		{"filter[username][eq][x]", "max", "filter[username][eq][x] must be formatted as filter[<field>][<operator>]"},
+		{"filter[age][gt]", "old", "filter[age][gt] must be an integer"},
+		{"filter[age][in]", "18,x", "filter[age][in] must be an integer"},
+		{"filter[id]", "1", "filter[id] must be a UUID"},
+		{"filter[created_at][lt]", "2026-10-19", "filter[created_at][lt] must be a RFC 3339 timestamp"},
+		{"filter[username][isnull]", "maybe", "filter[username][isnull] must be a boolean"},
+		{"filter[username][ilike]", "", "filter[username][ilike] must not be empty"},
+		{"filter[search][search]", " ' ", "filter[search][search] must contain at least one word"},
+		{"filter[search][search]", ` \' \ `, "filter[search][search] must contain at least one word"},
+		{"filter[scope][contains]", "root", "filter[scope][contains] must be one of: app, cms"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			_, details := request.ParseFilters(newQueryContext(url.Values{tt.key: {tt.value}}.Encode()), testFilters)
+			require.Len(t, details, 1)
+			assert.Equal(t, tt.key, *details[0].Key)
+			assert.Equal(t, "query", *details[0].In)
+			assert.Equal(t, tt.msg, *details[0].Error)
+		})
+	}
+}
+
+func TestParseFiltersRepeated(t *testing.T) {
+	_, details := request.ParseFilters(newQueryContext("filter[username]=max&filter[username]=erika"), testFilters)
+	require.Len(t, details, 1)
+	assert.Equal(t, "filter[username] is provided multiple times", *details[0].Error)
+}
+
+func TestParseFilterAliases(t *testing.T) {
+	aliases := request.FilterAliases{
+		"q":     {Field: "username", Operator: request.FilterILike},
+		"scope": {Field: "scope", Operator: request.FilterContains},
+		"id":    {Field: "id"},
+	}
+
+	mods, details := request.ParseFilterAliases(newQueryContext("q=max_&scope=cms&id="), testFilters, aliases)
+	require.Empty(t, details)
+
+	sql, args := queries.BuildQuery(models.NewQuery(append([]qm.QueryMod{qm.Select("*"), qm.From("users")}, mods...)...))
+	assert.Equal(t, `SELECT * FROM "users" WHERE (users.username ILIKE $1) AND ($2 = ANY(users.scopes));`, sql)
+	assert.Equal(t, []interface{}{`%max\_%`, "cms"}, args)
+
+	_, details = request.ParseFilterAliases(newQueryContext("scope=root&id=1"), testFilters, aliases)
+	require.Len(t, details, 2)
+	assert.Equal(t, "id", *details[0].Key)
+	assert.Equal(t, "id must be a UUID", *details[0].Error)
+	assert.Equal(t, "scope", *details[1].Key)
+	assert.Equal(t, "scope must be one of: app, cms", *details[1].Error)
+}
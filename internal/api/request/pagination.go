@@ -0,0 +1,191 @@
+package request
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// PaginationOptions configure the offset pagination and sorting of a list endpoint.
+type PaginationOptions struct {
+	DefaultLimit int
+	MaxLimit     int
+	// SortFields maps the keys accepted by `sort` to the (trusted) columns ordered by,
+	// e.g. "created_at" to models.UserTableColumns.CreatedAt.
+	SortFields map[string]string
+	// DefaultSort is applied if no `sort` is provided, using the same syntax, e.g. "-created_at".
+	DefaultSort string
+	// TieBreaker is a unique column appended to the order (unless already included) to keep pages stable,
+	// e.g. the primary key.
+	TieBreaker string
+	// DefaultTotal controls whether the total count is computed unless requested via `total`.
+	DefaultTotal bool
+}
+
+// SortField is a single column to order by.
+type SortField struct {
+	Column string
+	Desc   bool
+}
+
+// Pagination is the parsed offset pagination and sorting of a list request.
+type Pagination struct {
+	Page  int
+	Limit int
+	Sort  []SortField
+	// IncludeTotal reports whether the total count was requested.
+	IncludeTotal bool
+}
+
+// ParsePagination parses the query parameters `page`, `limit`, `sort` (comma separated keys, prefixed by `-` for
+// descending order, e.g. `-created_at,username`) and `total`. Invalid parameters are returned as details,
+// so they can be reported together with other invalid parameters via NewValidationError.
+func ParsePagination(c echo.Context, opts PaginationOptions) (*Pagination, []*errs.HTTPValidationErrorDetail) {
+	var details []*errs.HTTPValidationErrorDetail
+
+	p := &Pagination{Page: 1, Limit: opts.DefaultLimit, IncludeTotal: opts.DefaultTotal}
+
+	if v := c.QueryParam("page"); len(v) > 0 {
+		page, err := strconv.Atoi(v)
+		if err != nil || page < 1 {
+			details = append(details, InvalidField("page", InQuery, "page must be a positive integer"))
+		} else {
+			p.Page = page
+		}
+	}
+
+	if v := c.QueryParam("limit"); len(v) > 0 {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > opts.MaxLimit {
+			details = append(details, InvalidField("limit", InQuery, fmt.Sprintf("limit must be between 1 and %d", opts.MaxLimit)))
+		} else {
+			p.Limit = limit
+		}
+	}
+
+	if p.Limit > 0 && p.Page > math.MaxInt32/p.Limit {
+		details = append(details, InvalidField("page", InQuery, "page is out of range"))
+	}
+
+	if v := c.QueryParam("total"); len(v) > 0 {
+		total, err := strconv.ParseBool(v)
+		if err != nil {
+			details = append(details, InvalidField("total", InQuery, "total must be a boolean"))
+		} else {
+			p.IncludeTotal = total
+		}
+	}
+
+	sortParam := c.QueryParam("sort")
+	if len(sortParam) == 0 {
+		sortParam = opts.DefaultSort
+	}
+
+	if len(sortParam) > 0 {
+		sortFields, err := parseSort(sortParam, opts.SortFields)
+		if err != nil {
+			details = append(details, InvalidField("sort", InQuery, err.Error()))
+		} else {
+			p.Sort = sortFields
+		}
+	}
+
+	if len(opts.TieBreaker) > 0 && !p.sortsBy(opts.TieBreaker) {
+		p.Sort = append(p.Sort, SortField{Column: opts.TieBreaker})
+	}
+
+	return p, details
+}
+
+func parseSort(param string, fields map[string]string) ([]SortField, error) {
+	keys := strings.Split(param, ",")
+	res := make([]SortField, 0, len(keys))
+	seen := make(map[string]struct{}, len(keys))
+
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+
+		desc := strings.HasPrefix(key, "-")
+		key = strings.TrimPrefix(key, "-")
+
+		column, ok := fields[key]
+		if !ok {
+			allowed := make([]string, 0, len(fields))
+			for k := range fields {
+				allowed = append(allowed, k)
+			}
+			sort.Strings(allowed)
+
+			return nil, fmt.Errorf("sort key %q is unknown, allowed: %s", key, strings.Join(allowed, ", "))
+		}
+
+		if _, ok := seen[key]; ok {
+			return nil, fmt.Errorf("sort key %q is provided multiple times", key)
+		}
+		seen[key] = struct{}{}
+
+		res = append(res, SortField{Column: column, Desc: desc})
+	}
+
+	return res, nil
+}
+
+func (p *Pagination) sortsBy(column string) bool {
+	for _, f := range p.Sort {
+		if f.Column == column {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Offset returns the number of rows skipped for the page.
+func (p *Pagination) Offset() int {
+	return (p.Page - 1) * p.Limit
+}
+
+// OrderBy returns the ORDER BY clause, empty if not sorted.
+func (p *Pagination) OrderBy() string {
+	order := make([]string, 0, len(p.Sort))
+	for _, f := range p.Sort {
+		if f.Desc {
+			order = append(order, f.Column+" DESC")
+		} else {
+			order = append(order, f.Column+" ASC")
+		}
+	}
+
+	return strings.Join(order, ", ")
+}
+
+// QueryMods returns the query mods ordering, limiting and offsetting the query to the page.
+func (p *Pagination) QueryMods() []qm.QueryMod {
+	mods := make([]qm.QueryMod, 0, 3)
+	if orderBy := p.OrderBy(); len(orderBy) > 0 {
+		mods = append(mods, qm.OrderBy(orderBy))
+	}
+
+	return append(mods, qm.Limit(p.Limit), qm.Offset(p.Offset()))
+}
+
+// Total calls count if the total count was requested, returning null otherwise.
+func (p *Pagination) Total(count func() (int64, error)) (null.Int64, error) {
+	if !p.IncludeTotal {
+		return null.Int64{}, nil
+	}
+
+	total, err := count()
+	if err != nil {
+		return null.Int64{}, err
+	}
+
+	return null.Int64From(total), nil
+}
@@ -0,0 +1,115 @@
+package request_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/driif/echo-go-starter/internal/api/request"
+	models "github.com/driif/echo-go-starter/internal/models"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var testPagination = request.PaginationOptions{
+	DefaultLimit: 20,
+	MaxLimit:     100,
+	SortFields: map[string]string{
+		"created_at": "users.created_at",
+		"username":   "users.username",
+	},
+	DefaultSort:  "-created_at",
+	TieBreaker:   "users.id",
+	DefaultTotal: true,
+}
+
+func newQueryContext(query string) echo.Context {
+	req := httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+
+	return echo.New().NewContext(req, httptest.NewRecorder())
+}
+
+func buildPaginationQuery(p *request.Pagination) string {
+	sql, _ := queries.BuildQuery(models.NewQuery(append([]qm.QueryMod{qm.Select("*"), qm.From("users")}, p.QueryMods()...)...))
+
+	return sql
+}
+
+func TestParsePaginationDefaults(t *testing.T) {
+	p, details := request.ParsePagination(newQueryContext(""), testPagination)
+	require.Empty(t, details)
+
+	assert.Equal(t, 1, p.Page)
+	assert.Equal(t, 20, p.Limit)
+	assert.True(t, p.IncludeTotal)
+	assert.Equal(t, "users.created_at DESC, users.id ASC", p.OrderBy())
+	assert.Equal(t, `SELECT * FROM "users" ORDER BY users.created_at DESC, users.id ASC LIMIT 20;`, buildPaginationQuery(p))
+}
+
+func TestParsePagination(t *testing.T) {
+	p, details := request.ParsePagination(newQueryContext("page=3&limit=10&sort=username,-created_at&total=false"), testPagination)
+	require.Empty(t, details)
+
+	assert.Equal(t, 3, p.Page)
+	assert.Equal(t, 10, p.Limit)
+	assert.Equal(t, 20, p.Offset())
+	assert.False(t, p.IncludeTotal)
+	assert.Equal(t, []request.SortField{
+		{Column: "users.username"},
+		{Column: "users.created_at", Desc: true},
+		{Column: "users.id"},
+	}, p.Sort)
+	assert.Equal(t, `SELECT * FROM "users" ORDER BY users.username ASC, users.created_at DESC, users.id ASC LIMIT 10 OFFSET 20;`, buildPaginationQuery(p))
+}
+
+func TestParsePaginationInvalid(t *testing.T) {
+	tests := []struct {
+		query string
+		key   string
+		msg   string
+	}{
+		{"page=0", "page", "page must be a positive integer"},
+		{"page=abc", "page", "page must be a positive integer"},
+		{"page=99999999999", "page", "page is out of range"},
+		{"limit=101", "limit", "limit must be between 1 and 100"},
+		{"limit=-1", "limit", "limit must be between 1 and 100"},
+		{"total=maybe", "total", "total must be a boolean"},
+		{"sort=password", "sort", `sort key "password" is unknown, allowed: created_at, username`},
+		{"sort=-username,username", "sort", `sort key "username" is provided multiple times`},
+		{"sort=username,", "sort", `sort key "" is unknown, allowed: created_at, username`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, details := request.ParsePagination(newQueryContext(tt.query), testPagination)
+			require.Len(t, details, 1)
+			assert.Equal(t, tt.key, *details[0].Key)
+			assert.Equal(t, "query", *details[0].In)
+			assert.Equal(t, tt.msg, *details[0].Error)
+		})
+	}
+}
+
+func TestPaginationTotal(t *testing.T) {
+	p, _ := request.ParsePagination(newQueryContext(""), testPagination)
+	total, err := p.Total(func() (int64, error) { return 42, nil })
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), total.Int64)
+	assert.True(t, total.Valid)
+
+	cause := errors.New("count failed")
+	_, err = p.Total(func() (int64, error) { return 0, cause })
+	assert.ErrorIs(t, err, cause)
+
+	p, _ = request.ParsePagination(newQueryContext("total=false"), testPagination)
+	total, err = p.Total(func() (int64, error) {
+		t.Fatal("count must not be called")
+		return 0, nil
+	})
+	require.NoError(t, err)
+	assert.False(t, total.Valid)
+}
@@ -1,21 +1,50 @@
 package router
 
 import (
+	"fmt"
+
+	"github.com/driif/echo-go-starter/internal/api/admin"
+	"github.com/driif/echo-go-starter/internal/api/auth"
+	"github.com/driif/echo-go-starter/internal/api/management"
+	"github.com/driif/echo-go-starter/internal/api/module"
+	"github.com/driif/echo-go-starter/internal/api/user"
 	"github.com/driif/echo-go-starter/internal/server"
 	mdwr "github.com/driif/echo-go-starter/internal/server/net/middleware"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
 
+// Modules lists the registration funcs of all feature modules. Routes are attached in this order.
+var Modules = []func(r *module.Registry){
+	management.Register,
+	auth.Register,
+	user.Register,
+	admin.Register,
+}
+
+// NewRegistry collects the routes of all modules and validates them, e.g. checking for duplicate routes.
+func NewRegistry() (*module.Registry, error) {
+	r := module.NewRegistry()
+	for _, register := range Modules {
+		register(r)
+	}
+
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
 // Attaches a router with configurirable middleware and all the routes to the server
 func InitGroups(s *server.Server) {
 	s.Router = &server.Router{
 		// All Available Routes
 		Routes: nil,
 
-		Root: s.Echo.Group(""),
+		Root: s.Echo.Group(module.GroupRoot.Prefix()),
 
-		Management: s.Echo.Group("/-", middleware.KeyAuthWithConfig(middleware.KeyAuthConfig{
+		Management: s.Echo.Group(module.GroupManagement.Prefix(), middleware.KeyAuthWithConfig(middleware.KeyAuthConfig{
 			KeyLookup: "query:mgmt-secret",
 			Validator: func(key string, c echo.Context) (bool, error) {
 				return key == s.Config.Management.Secret, nil
@@ -28,20 +57,62 @@ func InitGroups(s *server.Server) {
 				return false
 			},
 		}), mdwr.NoCache()),
+
+		V1Auth:  s.Echo.Group(module.GroupV1Auth.Prefix()),
+		V1User:  s.Echo.Group(module.GroupV1User.Prefix()),
+		V1Admin: s.Echo.Group(module.GroupV1Admin.Prefix()),
 	}
 }
 
-func AttachRoutes(s *server.Server) {
-	// Attach all the routes
-	s.Router.Routes = []*echo.Route{
-		// == MANAGEMENT == //
-		// management.GetVersionRoute(s),
-		// management.GetDbVersionRoute(s),
-		// == USER == //
-		// user.GetMeRoute(s),
-		// user.CreateUserRoute(s),
-		// user.UpdateUserRoute(s),
-		// user.DeleteUserRoute(s),
+// AttachRoutes attaches all routes registered by our modules to their groups, wrapping them in the
+// auth middleware as required by each route. Returns an error if the registered routes are invalid.
+func AttachRoutes(s *server.Server) error {
+	r, err := NewRegistry()
+	if err != nil {
+		return err
+	}
+
+	routes := r.Routes()
+	s.Router.Routes = make([]*echo.Route, 0, len(routes))
+
+	for _, route := range routes {
+		g, err := group(s, route.Group)
+		if err != nil {
+			return err
+		}
+
+		m := make([]echo.MiddlewareFunc, 0, len(route.Middleware)+1)
+		if route.Auth != mdwr.AuthModeNone {
+			m = append(m, mdwr.AuthWithConfig(mdwr.AuthConfig{
+				Mode:   route.Auth,
+				Scopes: route.Scopes,
+				DB:     s.DB,
+				JWT:    s.JWT,
+			}))
+		}
+		for _, mf := range route.Middleware {
+			m = append(m, mf(s))
+		}
+
+		s.Router.Routes = append(s.Router.Routes, g.Add(route.Method, route.Path, route.Handler(s), m...))
+	}
+
+	return nil
+}
 
+func group(s *server.Server, g module.Group) (*echo.Group, error) {
+	switch g {
+	case module.GroupRoot:
+		return s.Router.Root, nil
+	case module.GroupManagement:
+		return s.Router.Management, nil
+	case module.GroupV1Auth:
+		return s.Router.V1Auth, nil
+	case module.GroupV1User:
+		return s.Router.V1User, nil
+	case module.GroupV1Admin:
+		return s.Router.V1Admin, nil
+	default:
+		return nil, fmt.Errorf("unknown route group %q", g)
 	}
 }
@@ -0,0 +1,28 @@
+package router_test
+
+import (
+	"testing"
+
+	"github.com/driif/echo-go-starter/internal/api/router"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRegistry(t *testing.T) {
+	r, err := router.NewRegistry()
+	require.NoError(t, err)
+	require.NotEmpty(t, r.Routes())
+}
+
+func TestJWKSRouteIsPublic(t *testing.T) {
+	r, err := router.NewRegistry()
+	require.NoError(t, err)
+
+	for _, route := range r.Routes() {
+		if route.FullPath() == "/-/jwks.json" {
+			require.Equal(t, "none", route.AuthRequirement())
+			return
+		}
+	}
+
+	t.Fatal("JWKS route not registered")
+}
@@ -0,0 +1,353 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/api/request"
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/net/auth"
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+	"github.com/driif/echo-go-starter/pkg/auth/apikey"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/driif/echo-go-starter/pkg/slices"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const apiKeyNameMaxLength = 255
+
+type apiKeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+}
+
+type apiKeysResponse struct {
+	Items []apiKeyResponse `json:"items"`
+}
+
+type createdAPIKeyResponse struct {
+	apiKeyResponse
+	// Key is only returned once on creation.
+	Key string `json:"key"`
+}
+
+func newAPIKeyResponse(k *models.APIKey) apiKeyResponse {
+	res := apiKeyResponse{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     k.Scopes,
+		ExpiresAt:  k.ExpiresAt.Ptr(),
+		LastUsedAt: k.LastUsedAt.Ptr(),
+		CreatedAt:  k.CreatedAt,
+		UpdatedAt:  k.UpdatedAt,
+	}
+
+	if res.Scopes == nil {
+		res.Scopes = []string{}
+	}
+
+	return res
+}
+
+type postAPIKeyPayload struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+func (p *postAPIKeyPayload) Validate() []*errs.HTTPValidationErrorDetail {
+	var details []*errs.HTTPValidationErrorDetail
+
+	if d := validateAPIKeyName(p.Name); d != nil {
+		details = append(details, d)
+	}
+
+	if p.Scopes == nil {
+		details = append(details, request.InvalidField("scopes", request.InBody, "scopes is required, provide an empty list for no scopes"))
+	} else {
+		p.Scopes = slices.UniqueString(p.Scopes)
+	}
+
+	if p.ExpiresAt != nil && !p.ExpiresAt.After(time.Now()) {
+		details = append(details, request.InvalidField("expiresAt", request.InBody, "expiresAt must be in the future"))
+	}
+
+	return details
+}
+
+type patchAPIKeyPayload struct {
+	Name   *string   `json:"name"`
+	Scopes *[]string `json:"scopes"`
+}
+
+func (p *patchAPIKeyPayload) Validate() []*errs.HTTPValidationErrorDetail {
+	var details []*errs.HTTPValidationErrorDetail
+
+	if p.Name != nil {
+		if d := validateAPIKeyName(*p.Name); d != nil {
+			details = append(details, d)
+		}
+	}
+
+	if p.Scopes != nil {
+		if *p.Scopes == nil {
+			details = append(details, request.InvalidField("scopes", request.InBody, "scopes must be a list"))
+		} else {
+			scopes := slices.UniqueString(*p.Scopes)
+			p.Scopes = &scopes
+		}
+	}
+
+	return details
+}
+
+func validateAPIKeyName(name string) *errs.HTTPValidationErrorDetail {
+	if len(name) == 0 {
+		return request.InvalidField("name", request.InBody, "name is required")
+	}
+	if utf8.RuneCountInString(name) > apiKeyNameMaxLength {
+		return request.InvalidField("name", request.InBody, fmt.Sprintf("name must not exceed %d characters", apiKeyNameMaxLength))
+	}
+
+	return nil
+}
+
+// validateAPIKeyScopes ensures keys are restricted to a subset of the user's scopes.
+func validateAPIKeyScopes(user *models.User, scopes []string) error {
+	for _, scope := range scopes {
+		if !auth.IsKnownScope(scope) || !slices.ContainsString(user.Scopes, scope) {
+			return request.NewValidationError(request.InvalidField("scopes", request.InBody, fmt.Sprintf("scope %q is unknown or not possessed by the user", scope)))
+		}
+	}
+
+	return nil
+}
+
+// getAPIKeysHandler lists all API keys of the current user, most recent first.
+func getAPIKeysHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user := auth.UserFromContext(ctx)
+
+		keys, err := models.APIKeys(
+			models.APIKeyWhere.UserID.EQ(user.ID),
+			qm.OrderBy(models.APIKeyColumns.CreatedAt+" DESC, "+models.APIKeyColumns.ID),
+		).All(ctx, s.DB)
+		if err != nil {
+			logs.LogFromContext(ctx).Error().Err(err).Msg("Failed to load API keys")
+			return err
+		}
+
+		res := apiKeysResponse{Items: make([]apiKeyResponse, 0, len(keys))}
+		for _, k := range keys {
+			res.Items = append(res.Items, newAPIKeyResponse(k))
+		}
+
+		return c.JSON(http.StatusOK, res)
+	}
+}
+
+// postAPIKeyHandler creates an API key for the current user, the key itself is only returned once.
+// Keys can't be created using an API key, their scopes must be a subset of the user's scopes.
+func postAPIKeyHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user := auth.UserFromContext(ctx)
+
+		var body postAPIKeyPayload
+		if err := request.BindBody(c, &body); err != nil {
+			return err
+		}
+
+		if err := validateAPIKeyScopes(user, body.Scopes); err != nil {
+			return err
+		}
+
+		generated, err := apikey.Generate()
+		if err != nil {
+			logs.LogFromContext(ctx).Error().Err(err).Msg("Failed to generate API key")
+			return err
+		}
+
+		key := &models.APIKey{
+			UserID:     user.ID,
+			Name:       body.Name,
+			Prefix:     generated.Prefix,
+			SecretHash: generated.Hash,
+			Scopes:     body.Scopes,
+			ExpiresAt:  null.TimeFromPtr(body.ExpiresAt),
+		}
+		err = db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			// serializes concurrent creations of the user to enforce the limit
+			if _, err := models.Users(models.UserWhere.ID.EQ(user.ID), qm.For("UPDATE")).One(ctx, tx); err != nil {
+				return err
+			}
+
+			n, err := models.APIKeys(models.APIKeyWhere.UserID.EQ(user.ID)).Count(ctx, tx)
+			if err != nil {
+				return err
+			}
+			if n >= int64(s.Config.Auth.APIKeyMaxPerUser) {
+				return apierrs.APIKeyLimitReached
+			}
+
+			return key.Insert(ctx, tx, boil.Infer())
+		})
+		if err != nil {
+			return handleAPIKeyError(c, err, "Failed to create API key")
+		}
+
+		logs.LogFromContext(ctx).Info().Str("apiKeyID", key.ID).Msg("Created API key")
+
+		return c.JSON(http.StatusCreated, createdAPIKeyResponse{
+			apiKeyResponse: newAPIKeyResponse(key),
+			Key:            generated.String(),
+		})
+	}
+}
+
+// getAPIKeyHandler returns an API key of the current user.
+func getAPIKeyHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		id, err := apiKeyIDFromPath(c)
+		if err != nil {
+			return err
+		}
+
+		key, err := findAPIKey(ctx, s.DB, auth.UserFromContext(ctx).ID, id)
+		if err != nil {
+			return handleAPIKeyError(c, err, "Failed to load API key")
+		}
+
+		return c.JSON(http.StatusOK, newAPIKeyResponse(key))
+	}
+}
+
+// patchAPIKeyHandler renames an API key of the current user or replaces its scopes, the expiry is immutable.
+func patchAPIKeyHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user := auth.UserFromContext(ctx)
+
+		id, err := apiKeyIDFromPath(c)
+		if err != nil {
+			return err
+		}
+
+		var body patchAPIKeyPayload
+		if err := request.BindBody(c, &body); err != nil {
+			return err
+		}
+
+		if body.Scopes != nil {
+			if err := validateAPIKeyScopes(user, *body.Scopes); err != nil {
+				return err
+			}
+		}
+
+		var key *models.APIKey
+		err = db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			key, err = findAPIKey(ctx, tx, user.ID, id, qm.For("UPDATE"))
+			if err != nil {
+				return err
+			}
+
+			if body.Name != nil {
+				key.Name = *body.Name
+			}
+			if body.Scopes != nil {
+				key.Scopes = *body.Scopes
+			}
+
+			_, err := key.Update(ctx, tx, boil.Whitelist(models.APIKeyColumns.Name, models.APIKeyColumns.Scopes, models.APIKeyColumns.UpdatedAt))
+			return err
+		})
+		if err != nil {
+			return handleAPIKeyError(c, err, "Failed to update API key")
+		}
+
+		return c.JSON(http.StatusOK, newAPIKeyResponse(key))
+	}
+}
+
+// deleteAPIKeyHandler revokes an API key of the current user.
+func deleteAPIKeyHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		id, err := apiKeyIDFromPath(c)
+		if err != nil {
+			return err
+		}
+
+		n, err := models.APIKeys(
+			models.APIKeyWhere.ID.EQ(id),
+			models.APIKeyWhere.UserID.EQ(auth.UserFromContext(ctx).ID),
+		).DeleteAll(ctx, s.DB)
+		if err != nil {
+			return handleAPIKeyError(c, err, "Failed to delete API key")
+		}
+		if n == 0 {
+			return apierrs.APIKeyNotFound
+		}
+
+		logs.LogFromContext(ctx).Info().Str("apiKeyID", id).Msg("Deleted API key")
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+func apiKeyIDFromPath(c echo.Context) (string, error) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return "", apierrs.NotUUID
+	}
+
+	return id, nil
+}
+
+func findAPIKey(ctx context.Context, exec boil.ContextExecutor, userID string, id string, mods ...qm.QueryMod) (*models.APIKey, error) {
+	mods = append(mods, models.APIKeyWhere.ID.EQ(id), models.APIKeyWhere.UserID.EQ(userID))
+
+	key, err := models.APIKeys(mods...).One(ctx, exec)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apierrs.APIKeyNotFound
+		}
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func handleAPIKeyError(c echo.Context, err error, msg string) error {
+	var httpErr *errs.HTTPError
+	if errors.As(err, &httpErr) {
+		logs.LogFromEchoContext(c).Debug().Err(err).Msg(msg)
+		return err
+	}
+
+	logs.LogFromEchoContext(c).Error().Err(err).Msg(msg)
+	return err
+}
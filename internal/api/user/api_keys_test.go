@@ -0,0 +1,96 @@
+package user_test
+
+import (
+	"net/http"
+	"testing"
+
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/net/middleware"
+	"github.com/driif/echo-go-starter/internal/server/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type apiKeyResponse struct {
+	ID     string   `json:"id"`
+	Prefix string   `json:"prefix"`
+	Scopes []string `json:"scopes"`
+	Key    string   `json:"key"`
+}
+
+func TestAPIKeyAuthentication(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		res := test.PerformRequest(t, s, "GET", "/v1/admin/users", nil, test.HeadersWithAPIKey(t, test.PlainAdmin1APIKeyAdmin))
+		require.Equal(t, http.StatusOK, res.Result().StatusCode)
+
+		res = test.PerformRequest(t, s, "GET", "/v1/users/me", nil, test.HeadersWithAPIKey(t, test.PlainAdmin1APIKeyApp))
+		require.Equal(t, http.StatusOK, res.Result().StatusCode)
+
+		// the secret is verified, not only the prefix
+		invalid := test.PlainAdmin1APIKeyApp[:len(test.PlainAdmin1APIKeyApp)-1] + "0"
+		res = test.PerformRequest(t, s, "GET", "/v1/users/me", nil, test.HeadersWithAPIKey(t, invalid))
+		test.RequireHTTPError(t, res, middleware.ErrAuthTokenInvalid)
+	})
+}
+
+func TestAPIKeyScopeIntersection(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		// the key itself is restricted to the app scope, although its user is an admin
+		res := test.PerformRequest(t, s, "GET", "/v1/admin/users", nil, test.HeadersWithAPIKey(t, test.PlainAdmin1APIKeyApp))
+		test.RequireHTTPError(t, res, middleware.ErrAuthMissingScopes)
+
+		// the key still lists the admin scope, but its user no longer possesses it
+		res = test.PerformRequest(t, s, "GET", "/v1/admin/users", nil, test.HeadersWithAPIKey(t, test.PlainUser1APIKeyStale))
+		test.RequireHTTPError(t, res, middleware.ErrAuthMissingScopes)
+
+		res = test.PerformRequest(t, s, "GET", "/v1/users/me", nil, test.HeadersWithAPIKey(t, test.PlainUser1APIKeyStale))
+		require.Equal(t, http.StatusOK, res.Result().StatusCode)
+	})
+}
+
+func TestAPIKeyCredentialManagementRejected(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		headers := test.HeadersWithAPIKey(t, test.PlainAdmin1APIKeyApp)
+
+		res := test.PerformRequest(t, s, "PUT", "/v1/users/me/password", test.GenericPayload{
+			"currentPassword": test.PlainTestUserPassword,
+			"newPassword":     test.PlainTestUserPassword + "123",
+		}, headers)
+		test.RequireHTTPError(t, res, apierrs.APIKeyNotAllowed)
+
+		res = test.PerformRequest(t, s, "POST", "/v1/users/me/api-keys", test.GenericPayload{
+			"name":   "Derived key",
+			"scopes": []string{"app"},
+		}, headers)
+		test.RequireHTTPError(t, res, apierrs.APIKeyNotAllowed)
+	})
+}
+
+func TestPostAPIKeyScopes(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		fix := test.Fixtures()
+		headers := test.HeadersWithAuth(t, fix.Admin1AccessToken1.Token)
+
+		res := test.PerformRequest(t, s, "POST", "/v1/users/me/api-keys", test.GenericPayload{
+			"name":   "Admin key",
+			"scopes": []string{"admin"},
+		}, headers)
+		require.Equal(t, http.StatusCreated, res.Result().StatusCode)
+
+		var response apiKeyResponse
+		test.ParseResponseBody(t, res, &response)
+		assert.Equal(t, []string{"admin"}, response.Scopes)
+		require.NotEmpty(t, response.Key)
+
+		res = test.PerformRequest(t, s, "GET", "/v1/admin/users", nil, test.HeadersWithAPIKey(t, response.Key))
+		require.Equal(t, http.StatusOK, res.Result().StatusCode)
+
+		// keys can't be granted scopes beyond the user's own
+		res = test.PerformRequest(t, s, "POST", "/v1/users/me/api-keys", test.GenericPayload{
+			"name":   "Superadmin key",
+			"scopes": []string{"superadmin"},
+		}, headers)
+		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
+	})
+}
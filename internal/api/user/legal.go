@@ -0,0 +1,39 @@
+package user
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/net/auth"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// postLegalHandler records the current time as legal acceptance, earlier acceptances are overwritten.
+func postLegalHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user := auth.UserFromContext(ctx)
+
+		var profile *models.AppUserProfile
+		err := db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			var err error
+			profile, err = upsertProfile(ctx, tx, user.ID, func(p *models.AppUserProfile) {
+				p.LegalAcceptedAt = null.TimeFrom(time.Now())
+			})
+
+			return err
+		})
+		if err != nil {
+			logs.LogFromContext(ctx).Error().Err(err).Msg("Failed to record legal acceptance")
+			return err
+		}
+
+		return c.JSON(http.StatusOK, newProfileResponse(profile))
+	}
+}
@@ -0,0 +1,229 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/driif/echo-go-starter/internal/api/request"
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/net/auth"
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+	mdwr "github.com/driif/echo-go-starter/internal/server/net/middleware"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"golang.org/x/text/language"
+)
+
+const displayNameMaxLength = 255
+
+type meResponse struct {
+	ID                  string           `json:"id"`
+	Username            *string          `json:"username"`
+	Scopes              []string         `json:"scopes"`
+	LastAuthenticatedAt *time.Time       `json:"lastAuthenticatedAt"`
+	CreatedAt           time.Time        `json:"createdAt"`
+	UpdatedAt           time.Time        `json:"updatedAt"`
+	Profile             *profileResponse `json:"profile"`
+}
+
+type profileResponse struct {
+	DisplayName     *string    `json:"displayName"`
+	Locale          *string    `json:"locale"`
+	LegalAcceptedAt *time.Time `json:"legalAcceptedAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
+
+// patchMePayload holds the profile fields to update. Omitted (or null) fields are left unchanged,
+// empty strings clear the field.
+type patchMePayload struct {
+	DisplayName *string `json:"displayName"`
+	Locale      *string `json:"locale"`
+}
+
+func (p *patchMePayload) Validate() []*errs.HTTPValidationErrorDetail {
+	var details []*errs.HTTPValidationErrorDetail
+
+	if p.DisplayName != nil && utf8.RuneCountInString(*p.DisplayName) > displayNameMaxLength {
+		details = append(details, request.InvalidField("displayName", request.InBody, fmt.Sprintf("displayName must not exceed %d characters", displayNameMaxLength)))
+	}
+
+	if p.Locale != nil && len(*p.Locale) > 0 {
+		tag, err := language.Parse(*p.Locale)
+		if err != nil {
+			details = append(details, request.InvalidField("locale", request.InBody, "locale must be a valid BCP 47 language tag"))
+		} else {
+			// store the canonical representation only
+			l := tag.String()
+			p.Locale = &l
+		}
+	}
+
+	return details
+}
+
+func getMeHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		user, err := loadCurrentUser(ctx, s)
+		if err != nil {
+			return err
+		}
+
+		profile, err := findProfile(ctx, s.DB, user.ID)
+		if err != nil {
+			logs.LogFromContext(ctx).Error().Err(err).Msg("Failed to load user profile")
+			return err
+		}
+
+		return c.JSON(http.StatusOK, newMeResponse(user, profile))
+	}
+}
+
+func patchMeHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := logs.LogFromContext(ctx)
+
+		var body patchMePayload
+		if err := request.BindBody(c, &body); err != nil {
+			return err
+		}
+
+		user, err := loadCurrentUser(ctx, s)
+		if err != nil {
+			return err
+		}
+
+		var profile *models.AppUserProfile
+		err = db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			var err error
+			profile, err = upsertProfile(ctx, tx, user.ID, func(p *models.AppUserProfile) {
+				if body.DisplayName != nil {
+					p.DisplayName = null.NewString(*body.DisplayName, len(*body.DisplayName) > 0)
+				}
+				if body.Locale != nil {
+					p.Locale = null.NewString(*body.Locale, len(*body.Locale) > 0)
+				}
+			})
+
+			return err
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to update user profile")
+			return err
+		}
+
+		return c.JSON(http.StatusOK, newMeResponse(user, profile))
+	}
+}
+
+// loadCurrentUser returns the authenticated user with all columns, see auth.LoadUserFromContext.
+func loadCurrentUser(ctx context.Context, s *server.Server) (*models.User, error) {
+	user, err := auth.LoadUserFromContext(ctx, s.DB)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// the user was deleted after the JWT was issued
+			logs.LogFromContext(ctx).Debug().Msg("Authenticated user no longer exists")
+			return nil, mdwr.ErrAuthTokenInvalid
+		}
+
+		logs.LogFromContext(ctx).Error().Err(err).Msg("Failed to load user")
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// findCurrentUserForUpdate loads and locks the authenticated user for the current transaction.
+func findCurrentUserForUpdate(ctx context.Context, tx boil.ContextExecutor) (*models.User, error) {
+	user, err := models.Users(models.UserWhere.ID.EQ(auth.UserFromContext(ctx).ID), qm.For("UPDATE")).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logs.LogFromContext(ctx).Debug().Msg("Authenticated user no longer exists")
+			return nil, mdwr.ErrAuthTokenInvalid
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func newMeResponse(user *models.User, profile *models.AppUserProfile) meResponse {
+	res := meResponse{
+		ID:                  user.ID,
+		Username:            user.Username.Ptr(),
+		Scopes:              user.Scopes,
+		LastAuthenticatedAt: user.LastAuthenticatedAt.Ptr(),
+		CreatedAt:           user.CreatedAt,
+		UpdatedAt:           user.UpdatedAt,
+	}
+
+	if res.Scopes == nil {
+		res.Scopes = []string{}
+	}
+
+	if profile != nil {
+		res.Profile = newProfileResponse(profile)
+	}
+
+	return res
+}
+
+func newProfileResponse(profile *models.AppUserProfile) *profileResponse {
+	return &profileResponse{
+		DisplayName:     profile.DisplayName.Ptr(),
+		Locale:          profile.Locale.Ptr(),
+		LegalAcceptedAt: profile.LegalAcceptedAt.Ptr(),
+		UpdatedAt:       profile.UpdatedAt,
+	}
+}
+
+// findProfile returns the user's profile or nil if the user has no profile yet.
+func findProfile(ctx context.Context, exec boil.ContextExecutor, userID string) (*models.AppUserProfile, error) {
+	profile, err := models.FindAppUserProfile(ctx, exec, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// upsertProfile applies update to the user's profile (locked for the transaction), creating the profile if it does not exist yet.
+func upsertProfile(ctx context.Context, tx boil.ContextExecutor, userID string, update func(p *models.AppUserProfile)) (*models.AppUserProfile, error) {
+	profile, err := models.AppUserProfiles(models.AppUserProfileWhere.UserID.EQ(userID), qm.For("UPDATE")).One(ctx, tx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if profile == nil {
+		profile = &models.AppUserProfile{UserID: userID}
+		update(profile)
+
+		if err := profile.Insert(ctx, tx, boil.Infer()); err != nil {
+			return nil, err
+		}
+
+		return profile, nil
+	}
+
+	update(profile)
+	if _, err := profile.Update(ctx, tx, boil.Infer()); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,226 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/driif/echo-go-starter/internal/api/attempts"
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/api/mfa"
+	"github.com/driif/echo-go-starter/internal/api/request"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+type mfaResponse struct {
+	Enabled                bool  `json:"enabled"`
+	Required               bool  `json:"required"`
+	RecoveryCodesRemaining int64 `json:"recoveryCodesRemaining"`
+}
+
+type recoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type mfaCodePayload struct {
+	Code string `json:"code"`
+}
+
+func (p *mfaCodePayload) Validate() []*errs.HTTPValidationErrorDetail {
+	if len(p.Code) == 0 {
+		return []*errs.HTTPValidationErrorDetail{request.InvalidField("code", request.InBody, "code is required")}
+	}
+
+	return nil
+}
+
+type deleteTOTPPayload struct {
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recoveryCode"`
+}
+
+func (p *deleteTOTPPayload) Validate() []*errs.HTTPValidationErrorDetail {
+	if (len(p.Code) == 0) == (len(p.RecoveryCode) == 0) {
+		return []*errs.HTTPValidationErrorDetail{request.InvalidField("code", request.InBody, "exactly one of code and recoveryCode is required")}
+	}
+
+	return nil
+}
+
+// getMFAHandler returns the 2FA status of the current user.
+func getMFAHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		user, err := loadCurrentUser(ctx, s)
+		if err != nil {
+			return err
+		}
+
+		res := mfaResponse{Required: mfa.Required(s.Config.Auth.MFA, user)}
+		if res.Enabled, err = mfa.Enabled(ctx, s.DB, user.ID); err != nil {
+			logs.LogFromContext(ctx).Error().Err(err).Msg("Failed to load 2FA status")
+			return err
+		}
+		if res.RecoveryCodesRemaining, err = mfa.RemainingRecoveryCodes(ctx, s.DB, user.ID); err != nil {
+			logs.LogFromContext(ctx).Error().Err(err).Msg("Failed to count recovery codes")
+			return err
+		}
+
+		return c.JSON(http.StatusOK, res)
+	}
+}
+
+// postTOTPHandler starts the TOTP enrollment of the current user, 2FA is enabled once confirmed via postTOTPConfirmHandler.
+func postTOTPHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		user, err := loadCurrentUser(ctx, s)
+		if err != nil {
+			return err
+		}
+
+		var enrollment mfa.Enrollment
+		err = db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			enrollment, err = mfa.Enroll(ctx, tx, s.Config.Auth.MFA, user)
+			return err
+		})
+		if err != nil {
+			return handleMFAError(c, err, "Failed to start 2FA enrollment")
+		}
+
+		return c.JSON(http.StatusOK, enrollment)
+	}
+}
+
+// postTOTPConfirmHandler enables 2FA for the current user by confirming a code of the pending enrollment.
+// The recovery codes are returned once and only persisted hashed.
+func postTOTPConfirmHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var body mfaCodePayload
+		if err := request.BindBody(c, &body); err != nil {
+			return err
+		}
+
+		user, err := loadCurrentUser(ctx, s)
+		if err != nil {
+			return err
+		}
+
+		var res recoveryCodesResponse
+		err = db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			res.RecoveryCodes, err = mfa.Confirm(ctx, tx, s.Config.Auth.MFA, user.ID, body.Code)
+			return err
+		})
+		if err != nil {
+			return handleMFAError(c, err, "Failed to confirm 2FA enrollment")
+		}
+
+		logs.LogFromContext(ctx).Info().Msg("Enabled 2FA")
+
+		return c.JSON(http.StatusOK, res)
+	}
+}
+
+// deleteTOTPHandler disables 2FA for the current user, requiring a TOTP or recovery code (wrong codes are throttled).
+// Users possessing a scope requiring 2FA can't disable it.
+func deleteTOTPHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var body deleteTOTPPayload
+		if err := request.BindBody(c, &body); err != nil {
+			return err
+		}
+
+		user, err := loadCurrentUser(ctx, s)
+		if err != nil {
+			return err
+		}
+
+		if mfa.Required(s.Config.Auth.MFA, user) {
+			return apierrs.MFARequired
+		}
+
+		err = withVerifiedMFA(c, s, user.ID, body.Code, body.RecoveryCode, func(tx boil.ContextExecutor) error {
+			_, err := mfa.Disable(ctx, tx, user.ID)
+			return err
+		})
+		if err != nil {
+			return handleMFAError(c, err, "Failed to disable 2FA")
+		}
+
+		logs.LogFromContext(ctx).Info().Msg("Disabled 2FA")
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// postRecoveryCodesHandler replaces all recovery codes of the current user, requiring a TOTP code (wrong codes are throttled).
+func postRecoveryCodesHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var body mfaCodePayload
+		if err := request.BindBody(c, &body); err != nil {
+			return err
+		}
+
+		user, err := loadCurrentUser(ctx, s)
+		if err != nil {
+			return err
+		}
+
+		var res recoveryCodesResponse
+		err = withVerifiedMFA(c, s, user.ID, body.Code, "", func(tx boil.ContextExecutor) error {
+			res.RecoveryCodes, err = mfa.RegenerateRecoveryCodes(ctx, tx, s.Config.Auth.MFA, user.ID)
+			return err
+		})
+		if err != nil {
+			return handleMFAError(c, err, "Failed to regenerate recovery codes")
+		}
+
+		return c.JSON(http.StatusOK, res)
+	}
+}
+
+// withVerifiedMFA runs fn within the transaction verifying the TOTP or recovery code (see mfa.Verify).
+// Wrong codes are tracked as failed attempts of the user, throttling guessing codes using a stolen session.
+func withVerifiedMFA(c echo.Context, s *server.Server, userID string, code string, recoveryCode string, fn func(tx boil.ContextExecutor) error) error {
+	ctx := c.Request().Context()
+	key := attempts.UserKey(attempts.ActionMFA, userID)
+
+	return attempts.Guard(c, s.DB, s.Config.Auth.Attempts, []attempts.Key{key}, func() (bool, error) {
+		err := db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+			if err := mfa.Verify(ctx, tx, userID, code, recoveryCode); err != nil {
+				return err
+			}
+
+			if err := attempts.Reset(ctx, tx, key); err != nil {
+				return err
+			}
+
+			return fn(tx)
+		})
+
+		return errors.Is(err, apierrs.MFAInvalidCode), err
+	})
+}
+
+func handleMFAError(c echo.Context, err error, msg string) error {
+	var httpErr *errs.HTTPError
+	if errors.As(err, &httpErr) {
+		logs.LogFromEchoContext(c).Debug().Err(err).Msg(msg)
+		return err
+	}
+
+	logs.LogFromEchoContext(c).Error().Err(err).Msg(msg)
+	return err
+}
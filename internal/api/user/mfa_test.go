@@ -0,0 +1,57 @@
+package user_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/test"
+	"github.com/driif/echo-go-starter/pkg/auth/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteTOTPThrottled(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		ctx := context.Background()
+		fix := test.Fixtures()
+		headers := test.HeadersWithAuth(t, fix.UserMFAAccessToken1.Token)
+
+		for i := 0; i < s.Config.Auth.Attempts.Username.FreeAttempts; i++ {
+			res := test.PerformRequest(t, s, "DELETE", "/v1/users/me/mfa/totp", test.GenericPayload{"code": "000000"}, headers)
+			test.RequireHTTPError(t, res, apierrs.MFAInvalidCode)
+		}
+
+		// even the correct code is rejected until the delay has passed
+		code, err := totp.Code(test.TestUserTOTPSecret, totp.Step(time.Now()))
+		require.NoError(t, err)
+
+		res := test.PerformRequest(t, s, "DELETE", "/v1/users/me/mfa/totp", test.GenericPayload{"code": code}, headers)
+		test.RequireHTTPError(t, res, apierrs.TooManyAttempts)
+
+		exists, err := models.UserTotpExists(ctx, s.DB, fix.UserMFA.ID)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+}
+
+func TestDeleteTOTPSuccess(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		ctx := context.Background()
+		fix := test.Fixtures()
+
+		code, err := totp.Code(test.TestUserTOTPSecret, totp.Step(time.Now()))
+		require.NoError(t, err)
+
+		res := test.PerformRequest(t, s, "DELETE", "/v1/users/me/mfa/totp", test.GenericPayload{"code": code}, test.HeadersWithAuth(t, fix.UserMFAAccessToken1.Token))
+		require.Equal(t, http.StatusNoContent, res.Result().StatusCode)
+
+		exists, err := models.UserTotpExists(ctx, s.DB, fix.UserMFA.ID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
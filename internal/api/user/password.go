@@ -0,0 +1,144 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/driif/echo-go-starter/internal/api/attempts"
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/api/request"
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/net/auth"
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+	"github.com/driif/echo-go-starter/pkg/auth/hashing"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+type putPasswordPayload struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+}
+
+func (p *putPasswordPayload) Validate() []*errs.HTTPValidationErrorDetail {
+	var details []*errs.HTTPValidationErrorDetail
+
+	if len(p.CurrentPassword) == 0 {
+		details = append(details, request.InvalidField("currentPassword", request.InBody, "currentPassword is required"))
+	}
+
+	if !auth.ValidPasswordLength(p.NewPassword) {
+		details = append(details, request.InvalidField("newPassword", request.InBody,
+			fmt.Sprintf("newPassword must be between %d and %d bytes long", auth.PasswordMinLength, auth.PasswordMaxLength)))
+	}
+
+	return details
+}
+
+// putPasswordHandler changes the password of the current user. All opaque access tokens except the one used
+// for this request, all refresh tokens and pending password reset tokens of the user are revoked.
+// Wrong current passwords are tracked as failed attempts of the user, throttling guessing it using a stolen session.
+func putPasswordHandler(s *server.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := logs.LogFromContext(ctx)
+		accessToken := auth.AccessTokenFromContext(ctx)
+
+		var body putPasswordPayload
+		if err := request.BindBody(c, &body); err != nil {
+			return err
+		}
+
+		hash, err := hashing.Hash(body.NewPassword, s.Config.Hashing)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to hash new password")
+			return err
+		}
+
+		key := attempts.UserKey(attempts.ActionPassword, auth.UserFromContext(ctx).ID)
+		err = attempts.Guard(c, s.DB, s.Config.Auth.Attempts, []attempts.Key{key}, func() (bool, error) {
+			err := db.WithTransaction(ctx, s.DB, func(tx boil.ContextExecutor) error {
+				if err := changePassword(ctx, tx, accessToken, body.CurrentPassword, hash); err != nil {
+					return err
+				}
+
+				return attempts.Reset(ctx, tx, key)
+			})
+
+			return errors.Is(err, apierrs.InvalidPassword), err
+		})
+		if err != nil {
+			var httpErr *errs.HTTPError
+			if errors.As(err, &httpErr) {
+				log.Debug().Err(err).Msg("Refusing to change password")
+				return err
+			}
+
+			log.Error().Err(err).Msg("Failed to change password")
+			return err
+		}
+
+		log.Info().Msg("Password changed, revoked all other sessions")
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// changePassword verifies the current password of the user, sets the new hash and revokes the user's other
+// credentials and pending 2FA challenges. The user is locked, so concurrent changes verify the current password
+// against the result of the previous one.
+func changePassword(ctx context.Context, tx boil.ContextExecutor, accessToken *models.AccessToken, currentPassword string, hash string) error {
+	log := logs.LogFromContext(ctx)
+
+	user, err := findCurrentUserForUpdate(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	if !user.Password.Valid {
+		log.Debug().Msg("User has no password set")
+		return apierrs.InvalidPassword
+	}
+
+	ok, err := hashing.Verify(currentPassword, user.Password.String)
+	if err != nil {
+		log.Warn().Err(err).Msg("Unsupported password hash, treating as invalid password")
+	}
+	if !ok {
+		log.Debug().Msg("Current password does not match")
+		return apierrs.InvalidPassword
+	}
+
+	user.Password = null.StringFrom(hash)
+	if _, err := user.Update(ctx, tx, boil.Whitelist(models.UserColumns.Password, models.UserColumns.UpdatedAt)); err != nil {
+		return err
+	}
+
+	// JWTs can't be revoked and stay valid until they expire
+	mods := []qm.QueryMod{models.AccessTokenWhere.UserID.EQ(user.ID)}
+	if accessToken != nil {
+		mods = append(mods, models.AccessTokenWhere.Token.NEQ(accessToken.Token))
+	}
+	if _, err := models.AccessTokens(mods...).DeleteAll(ctx, tx); err != nil {
+		return err
+	}
+
+	if _, err := models.RefreshTokens(models.RefreshTokenWhere.UserID.EQ(user.ID)).DeleteAll(ctx, tx); err != nil {
+		return err
+	}
+
+	if _, err := models.PasswordResetTokens(models.PasswordResetTokenWhere.UserID.EQ(user.ID)).DeleteAll(ctx, tx); err != nil {
+		return err
+	}
+
+	// pending 2FA challenges were issued for the old password
+	_, err = models.MfaTokens(models.MfaTokenWhere.UserID.EQ(user.ID)).DeleteAll(ctx, tx)
+	return err
+}
@@ -0,0 +1,94 @@
+package user_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/test"
+	"github.com/driif/echo-go-starter/pkg/auth/totp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPutPasswordSuccess(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		fix := test.Fixtures()
+		newPassword := test.PlainTestUserPassword + "123"
+
+		res := test.PerformRequest(t, s, "PUT", "/v1/users/me/password", test.GenericPayload{
+			"currentPassword": test.PlainTestUserPassword,
+			"newPassword":     newPassword,
+		}, test.HeadersWithAuth(t, fix.User1AccessToken1.Token))
+		require.Equal(t, http.StatusNoContent, res.Result().StatusCode)
+
+		res = test.PerformRequest(t, s, "POST", "/v1/auth/login", test.GenericPayload{
+			"username": fix.User1.Username.String,
+			"password": newPassword,
+		}, nil)
+		require.Equal(t, http.StatusOK, res.Result().StatusCode)
+
+		// the previous password no longer verifies
+		res = test.PerformRequest(t, s, "PUT", "/v1/users/me/password", test.GenericPayload{
+			"currentPassword": test.PlainTestUserPassword,
+			"newPassword":     newPassword + "456",
+		}, test.HeadersWithAuth(t, fix.User1AccessToken1.Token))
+		test.RequireHTTPError(t, res, apierrs.InvalidPassword)
+	})
+}
+
+func TestPutPasswordThrottled(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		fix := test.Fixtures()
+		headers := test.HeadersWithAuth(t, fix.User1AccessToken1.Token)
+		payload := test.GenericPayload{
+			"currentPassword": "not my password",
+			"newPassword":     test.PlainTestUserPassword + "123",
+		}
+
+		for i := 0; i < s.Config.Auth.Attempts.Username.FreeAttempts; i++ {
+			res := test.PerformRequest(t, s, "PUT", "/v1/users/me/password", payload, headers)
+			test.RequireHTTPError(t, res, apierrs.InvalidPassword)
+		}
+
+		// even the correct password is rejected until the delay has passed
+		payload["currentPassword"] = test.PlainTestUserPassword
+		res := test.PerformRequest(t, s, "PUT", "/v1/users/me/password", payload, headers)
+		test.RequireHTTPError(t, res, apierrs.TooManyAttempts)
+	})
+}
+
+func TestPutPasswordRevokesMFAChallenges(t *testing.T) {
+	test.E2e(t, func(s *server.Server) {
+		fix := test.Fixtures()
+
+		res := test.PerformRequest(t, s, "POST", "/v1/auth/login", test.GenericPayload{
+			"username": fix.UserMFA.Username.String,
+			"password": test.PlainTestUserPassword,
+		}, nil)
+		require.Equal(t, http.StatusOK, res.Result().StatusCode)
+
+		var challenge struct {
+			MFAToken string `json:"mfaToken"`
+		}
+		test.ParseResponseBody(t, res, &challenge)
+		require.NotEmpty(t, challenge.MFAToken)
+
+		res = test.PerformRequest(t, s, "PUT", "/v1/users/me/password", test.GenericPayload{
+			"currentPassword": test.PlainTestUserPassword,
+			"newPassword":     test.PlainTestUserPassword + "123",
+		}, test.HeadersWithAuth(t, fix.UserMFAAccessToken1.Token))
+		require.Equal(t, http.StatusNoContent, res.Result().StatusCode)
+
+		code, err := totp.Code(test.TestUserTOTPSecret, totp.Step(time.Now()))
+		require.NoError(t, err)
+
+		// the challenge was issued for the previous password
+		res = test.PerformRequest(t, s, "POST", "/v1/auth/login/mfa", test.GenericPayload{
+			"mfaToken": challenge.MFAToken,
+			"code":     code,
+		}, nil)
+		test.RequireHTTPError(t, res, apierrs.MFATokenInvalid)
+	})
+}
@@ -0,0 +1,154 @@
+package user
+
+import (
+	"net/http"
+
+	apierrs "github.com/driif/echo-go-starter/internal/api/errs"
+	"github.com/driif/echo-go-starter/internal/api/module"
+	"github.com/driif/echo-go-starter/internal/server/net/auth"
+	mdwr "github.com/driif/echo-go-starter/internal/server/net/middleware"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/labstack/echo/v4"
+)
+
+// credentialsMiddleware guards the routes managing the user's credentials (password, 2FA and API keys).
+var credentialsMiddleware = []module.MiddlewareFactory{module.Middleware(rejectAPIKeys)}
+
+// Register adds all routes of the current user to the registry.
+func Register(r *module.Registry) {
+	r.Add(
+		module.Route{
+			Method:      http.MethodGet,
+			Path:        "/me",
+			Group:       module.GroupV1User,
+			Auth:        mdwr.AuthModeRequired,
+			Handler:     getMeHandler,
+			Description: "Current user including profile and scopes",
+		},
+		module.Route{
+			Method:      http.MethodPatch,
+			Path:        "/me",
+			Group:       module.GroupV1User,
+			Auth:        mdwr.AuthModeRequired,
+			Handler:     patchMeHandler,
+			Description: "Update profile of the current user",
+		},
+		module.Route{
+			Method:      http.MethodPost,
+			Path:        "/me/legal",
+			Group:       module.GroupV1User,
+			Auth:        mdwr.AuthModeRequired,
+			Handler:     postLegalHandler,
+			Description: "Record legal acceptance of the current user",
+		},
+		module.Route{
+			Method:      http.MethodPut,
+			Path:        "/me/password",
+			Group:       module.GroupV1User,
+			Auth:        mdwr.AuthModeRequired,
+			Handler:     putPasswordHandler,
+			Middleware:  credentialsMiddleware,
+			Description: "Change password of the current user, revoking all other sessions",
+		},
+		module.Route{
+			Method:      http.MethodGet,
+			Path:        "/me/mfa",
+			Group:       module.GroupV1User,
+			Auth:        mdwr.AuthModeRequired,
+			Handler:     getMFAHandler,
+			Description: "2FA status of the current user",
+		},
+		module.Route{
+			Method:      http.MethodPost,
+			Path:        "/me/mfa/totp",
+			Group:       module.GroupV1User,
+			Auth:        mdwr.AuthModeRequired,
+			Handler:     postTOTPHandler,
+			Middleware:  credentialsMiddleware,
+			Description: "Start the TOTP enrollment, returning the secret and otpauth URI",
+		},
+		module.Route{
+			Method:      http.MethodPost,
+			Path:        "/me/mfa/totp/confirm",
+			Group:       module.GroupV1User,
+			Auth:        mdwr.AuthModeRequired,
+			Handler:     postTOTPConfirmHandler,
+			Middleware:  credentialsMiddleware,
+			Description: "Enable 2FA by confirming a TOTP code, returning recovery codes",
+		},
+		module.Route{
+			Method:      http.MethodDelete,
+			Path:        "/me/mfa/totp",
+			Group:       module.GroupV1User,
+			Auth:        mdwr.AuthModeRequired,
+			Handler:     deleteTOTPHandler,
+			Middleware:  credentialsMiddleware,
+			Description: "Disable 2FA",
+		},
+		module.Route{
+			Method:      http.MethodPost,
+			Path:        "/me/mfa/recovery-codes",
+			Group:       module.GroupV1User,
+			Auth:        mdwr.AuthModeRequired,
+			Handler:     postRecoveryCodesHandler,
+			Middleware:  credentialsMiddleware,
+			Description: "Replace all recovery codes",
+		},
+		module.Route{
+			Method:      http.MethodGet,
+			Path:        "/me/api-keys",
+			Group:       module.GroupV1User,
+			Auth:        mdwr.AuthModeRequired,
+			Handler:     getAPIKeysHandler,
+			Description: "API keys of the current user",
+		},
+		module.Route{
+			Method:      http.MethodPost,
+			Path:        "/me/api-keys",
+			Group:       module.GroupV1User,
+			Auth:        mdwr.AuthModeRequired,
+			Handler:     postAPIKeyHandler,
+			Middleware:  credentialsMiddleware,
+			Description: "Create an API key, returning its secret once",
+		},
+		module.Route{
+			Method:      http.MethodGet,
+			Path:        "/me/api-keys/:id",
+			Group:       module.GroupV1User,
+			Auth:        mdwr.AuthModeRequired,
+			Handler:     getAPIKeyHandler,
+			Description: "API key of the current user",
+		},
+		module.Route{
+			Method:      http.MethodPatch,
+			Path:        "/me/api-keys/:id",
+			Group:       module.GroupV1User,
+			Auth:        mdwr.AuthModeRequired,
+			Handler:     patchAPIKeyHandler,
+			Middleware:  credentialsMiddleware,
+			Description: "Rename an API key or replace its scopes",
+		},
+		module.Route{
+			Method:      http.MethodDelete,
+			Path:        "/me/api-keys/:id",
+			Group:       module.GroupV1User,
+			Auth:        mdwr.AuthModeRequired,
+			Handler:     deleteAPIKeyHandler,
+			Middleware:  credentialsMiddleware,
+			Description: "Revoke an API key",
+		},
+	)
+}
+
+// rejectAPIKeys requires the request to be authenticated by an access token instead of an API key, regardless of
+// the key's scopes, e.g. a leaked key must not be able to enroll 2FA and thereby lock out the owner.
+func rejectAPIKeys(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if auth.APIKeyFromContext(c.Request().Context()) != nil {
+			logs.LogFromEchoContext(c).Debug().Msg("Rejecting credential management using an API key")
+			return apierrs.APIKeyNotAllowed
+		}
+
+		return next(c)
+	}
+}
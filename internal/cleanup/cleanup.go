@@ -0,0 +1,260 @@
+package cleanup
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"expvar"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server/config"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// metrics are published via expvar, counting runs, failed runs and deleted rows per table ("deleted.<table>").
+var metrics = expvar.NewMap("cleanup")
+
+// Target deletes rows of a table matching a condition, e.g. expired tokens.
+type Target struct {
+	Table string
+	// Key is a unique column used to select the rows of a batch.
+	Key string
+	// Condition selects the rows to delete, its placeholders start at $2 ($1 is the batch size).
+	Condition string
+	Args      func(now time.Time) []interface{}
+}
+
+// Query returns the statement deleting a single batch. Rows locked by concurrent transactions (or other replicas
+// running the cleanup) are skipped instead of waited for.
+func (t Target) Query() string {
+	return fmt.Sprintf("DELETE FROM %s WHERE %s IN (SELECT %s FROM %s WHERE %s LIMIT $1 FOR UPDATE SKIP LOCKED)",
+		t.Table, t.Key, t.Key, t.Table, t.Condition)
+}
+
+// DefaultTargets returns the targets for expired tokens and stale auth state.
+// Email verification tokens are deliberately kept, as resending the verification relies on the pending token.
+func DefaultTargets(auth config.AuthServer) []Target {
+	expired := func(now time.Time) []interface{} {
+		return []interface{}{now}
+	}
+
+	// failures are forgotten once the longer of both reset periods passed since the last failure
+	attemptsResetAfter := auth.Attempts.Username.ResetAfter
+	if auth.Attempts.IP.ResetAfter > attemptsResetAfter {
+		attemptsResetAfter = auth.Attempts.IP.ResetAfter
+	}
+
+	return []Target{
+		{
+			Table:     models.TableNames.AccessTokens,
+			Key:       models.AccessTokenColumns.Token,
+			Condition: models.AccessTokenColumns.ValidUntil + " < $2",
+			Args:      expired,
+		},
+		{
+			Table:     models.TableNames.RefreshTokens,
+			Key:       models.RefreshTokenColumns.Token,
+			Condition: models.RefreshTokenColumns.CreatedAt + " < $2",
+			Args: func(now time.Time) []interface{} {
+				return []interface{}{now.Add(-auth.RefreshTokenValidity)}
+			},
+		},
+		{
+			Table:     models.TableNames.PasswordResetTokens,
+			Key:       models.PasswordResetTokenColumns.Token,
+			Condition: models.PasswordResetTokenColumns.ValidUntil + " < $2",
+			Args:      expired,
+		},
+		{
+			Table:     models.TableNames.MfaTokens,
+			Key:       models.MfaTokenColumns.Token,
+			Condition: models.MfaTokenColumns.ValidUntil + " < $2",
+			Args:      expired,
+		},
+		{
+			Table:     models.TableNames.OidcAuthRequests,
+			Key:       models.OidcAuthRequestColumns.State,
+			Condition: models.OidcAuthRequestColumns.ValidUntil + " < $2",
+			Args:      expired,
+		},
+		{
+			Table: models.TableNames.AuthAttempts,
+			Key:   models.AuthAttemptColumns.Key,
+			Condition: fmt.Sprintf("%s < $2 AND (%s IS NULL OR %s < $3)",
+				models.AuthAttemptColumns.LastFailureAt, models.AuthAttemptColumns.LockedUntil, models.AuthAttemptColumns.LockedUntil),
+			Args: func(now time.Time) []interface{} {
+				return []interface{}{now.Add(-attemptsResetAfter), now}
+			},
+		},
+	}
+}
+
+// Result of a cleanup run.
+type Result struct {
+	// Deleted rows per table.
+	Deleted  map[string]int64
+	Duration time.Duration
+}
+
+// Cleaner periodically deletes rows of its targets in batches.
+type Cleaner struct {
+	db      *sql.DB
+	config  config.Cleanup
+	targets []Target
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// defaultBatchSize is used if no positive batch size is configured.
+const defaultBatchSize = 1000
+
+// New returns a cleaner for the targets.
+func New(db *sql.DB, config config.Cleanup, targets []Target) *Cleaner {
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+
+	return &Cleaner{
+		db:      db,
+		config:  config,
+		targets: targets,
+	}
+}
+
+// Run deletes the matching rows of all targets once. A failing target doesn't prevent cleaning up the others,
+// all errors are returned joined.
+func (c *Cleaner) Run(ctx context.Context) (Result, error) {
+	start := time.Now()
+	res := Result{Deleted: make(map[string]int64, len(c.targets))}
+
+	var errs []error
+	for _, t := range c.targets {
+		deleted, err := c.runTarget(ctx, t, start)
+		res.Deleted[t.Table] = deleted
+		metrics.Add("deleted."+t.Table, deleted)
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to clean up %s: %w", t.Table, err))
+		}
+	}
+
+	res.Duration = time.Since(start)
+	metrics.Add("runs", 1)
+
+	err := errors.Join(errs...)
+	if err != nil {
+		metrics.Add("errors", 1)
+	}
+
+	return res, err
+}
+
+// runTarget deletes batches until fewer rows than the batch size were deleted.
+func (c *Cleaner) runTarget(ctx context.Context, t Target, now time.Time) (int64, error) {
+	query := t.Query()
+	args := append([]interface{}{c.config.BatchSize}, t.Args(now)...)
+
+	var deleted int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+
+		result, err := c.db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return deleted, err
+		}
+
+		n, err := result.RowsAffected()
+		if err != nil {
+			return deleted, err
+		}
+
+		deleted += n
+		if n < int64(c.config.BatchSize) {
+			return deleted, nil
+		}
+	}
+}
+
+// Start runs the cleanup periodically in the background until Stop is called.
+// The first run happens after a random delay of up to the configured jitter.
+func (c *Cleaner) Start() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		timer := time.NewTimer(Delay(0, c.config.Jitter, rand.Int63n))
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+
+			c.runAndLog(ctx)
+			timer.Reset(Delay(c.config.Interval, c.config.Jitter, rand.Int63n))
+		}
+	}()
+
+	log.Info().Dur("interval", c.config.Interval).Dur("jitter", c.config.Jitter).Msg("Started periodic cleanup")
+}
+
+// Stop cancels a running cleanup and waits for the background goroutine to exit.
+func (c *Cleaner) Stop() {
+	c.mu.Lock()
+	cancel := c.cancel
+	c.cancel = nil
+	c.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	c.wg.Wait()
+}
+
+func (c *Cleaner) runAndLog(ctx context.Context) {
+	res, err := c.Run(ctx)
+
+	event := log.Info()
+	if err != nil && !errors.Is(err, context.Canceled) {
+		event = log.Error().Err(err)
+	}
+
+	deleted := zerolog.Dict()
+	for table, n := range res.Deleted {
+		deleted.Int64(table, n)
+	}
+
+	event.Dur("duration", res.Duration).Dict("deleted", deleted).Msg("Cleaned up expired rows")
+}
+
+// Delay returns the interval extended by a random duration in [0, jitter) drawn via randInt63n.
+func Delay(interval time.Duration, jitter time.Duration, randInt63n func(n int64) int64) time.Duration {
+	if jitter <= 0 {
+		return interval
+	}
+
+	return interval + time.Duration(randInt63n(int64(jitter)))
+}
@@ -0,0 +1,58 @@
+package cleanup_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/cleanup"
+	"github.com/driif/echo-go-starter/internal/server/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelay(t *testing.T) {
+	assert.Equal(t, time.Hour, cleanup.Delay(time.Hour, 0, nil))
+
+	assert.Equal(t, time.Hour, cleanup.Delay(time.Hour, time.Minute, func(n int64) int64 { return 0 }))
+	assert.Equal(t, time.Hour+time.Minute-1, cleanup.Delay(time.Hour, time.Minute, func(n int64) int64 { return n - 1 }))
+
+	var drawn int64
+	cleanup.Delay(time.Hour, 5*time.Minute, func(n int64) int64 { drawn = n; return 0 })
+	assert.Equal(t, int64(5*time.Minute), drawn)
+}
+
+func TestTargetQuery(t *testing.T) {
+	target := cleanup.Target{
+		Table:     "access_tokens",
+		Key:       "token",
+		Condition: "valid_until < $2",
+	}
+
+	assert.Equal(t,
+		"DELETE FROM access_tokens WHERE token IN (SELECT token FROM access_tokens WHERE valid_until < $2 LIMIT $1 FOR UPDATE SKIP LOCKED)",
+		target.Query())
+}
+
+func TestDefaultTargets(t *testing.T) {
+	auth := config.DefaultServiceConfigFromEnv().Auth
+	auth.RefreshTokenValidity = 24 * time.Hour
+	auth.Attempts.Username.ResetAfter = time.Hour
+	auth.Attempts.IP.ResetAfter = 2 * time.Hour
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	targets := make(map[string]cleanup.Target)
+	for _, target := range cleanup.DefaultTargets(auth) {
+		targets[target.Table] = target
+	}
+
+	require.Contains(t, targets, "access_tokens")
+	require.Contains(t, targets, "refresh_tokens")
+	require.Contains(t, targets, "password_reset_tokens")
+	require.Contains(t, targets, "auth_attempts")
+	assert.NotContains(t, targets, "email_verification_tokens", "resending the verification relies on pending tokens")
+
+	assert.Equal(t, []interface{}{now}, targets["access_tokens"].Args(now))
+	assert.Equal(t, []interface{}{now.Add(-24 * time.Hour)}, targets["refresh_tokens"].Args(now))
+	assert.Equal(t, []interface{}{now.Add(-2 * time.Hour), now}, targets["auth_attempts"].Args(now))
+}
@@ -0,0 +1,173 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// States of jobs, succeeded jobs are deleted.
+const (
+	StatePending = "pending"
+	StateRunning = "running"
+	// StateDead is reached once all attempts failed or the handler returned a permanent error.
+	StateDead = "dead"
+)
+
+// DefaultMaxAttempts is used unless overridden via MaxAttempts.
+const DefaultMaxAttempts = 10
+
+var (
+	// ErrDuplicate is returned by Enqueue if a pending or running job with the same name and unique key exists.
+	// The executor's transaction stays usable.
+	ErrDuplicate   = errors.New("duplicate job")
+	ErrJobNotFound = errors.New("job not found")
+)
+
+// insertQuery enqueues a job, skipping it if a pending or running job with the same unique key exists.
+const insertQuery = `INSERT INTO jobs (name, payload, state, unique_key, max_attempts, run_at, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+ON CONFLICT (name, unique_key) WHERE unique_key IS NOT NULL AND state IN ('pending', 'running') DO NOTHING
+RETURNING *`
+
+type options struct {
+	runAt       time.Time
+	uniqueKey   null.String
+	maxAttempts int
+}
+
+// Option customizes an enqueued job.
+type Option func(o *options)
+
+// RunAt schedules the job not to run before the given time.
+func RunAt(t time.Time) Option {
+	return func(o *options) {
+		o.runAt = t
+	}
+}
+
+// Delay schedules the job to run after the given duration.
+func Delay(d time.Duration) Option {
+	return func(o *options) {
+		o.runAt = time.Now().Add(d)
+	}
+}
+
+// UniqueKey deduplicates the job against pending or running jobs of the same name with the same key.
+func UniqueKey(key string) Option {
+	return func(o *options) {
+		o.uniqueKey = null.StringFrom(key)
+	}
+}
+
+// MaxAttempts limits the attempts before the job is dead.
+func MaxAttempts(n int) Option {
+	return func(o *options) {
+		o.maxAttempts = n
+	}
+}
+
+// Enqueue adds a job for the handler registered with the name, the payload is encoded as JSON. Passing a transaction
+// as executor only enqueues the job if the transaction commits.
+func Enqueue(ctx context.Context, exec boil.ContextExecutor, name string, payload interface{}, opts ...Option) (*models.Job, error) {
+	o := options{maxAttempts: DefaultMaxAttempts}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.maxAttempts < 1 {
+		return nil, fmt.Errorf("max attempts of job %q must be positive", name)
+	}
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload of job %q: %w", name, err)
+	}
+
+	now := time.Now()
+	if o.runAt.IsZero() {
+		o.runAt = now
+	}
+
+	var job models.Job
+	err = queries.Raw(insertQuery, name, b, StatePending, o.uniqueKey, o.maxAttempts, o.runAt, now).Bind(ctx, exec, &job)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDuplicate
+		}
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// Retry schedules a dead job to run again immediately, granting it another full set of attempts.
+func Retry(ctx context.Context, exec boil.ContextExecutor, id string) error {
+	n, err := models.Jobs(
+		models.JobWhere.ID.EQ(id),
+		models.JobWhere.State.EQ(StateDead),
+	).UpdateAll(ctx, exec, models.M{
+		models.JobColumns.State:      StatePending,
+		models.JobColumns.Attempts:   0,
+		models.JobColumns.RunAt:      time.Now(),
+		models.JobColumns.FinishedAt: null.Time{},
+		models.JobColumns.UpdatedAt:  time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrJobNotFound
+	}
+
+	return nil
+}
+
+// HandlerFunc processes a job. Returning an error schedules a retry with exponential backoff,
+// unless the error is permanent or the job has no attempts left.
+// Jobs are delivered at least once, e.g. after a crash, thus handlers must be idempotent.
+type HandlerFunc func(ctx context.Context, job *models.Job) error
+
+// Handle returns a HandlerFunc decoding the JSON payload of the job into T.
+// Payloads failing to decode are considered permanent errors.
+func Handle[T any](fn func(ctx context.Context, payload T) error) HandlerFunc {
+	return func(ctx context.Context, job *models.Job) error {
+		var payload T
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return Permanent(fmt.Errorf("failed to decode payload: %w", err))
+		}
+
+		return fn(ctx, payload)
+	}
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks the error as permanent, the job is moved to the dead state without being retried.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether the error was marked as permanent.
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
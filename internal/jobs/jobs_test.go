@@ -0,0 +1,95 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/jobs"
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoff(t *testing.T) {
+	base := 10 * time.Second
+	max := time.Hour
+
+	assert.Equal(t, base, jobs.Backoff(0, base, max))
+	assert.Equal(t, base, jobs.Backoff(1, base, max))
+	assert.Equal(t, 20*time.Second, jobs.Backoff(2, base, max))
+	assert.Equal(t, 80*time.Second, jobs.Backoff(4, base, max))
+	assert.Equal(t, max, jobs.Backoff(10, base, max))
+	assert.Equal(t, max, jobs.Backoff(math.MaxInt32, base, max))
+	assert.Equal(t, time.Minute, jobs.Backoff(1, time.Hour, time.Minute))
+}
+
+func TestPermanent(t *testing.T) {
+	cause := errors.New("invalid recipient")
+	err := jobs.Permanent(cause)
+
+	assert.True(t, jobs.IsPermanent(err))
+	assert.True(t, jobs.IsPermanent(errors.Join(errors.New("wrapped"), err)))
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, cause.Error(), err.Error())
+	assert.False(t, jobs.IsPermanent(cause))
+	assert.False(t, jobs.IsPermanent(nil))
+}
+
+type testPayload struct {
+	UserID string `json:"userId"`
+}
+
+func TestHandle(t *testing.T) {
+	var received testPayload
+	handler := jobs.Handle(func(_ context.Context, payload testPayload) error {
+		received = payload
+		return nil
+	})
+
+	err := handler(context.Background(), &models.Job{Payload: []byte(`{"userId":"f6ede5d8-e22a-4ca5-aa12-67821865a3e5"}`)})
+	require.NoError(t, err)
+	assert.Equal(t, "f6ede5d8-e22a-4ca5-aa12-67821865a3e5", received.UserID)
+
+	err = handler(context.Background(), &models.Job{Payload: []byte(`"not an object"`)})
+	require.Error(t, err)
+	assert.True(t, jobs.IsPermanent(err))
+}
+
+func TestHandleError(t *testing.T) {
+	cause := errors.New("smtp unavailable")
+	handler := jobs.Handle(func(_ context.Context, _ testPayload) error {
+		return cause
+	})
+
+	err := handler(context.Background(), &models.Job{Payload: []byte(`{}`)})
+	assert.ErrorIs(t, err, cause)
+	assert.False(t, jobs.IsPermanent(err))
+}
+
+func TestWorkerRegister(t *testing.T) {
+	w := jobs.NewWorker(nil, config.Jobs{})
+	noop := func(context.Context, *models.Job) error { return nil }
+
+	w.Register("send_email", noop)
+	w.Register("cleanup", noop)
+	assert.Equal(t, []string{"cleanup", "send_email"}, w.Names())
+
+	assert.Panics(t, func() { w.Register("send_email", noop) })
+}
+
+func TestWorkerShutdownWithoutStart(t *testing.T) {
+	w := jobs.NewWorker(nil, config.Jobs{})
+	assert.NoError(t, w.Shutdown(context.Background()))
+}
+
+func TestWorkerStartWithoutHandlers(t *testing.T) {
+	w := jobs.NewWorker(nil, config.Jobs{Concurrency: 2})
+	w.Start()
+
+	assert.NoError(t, w.Shutdown(context.Background()))
+	assert.Panics(t, func() { w.Register("send_email", func(context.Context, *models.Job) error { return nil }) })
+}
@@ -0,0 +1,305 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"expvar"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server/config"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// metrics are published via expvar, counting succeeded, retried and dead jobs.
+var metrics = expvar.NewMap("jobs")
+
+// bookkeepingTimeout limits updating a job's state after it was processed, which also happens during shutdown.
+const bookkeepingTimeout = 10 * time.Second
+
+// fetchQuery claims the next due job of the given names, including running jobs whose lease expired.
+// Concurrent workers skip jobs locked by each other instead of waiting.
+const fetchQuery = `UPDATE jobs SET state = 'running', attempts = attempts + 1, locked_until = $1, updated_at = $2
+WHERE id = (
+	SELECT id FROM jobs
+	WHERE name = ANY($3) AND ((state = 'pending' AND run_at <= $2) OR (state = 'running' AND locked_until < $2))
+	ORDER BY run_at
+	LIMIT 1
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING *`
+
+// Worker processes jobs using the registered handlers.
+type Worker struct {
+	db       *sql.DB
+	config   config.Jobs
+	handlers map[string]HandlerFunc
+
+	mu       sync.Mutex
+	started  bool
+	stop     chan struct{}
+	abort    context.CancelFunc
+	abortCtx context.Context
+	wg       sync.WaitGroup
+}
+
+// NewWorker returns a worker, handlers must be registered before starting it.
+func NewWorker(db *sql.DB, config config.Jobs) *Worker {
+	if config.Concurrency < 1 {
+		config.Concurrency = 1
+	}
+
+	return &Worker{
+		db:       db,
+		config:   config,
+		handlers: make(map[string]HandlerFunc),
+	}
+}
+
+// Register adds the handler for jobs with the name, registering a name twice panics.
+func (w *Worker) Register(name string, handler HandlerFunc) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.started {
+		panic(fmt.Sprintf("jobs: handler %q registered after starting the worker", name))
+	}
+	if _, ok := w.handlers[name]; ok {
+		panic(fmt.Sprintf("jobs: handler %q registered multiple times", name))
+	}
+
+	w.handlers[name] = handler
+}
+
+// Names returns the sorted names of the registered handlers.
+func (w *Worker) Names() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	names := make([]string, 0, len(w.handlers))
+	for name := range w.handlers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Start processes jobs in the background until Shutdown is called. Only jobs of registered handlers are claimed.
+func (w *Worker) Start() {
+	names := w.Names()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.started {
+		return
+	}
+
+	w.started = true
+	w.stop = make(chan struct{})
+	w.abortCtx, w.abort = context.WithCancel(context.Background())
+
+	if len(names) == 0 {
+		log.Debug().Msg("No job handlers registered, not starting job worker")
+		return
+	}
+
+	for i := 0; i < w.config.Concurrency; i++ {
+		w.wg.Add(1)
+		go w.loop(names)
+	}
+
+	log.Info().Int("concurrency", w.config.Concurrency).Strs("handlers", names).Msg("Started job worker")
+}
+
+// Shutdown stops claiming new jobs and waits for running jobs to finish. Once the context is done, running jobs are
+// canceled (and thus retried later) and the context's error is returned.
+func (w *Worker) Shutdown(ctx context.Context) error {
+	w.mu.Lock()
+	if !w.started {
+		w.mu.Unlock()
+		return nil
+	}
+	select {
+	case <-w.stop:
+		// already shutting down
+	default:
+		close(w.stop)
+	}
+	w.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		w.abort()
+		return nil
+	case <-ctx.Done():
+		log.Warn().Msg("Canceling running jobs")
+		w.abort()
+		<-done
+		return ctx.Err()
+	}
+}
+
+func (w *Worker) loop(names []string) {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		default:
+		}
+
+		job, err := w.fetch(names)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to fetch job")
+		}
+
+		if job == nil {
+			select {
+			case <-w.stop:
+				return
+			case <-time.After(w.config.PollInterval):
+			}
+			continue
+		}
+
+		w.process(job)
+	}
+}
+
+// fetch claims the next due job, nil if there is none.
+func (w *Worker) fetch(names []string) (*models.Job, error) {
+	now := time.Now()
+
+	var job models.Job
+	err := queries.Raw(fetchQuery, now.Add(w.config.Lease), now, pq.Array(names)).Bind(w.abortCtx, w.db, &job)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, context.Canceled) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// process runs the job's handler and records the outcome: succeeded jobs are deleted, failed jobs are retried
+// with backoff or moved to the dead state.
+func (w *Worker) process(job *models.Job) {
+	l := log.With().Str("jobID", job.ID).Str("job", job.Name).Int("attempt", job.Attempts).Logger()
+
+	var err error
+	if job.Attempts > job.MaxAttempts {
+		// the job's lease expired on its last attempt, e.g. as the process crashed while running it
+		err = Permanent(errors.New("lease expired on the last attempt"))
+	} else {
+		err = w.run(job)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), bookkeepingTimeout)
+	defer cancel()
+
+	if err == nil {
+		l.Debug().Msg("Job succeeded")
+		metrics.Add("succeeded", 1)
+
+		// the attempts guard against deleting a job claimed again by another worker after the lease expired
+		if _, err := models.Jobs(
+			models.JobWhere.ID.EQ(job.ID),
+			models.JobWhere.Attempts.EQ(job.Attempts),
+		).DeleteAll(ctx, w.db); err != nil {
+			l.Error().Err(err).Msg("Failed to delete succeeded job")
+		}
+		return
+	}
+
+	now := time.Now()
+	update := models.M{
+		models.JobColumns.LastError:   err.Error(),
+		models.JobColumns.LockedUntil: null.Time{},
+		models.JobColumns.UpdatedAt:   now,
+	}
+
+	if IsPermanent(err) || job.Attempts >= job.MaxAttempts {
+		l.Error().Err(err).Msg("Job failed permanently")
+		metrics.Add("dead", 1)
+
+		update[models.JobColumns.State] = StateDead
+		update[models.JobColumns.FinishedAt] = now
+	} else {
+		delay := Backoff(job.Attempts, w.config.BackoffBase, w.config.BackoffMax)
+		// spreads retries of jobs failed simultaneously, e.g. due to an unavailable dependency
+		delay += time.Duration(rand.Int63n(int64(delay)/10 + 1))
+
+		l.Warn().Err(err).Dur("retryIn", delay).Msg("Job failed, retrying")
+		metrics.Add("retried", 1)
+
+		update[models.JobColumns.State] = StatePending
+		update[models.JobColumns.RunAt] = now.Add(delay)
+	}
+
+	if _, err := models.Jobs(
+		models.JobWhere.ID.EQ(job.ID),
+		models.JobWhere.Attempts.EQ(job.Attempts),
+	).UpdateAll(ctx, w.db, update); err != nil {
+		l.Error().Err(err).Msg("Failed to update failed job")
+	}
+}
+
+// run calls the job's handler, limited by the lease and recovering panics.
+func (w *Worker) run(job *models.Job) (err error) {
+	ctx, cancel := context.WithTimeout(w.abortCtx, w.config.Lease)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	w.mu.Lock()
+	handler := w.handlers[job.Name]
+	w.mu.Unlock()
+
+	// transactions started by the handler within another one are nested
+	return handler(db.WithTxScope(ctx), job)
+}
+
+// Backoff returns the delay before the next attempt after the given (1-based) failed attempt,
+// doubling base for every attempt up to max.
+func Backoff(attempt int, base time.Duration, max time.Duration) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := base
+	for i := 1; i < attempt; i++ {
+		if delay >= max/2 {
+			return max
+		}
+		delay *= 2
+	}
+
+	if delay > max {
+		return max
+	}
+
+	return delay
+}
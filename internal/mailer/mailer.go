@@ -0,0 +1,126 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/driif/echo-go-starter/internal/mailer/transport"
+	"github.com/driif/echo-go-starter/internal/server/config"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/jordan-wright/email"
+)
+
+const (
+	TemplatePasswordReset     = "password_reset"
+	TemplateEmailVerification = "email_verification"
+)
+
+var (
+	ErrTemplateNotFound = errors.New("email template not found")
+)
+
+// Mailer renders our email templates and sends them via the configured transport.
+type Mailer struct {
+	Config    config.Mailer
+	Transport transport.MailTransporter
+	Templates map[string]*template.Template
+}
+
+// New creates a new mailer, call ParseTemplates before sending any mails.
+func New(config config.Mailer, transport transport.MailTransporter) *Mailer {
+	return &Mailer{
+		Config:    config,
+		Transport: transport,
+		Templates: map[string]*template.Template{},
+	}
+}
+
+// ParseTemplates parses all `*.tmpl` files in the subdirectories of WebTemplatesEmailBaseDirAbs,
+// each subdirectory becomes a template named after the directory (e.g. `password_reset`).
+func (m *Mailer) ParseTemplates() error {
+	entries, err := os.ReadDir(m.Config.WebTemplatesEmailBaseDirAbs)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+
+		t, err := template.ParseGlob(filepath.Join(m.Config.WebTemplatesEmailBaseDirAbs, e.Name(), "*.tmpl"))
+		if err != nil {
+			return fmt.Errorf("failed to parse email template %s: %w", e.Name(), err)
+		}
+
+		m.Templates[e.Name()] = t
+	}
+
+	return nil
+}
+
+// SendPasswordReset sends a mail containing the link to reset the user's password.
+func (m *Mailer) SendPasswordReset(ctx context.Context, to string, passwordResetLink string) error {
+	return m.send(ctx, to, "Password reset", TemplatePasswordReset, map[string]interface{}{
+		"passwordResetLink": passwordResetLink,
+	})
+}
+
+// SendEmailVerification sends a mail containing the link to verify the user's email address.
+func (m *Mailer) SendEmailVerification(ctx context.Context, to string, emailVerificationLink string) error {
+	return m.send(ctx, to, "Verify your email address", TemplateEmailVerification, map[string]interface{}{
+		"emailVerificationLink": emailVerificationLink,
+	})
+}
+
+func (m *Mailer) send(ctx context.Context, to string, subject string, name string, data map[string]interface{}) error {
+	log := logs.LogFromContext(ctx).With().Str("template", name).Logger()
+
+	t, ok := m.Templates[name]
+	if !ok {
+		log.Error().Msg("Email template not found")
+		return ErrTemplateNotFound
+	}
+
+	// each template directory holds a single `<name>.html.tmpl` file
+	var html bytes.Buffer
+	if err := t.ExecuteTemplate(&html, name+".html.tmpl", data); err != nil {
+		log.Error().Err(err).Msg("Failed to execute email template")
+		return err
+	}
+
+	mail := email.NewEmail()
+	mail.From = m.Config.DefaultSender
+	mail.To = []string{to}
+	mail.Subject = subject
+	mail.HTML = html.Bytes()
+
+	if !m.Config.Send {
+		log.Warn().Str("to", maskEmail(to)).Msg("Sending mails is disabled, not sending mail")
+		return nil
+	}
+
+	if err := m.Transport.Send(mail); err != nil {
+		log.Error().Err(err).Msg("Failed to send mail")
+		return err
+	}
+
+	log.Debug().Msg("Successfully sent mail")
+
+	return nil
+}
+
+// maskEmail keeps the domain of the address only, we don't want to log personal data.
+func maskEmail(address string) string {
+	if i := strings.LastIndex(address, "@"); i >= 0 {
+		return "***" + address[i:]
+	}
+
+	return "***"
+}
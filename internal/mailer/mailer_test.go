@@ -0,0 +1,63 @@
+package mailer_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/driif/echo-go-starter/internal/mailer"
+	"github.com/driif/echo-go-starter/internal/mailer/transport"
+	"github.com/driif/echo-go-starter/internal/server/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMailer(t *testing.T) (*mailer.Mailer, *transport.MockMailTransport) {
+	t.Helper()
+
+	conf := config.DefaultServiceConfigFromEnv().Mailer
+	conf.WebTemplatesEmailBaseDirAbs = filepath.Join("..", "..", "web", "templates", "email")
+
+	mock := transport.NewMock()
+	m := mailer.New(conf, mock)
+	require.NoError(t, m.ParseTemplates())
+
+	return m, mock
+}
+
+func TestSendEmailVerification(t *testing.T) {
+	m, mock := newMailer(t)
+
+	err := m.SendEmailVerification(context.Background(), "user1@example.com", "http://localhost:3000/verify-email?token=abc")
+	require.NoError(t, err)
+
+	mail := mock.GetLastSentMail()
+	require.NotNil(t, mail)
+	assert.Equal(t, []string{"user1@example.com"}, mail.To)
+	assert.Equal(t, m.Config.DefaultSender, mail.From)
+	assert.Contains(t, string(mail.HTML), "http://localhost:3000/verify-email?token=abc")
+}
+
+func TestSendPasswordReset(t *testing.T) {
+	m, mock := newMailer(t)
+
+	err := m.SendPasswordReset(context.Background(), "user1@example.com", "http://localhost:3000/set-new-password?token=abc")
+	require.NoError(t, err)
+	require.Len(t, mock.GetSentMails(), 1)
+	assert.Contains(t, string(mock.GetLastSentMail().HTML), "http://localhost:3000/set-new-password?token=abc")
+}
+
+func TestSendDisabled(t *testing.T) {
+	m, mock := newMailer(t)
+	m.Config.Send = false
+
+	require.NoError(t, m.SendPasswordReset(context.Background(), "user1@example.com", "http://localhost"))
+	assert.Nil(t, mock.GetLastSentMail())
+}
+
+func TestSendTemplateNotFound(t *testing.T) {
+	m := mailer.New(config.DefaultServiceConfigFromEnv().Mailer, transport.NewMock())
+
+	err := m.SendPasswordReset(context.Background(), "user1@example.com", "http://localhost")
+	assert.ErrorIs(t, err, mailer.ErrTemplateNotFound)
+}
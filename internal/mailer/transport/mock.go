@@ -0,0 +1,53 @@
+package transport
+
+import (
+	"sync"
+
+	"github.com/jordan-wright/email"
+)
+
+// MockMailTransport stores all mails sent in memory instead of sending them, used e.g. during tests.
+type MockMailTransport struct {
+	sync.RWMutex
+	mails []*email.Email
+}
+
+// NewMock creates a new mock mail transport.
+func NewMock() *MockMailTransport {
+	return &MockMailTransport{
+		mails: make([]*email.Email, 0),
+	}
+}
+
+// Send stores the mail.
+func (m *MockMailTransport) Send(mail *email.Email) error {
+	m.Lock()
+	defer m.Unlock()
+
+	m.mails = append(m.mails, mail)
+
+	return nil
+}
+
+// GetLastSentMail returns the mail sent last or nil if no mail was sent yet.
+func (m *MockMailTransport) GetLastSentMail() *email.Email {
+	m.RLock()
+	defer m.RUnlock()
+
+	if len(m.mails) == 0 {
+		return nil
+	}
+
+	return m.mails[len(m.mails)-1]
+}
+
+// GetSentMails returns all mails sent in order.
+func (m *MockMailTransport) GetSentMails() []*email.Email {
+	m.RLock()
+	defer m.RUnlock()
+
+	mails := make([]*email.Email, len(m.mails))
+	copy(mails, m.mails)
+
+	return mails
+}
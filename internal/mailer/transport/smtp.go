@@ -0,0 +1,89 @@
+package transport
+
+import (
+	"crypto/tls"
+	"net"
+	"net/smtp"
+	"strconv"
+
+	"github.com/jordan-wright/email"
+)
+
+type SMTPAuthType string
+
+const (
+	SMTPAuthTypeNone    SMTPAuthType = "none"
+	SMTPAuthTypePlain   SMTPAuthType = "plain"
+	SMTPAuthTypeCRAMMD5 SMTPAuthType = "crammd5"
+)
+
+func (t SMTPAuthType) String() string {
+	return string(t)
+}
+
+type SMTPEncryption string
+
+const (
+	SMTPEncryptionNone     SMTPEncryption = "none"
+	SMTPEncryptionTLS      SMTPEncryption = "tls"
+	SMTPEncryptionStartTLS SMTPEncryption = "starttls"
+)
+
+func (e SMTPEncryption) String() string {
+	return string(e)
+}
+
+// SMTPMailTransportConfig configures the SMTP server used to send mails.
+type SMTPMailTransportConfig struct {
+	Host       string
+	Port       int
+	AuthType   SMTPAuthType
+	Username   string
+	Password   string `json:"-"` // sensitive
+	Encryption SMTPEncryption
+	TLSConfig  *tls.Config `json:"-"`
+}
+
+// SMTPMailTransport sends mails via SMTP.
+type SMTPMailTransport struct {
+	config SMTPMailTransportConfig
+	addr   string
+	auth   smtp.Auth
+}
+
+// NewSMTP creates a new SMTP mail transport using the given config.
+func NewSMTP(config SMTPMailTransportConfig) *SMTPMailTransport {
+	t := &SMTPMailTransport{
+		config: config,
+		addr:   net.JoinHostPort(config.Host, strconv.Itoa(config.Port)),
+	}
+
+	switch config.AuthType {
+	case SMTPAuthTypePlain:
+		t.auth = smtp.PlainAuth("", config.Username, config.Password, config.Host)
+	case SMTPAuthTypeCRAMMD5:
+		t.auth = smtp.CRAMMD5Auth(config.Username, config.Password)
+	}
+
+	return t
+}
+
+// Send sends the mail using the configured encryption.
+func (t *SMTPMailTransport) Send(mail *email.Email) error {
+	switch t.config.Encryption {
+	case SMTPEncryptionTLS:
+		return mail.SendWithTLS(t.addr, t.auth, t.tlsConfig())
+	case SMTPEncryptionStartTLS:
+		return mail.SendWithStartTLS(t.addr, t.auth, t.tlsConfig())
+	default:
+		return mail.Send(t.addr, t.auth)
+	}
+}
+
+func (t *SMTPMailTransport) tlsConfig() *tls.Config {
+	if t.config.TLSConfig != nil {
+		return t.config.TLSConfig
+	}
+
+	return &tls.Config{ServerName: t.config.Host, MinVersion: tls.VersionTLS12}
+}
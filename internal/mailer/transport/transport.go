@@ -0,0 +1,8 @@
+package transport
+
+import "github.com/jordan-wright/email"
+
+// MailTransporter sends fully composed mails, e.g. via SMTP.
+type MailTransporter interface {
+	Send(mail *email.Email) error
+}
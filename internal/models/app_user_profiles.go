@@ -24,10 +24,12 @@ import (
 
 // AppUserProfile is an object representing the database table.
 type AppUserProfile struct {
-	UserID          string    `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
-	LegalAcceptedAt null.Time `boil:"legal_accepted_at" json:"legal_accepted_at,omitempty" toml:"legal_accepted_at" yaml:"legal_accepted_at,omitempty"`
-	CreatedAt       time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt       time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	UserID          string      `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	LegalAcceptedAt null.Time   `boil:"legal_accepted_at" json:"legal_accepted_at,omitempty" toml:"legal_accepted_at" yaml:"legal_accepted_at,omitempty"`
+	CreatedAt       time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt       time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	DisplayName     null.String `boil:"display_name" json:"display_name,omitempty" toml:"display_name" yaml:"display_name,omitempty"`
+	Locale          null.String `boil:"locale" json:"locale,omitempty" toml:"locale" yaml:"locale,omitempty"`
 
 	R *appUserProfileR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L appUserProfileL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -38,11 +40,15 @@ var AppUserProfileColumns = struct {
 	LegalAcceptedAt string
 	CreatedAt       string
 	UpdatedAt       string
+	DisplayName     string
+	Locale          string
 }{
 	UserID:          "user_id",
 	LegalAcceptedAt: "legal_accepted_at",
 	CreatedAt:       "created_at",
 	UpdatedAt:       "updated_at",
+	DisplayName:     "display_name",
+	Locale:          "locale",
 }
 
 var AppUserProfileTableColumns = struct {
@@ -50,49 +56,71 @@ var AppUserProfileTableColumns = struct {
 	LegalAcceptedAt string
 	CreatedAt       string
 	UpdatedAt       string
+	DisplayName     string
+	Locale          string
 }{
 	UserID:          "app_user_profiles.user_id",
 	LegalAcceptedAt: "app_user_profiles.legal_accepted_at",
 	CreatedAt:       "app_user_profiles.created_at",
 	UpdatedAt:       "app_user_profiles.updated_at",
+	DisplayName:     "app_user_profiles.display_name",
+	Locale:          "app_user_profiles.locale",
 }
 
 // Generated where
 
-type whereHelpernull_Time struct{ field string }
+type whereHelpernull_String struct{ field string }
 
-func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
 	return qmhelper.WhereNullEQ(w.field, false, x)
 }
-func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
 	return qmhelper.WhereNullEQ(w.field, true, x)
 }
-func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
+func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LT, x)
 }
-func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
+func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LTE, x)
 }
-func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
+func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GT, x)
 }
-func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
+func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
+func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
 
-func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
 var AppUserProfileWhere = struct {
 	UserID          whereHelperstring
 	LegalAcceptedAt whereHelpernull_Time
 	CreatedAt       whereHelpertime_Time
 	UpdatedAt       whereHelpertime_Time
+	DisplayName     whereHelpernull_String
+	Locale          whereHelpernull_String
 }{
 	UserID:          whereHelperstring{field: "\"app_user_profiles\".\"user_id\""},
 	LegalAcceptedAt: whereHelpernull_Time{field: "\"app_user_profiles\".\"legal_accepted_at\""},
 	CreatedAt:       whereHelpertime_Time{field: "\"app_user_profiles\".\"created_at\""},
 	UpdatedAt:       whereHelpertime_Time{field: "\"app_user_profiles\".\"updated_at\""},
+	DisplayName:     whereHelpernull_String{field: "\"app_user_profiles\".\"display_name\""},
+	Locale:          whereHelpernull_String{field: "\"app_user_profiles\".\"locale\""},
 }
 
 // AppUserProfileRels is where relationship names are stored.
@@ -123,9 +151,9 @@ func (r *appUserProfileR) GetUser() *User {
 type appUserProfileL struct{}
 
 var (
-	appUserProfileAllColumns            = []string{"user_id", "legal_accepted_at", "created_at", "updated_at"}
+	appUserProfileAllColumns            = []string{"user_id", "legal_accepted_at", "created_at", "updated_at", "display_name", "locale"}
 	appUserProfileColumnsWithoutDefault = []string{"user_id", "created_at", "updated_at"}
-	appUserProfileColumnsWithDefault    = []string{"legal_accepted_at"}
+	appUserProfileColumnsWithDefault    = []string{"legal_accepted_at", "display_name", "locale"}
 	appUserProfilePrimaryKeyColumns     = []string{"user_id"}
 	appUserProfileGeneratedColumns      = []string{}
 )
@@ -0,0 +1,745 @@
+// Code generated by SQLBoiler 4.14.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// AuthAttempt is an object representing the database table.
+type AuthAttempt struct {
+	Key           string    `boil:"key" json:"key" toml:"key" yaml:"key"`
+	Failures      int       `boil:"failures" json:"failures" toml:"failures" yaml:"failures"`
+	LastFailureAt time.Time `boil:"last_failure_at" json:"last_failure_at" toml:"last_failure_at" yaml:"last_failure_at"`
+	LockedUntil   null.Time `boil:"locked_until" json:"locked_until,omitempty" toml:"locked_until" yaml:"locked_until,omitempty"`
+	CreatedAt     time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt     time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+
+	R *authAttemptR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L authAttemptL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var AuthAttemptColumns = struct {
+	Key           string
+	Failures      string
+	LastFailureAt string
+	LockedUntil   string
+	CreatedAt     string
+	UpdatedAt     string
+}{
+	Key:           "key",
+	Failures:      "failures",
+	LastFailureAt: "last_failure_at",
+	LockedUntil:   "locked_until",
+	CreatedAt:     "created_at",
+	UpdatedAt:     "updated_at",
+}
+
+var AuthAttemptTableColumns = struct {
+	Key           string
+	Failures      string
+	LastFailureAt string
+	LockedUntil   string
+	CreatedAt     string
+	UpdatedAt     string
+}{
+	Key:           "auth_attempts.key",
+	Failures:      "auth_attempts.failures",
+	LastFailureAt: "auth_attempts.last_failure_at",
+	LockedUntil:   "auth_attempts.locked_until",
+	CreatedAt:     "auth_attempts.created_at",
+	UpdatedAt:     "auth_attempts.updated_at",
+}
+
+// Generated where
+
+type whereHelperint struct{ field string }
+
+func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint) IN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperint) NIN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+var AuthAttemptWhere = struct {
+	Key           whereHelperstring
+	Failures      whereHelperint
+	LastFailureAt whereHelpertime_Time
+	LockedUntil   whereHelpernull_Time
+	CreatedAt     whereHelpertime_Time
+	UpdatedAt     whereHelpertime_Time
+}{
+	Key:           whereHelperstring{field: "\"auth_attempts\".\"key\""},
+	Failures:      whereHelperint{field: "\"auth_attempts\".\"failures\""},
+	LastFailureAt: whereHelpertime_Time{field: "\"auth_attempts\".\"last_failure_at\""},
+	LockedUntil:   whereHelpernull_Time{field: "\"auth_attempts\".\"locked_until\""},
+	CreatedAt:     whereHelpertime_Time{field: "\"auth_attempts\".\"created_at\""},
+	UpdatedAt:     whereHelpertime_Time{field: "\"auth_attempts\".\"updated_at\""},
+}
+
+// AuthAttemptRels is where relationship names are stored.
+var AuthAttemptRels = struct {
+}{}
+
+// authAttemptR is where relationships are stored.
+type authAttemptR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*authAttemptR) NewStruct() *authAttemptR {
+	return &authAttemptR{}
+}
+
+// authAttemptL is where Load methods for each relationship are stored.
+type authAttemptL struct{}
+
+var (
+	authAttemptAllColumns            = []string{"key", "failures", "last_failure_at", "locked_until", "created_at", "updated_at"}
+	authAttemptColumnsWithoutDefault = []string{"key", "last_failure_at", "created_at", "updated_at"}
+	authAttemptColumnsWithDefault    = []string{"failures", "locked_until"}
+	authAttemptPrimaryKeyColumns     = []string{"key"}
+	authAttemptGeneratedColumns      = []string{}
+)
+
+type (
+	// AuthAttemptSlice is an alias for a slice of pointers to AuthAttempt.
+	// This should almost always be used instead of []AuthAttempt.
+	AuthAttemptSlice []*AuthAttempt
+
+	authAttemptQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	authAttemptType                 = reflect.TypeOf(&AuthAttempt{})
+	authAttemptMapping              = queries.MakeStructMapping(authAttemptType)
+	authAttemptPrimaryKeyMapping, _ = queries.BindMapping(authAttemptType, authAttemptMapping, authAttemptPrimaryKeyColumns)
+	authAttemptInsertCacheMut       sync.RWMutex
+	authAttemptInsertCache          = make(map[string]insertCache)
+	authAttemptUpdateCacheMut       sync.RWMutex
+	authAttemptUpdateCache          = make(map[string]updateCache)
+	authAttemptUpsertCacheMut       sync.RWMutex
+	authAttemptUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single authAttempt record from the query.
+func (q authAttemptQuery) One(ctx context.Context, exec boil.ContextExecutor) (*AuthAttempt, error) {
+	o := &AuthAttempt{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for auth_attempts")
+	}
+
+	return o, nil
+}
+
+// All returns all AuthAttempt records from the query.
+func (q authAttemptQuery) All(ctx context.Context, exec boil.ContextExecutor) (AuthAttemptSlice, error) {
+	var o []*AuthAttempt
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to AuthAttempt slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all AuthAttempt records in the query.
+func (q authAttemptQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count auth_attempts rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q authAttemptQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if auth_attempts exists")
+	}
+
+	return count > 0, nil
+}
+
+// AuthAttempts retrieves all the records using an executor.
+func AuthAttempts(mods ...qm.QueryMod) authAttemptQuery {
+	mods = append(mods, qm.From("\"auth_attempts\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"auth_attempts\".*"})
+	}
+
+	return authAttemptQuery{q}
+}
+
+// FindAuthAttempt retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindAuthAttempt(ctx context.Context, exec boil.ContextExecutor, key string, selectCols ...string) (*AuthAttempt, error) {
+	authAttemptObj := &AuthAttempt{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"auth_attempts\" where \"key\"=$1", sel,
+	)
+
+	q := queries.Raw(query, key)
+
+	err := q.Bind(ctx, exec, authAttemptObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from auth_attempts")
+	}
+
+	return authAttemptObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *AuthAttempt) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no auth_attempts provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(authAttemptColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	authAttemptInsertCacheMut.RLock()
+	cache, cached := authAttemptInsertCache[key]
+	authAttemptInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			authAttemptAllColumns,
+			authAttemptColumnsWithDefault,
+			authAttemptColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(authAttemptType, authAttemptMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(authAttemptType, authAttemptMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"auth_attempts\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"auth_attempts\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into auth_attempts")
+	}
+
+	if !cached {
+		authAttemptInsertCacheMut.Lock()
+		authAttemptInsertCache[key] = cache
+		authAttemptInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the AuthAttempt.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *AuthAttempt) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	key := makeCacheKey(columns, nil)
+	authAttemptUpdateCacheMut.RLock()
+	cache, cached := authAttemptUpdateCache[key]
+	authAttemptUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			authAttemptAllColumns,
+			authAttemptPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update auth_attempts, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"auth_attempts\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, authAttemptPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(authAttemptType, authAttemptMapping, append(wl, authAttemptPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update auth_attempts row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for auth_attempts")
+	}
+
+	if !cached {
+		authAttemptUpdateCacheMut.Lock()
+		authAttemptUpdateCache[key] = cache
+		authAttemptUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q authAttemptQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for auth_attempts")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for auth_attempts")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o AuthAttemptSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), authAttemptPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"auth_attempts\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, authAttemptPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in authAttempt slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all authAttempt")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *AuthAttempt) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no auth_attempts provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		o.UpdatedAt = currTime
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(authAttemptColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	authAttemptUpsertCacheMut.RLock()
+	cache, cached := authAttemptUpsertCache[key]
+	authAttemptUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			authAttemptAllColumns,
+			authAttemptColumnsWithDefault,
+			authAttemptColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			authAttemptAllColumns,
+			authAttemptPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert auth_attempts, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(authAttemptPrimaryKeyColumns))
+			copy(conflict, authAttemptPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"auth_attempts\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(authAttemptType, authAttemptMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(authAttemptType, authAttemptMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert auth_attempts")
+	}
+
+	if !cached {
+		authAttemptUpsertCacheMut.Lock()
+		authAttemptUpsertCache[key] = cache
+		authAttemptUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single AuthAttempt record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *AuthAttempt) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no AuthAttempt provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), authAttemptPrimaryKeyMapping)
+	sql := "DELETE FROM \"auth_attempts\" WHERE \"key\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from auth_attempts")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for auth_attempts")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q authAttemptQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no authAttemptQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from auth_attempts")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for auth_attempts")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o AuthAttemptSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), authAttemptPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"auth_attempts\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, authAttemptPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from authAttempt slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for auth_attempts")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *AuthAttempt) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindAuthAttempt(ctx, exec, o.Key)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *AuthAttemptSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := AuthAttemptSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), authAttemptPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"auth_attempts\".* FROM \"auth_attempts\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, authAttemptPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in AuthAttemptSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// AuthAttemptExists checks if the AuthAttempt row exists.
+func AuthAttemptExists(ctx context.Context, exec boil.ContextExecutor, key string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"auth_attempts\" where \"key\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, key)
+	}
+	row := exec.QueryRowContext(ctx, sql, key)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if auth_attempts exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the AuthAttempt row exists.
+func (o *AuthAttempt) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return AuthAttemptExists(ctx, exec, o.Key)
+}
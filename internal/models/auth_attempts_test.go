@@ -0,0 +1,591 @@
+// Code generated by SQLBoiler 4.14.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testAuthAttempts(t *testing.T) {
+	t.Parallel()
+
+	query := AuthAttempts()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testAuthAttemptsDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthAttempt{}
+	if err = randomize.Struct(seed, o, authAttemptDBTypes, true, authAttemptColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := AuthAttempts().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testAuthAttemptsQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthAttempt{}
+	if err = randomize.Struct(seed, o, authAttemptDBTypes, true, authAttemptColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := AuthAttempts().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := AuthAttempts().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testAuthAttemptsSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthAttempt{}
+	if err = randomize.Struct(seed, o, authAttemptDBTypes, true, authAttemptColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := AuthAttemptSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := AuthAttempts().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testAuthAttemptsExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthAttempt{}
+	if err = randomize.Struct(seed, o, authAttemptDBTypes, true, authAttemptColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := AuthAttemptExists(ctx, tx, o.Key)
+	if err != nil {
+		t.Errorf("Unable to check if AuthAttempt exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected AuthAttemptExists to return true, but got false.")
+	}
+}
+
+func testAuthAttemptsFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthAttempt{}
+	if err = randomize.Struct(seed, o, authAttemptDBTypes, true, authAttemptColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	authAttemptFound, err := FindAuthAttempt(ctx, tx, o.Key)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if authAttemptFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testAuthAttemptsBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthAttempt{}
+	if err = randomize.Struct(seed, o, authAttemptDBTypes, true, authAttemptColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = AuthAttempts().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testAuthAttemptsOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthAttempt{}
+	if err = randomize.Struct(seed, o, authAttemptDBTypes, true, authAttemptColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := AuthAttempts().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testAuthAttemptsAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	authAttemptOne := &AuthAttempt{}
+	authAttemptTwo := &AuthAttempt{}
+	if err = randomize.Struct(seed, authAttemptOne, authAttemptDBTypes, false, authAttemptColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+	if err = randomize.Struct(seed, authAttemptTwo, authAttemptDBTypes, false, authAttemptColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = authAttemptOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = authAttemptTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := AuthAttempts().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testAuthAttemptsCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	authAttemptOne := &AuthAttempt{}
+	authAttemptTwo := &AuthAttempt{}
+	if err = randomize.Struct(seed, authAttemptOne, authAttemptDBTypes, false, authAttemptColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+	if err = randomize.Struct(seed, authAttemptTwo, authAttemptDBTypes, false, authAttemptColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = authAttemptOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = authAttemptTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AuthAttempts().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func testAuthAttemptsInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthAttempt{}
+	if err = randomize.Struct(seed, o, authAttemptDBTypes, true, authAttemptColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AuthAttempts().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testAuthAttemptsInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthAttempt{}
+	if err = randomize.Struct(seed, o, authAttemptDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(authAttemptColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AuthAttempts().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testAuthAttemptsReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthAttempt{}
+	if err = randomize.Struct(seed, o, authAttemptDBTypes, true, authAttemptColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testAuthAttemptsReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthAttempt{}
+	if err = randomize.Struct(seed, o, authAttemptDBTypes, true, authAttemptColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := AuthAttemptSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testAuthAttemptsSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthAttempt{}
+	if err = randomize.Struct(seed, o, authAttemptDBTypes, true, authAttemptColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := AuthAttempts().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	authAttemptDBTypes = map[string]string{`Key`: `text`, `Failures`: `integer`, `LastFailureAt`: `timestamp with time zone`, `LockedUntil`: `timestamp with time zone`, `CreatedAt`: `timestamp with time zone`, `UpdatedAt`: `timestamp with time zone`}
+	_                  = bytes.MinRead
+)
+
+func testAuthAttemptsUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(authAttemptPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(authAttemptAllColumns) == len(authAttemptPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthAttempt{}
+	if err = randomize.Struct(seed, o, authAttemptDBTypes, true, authAttemptColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AuthAttempts().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, authAttemptDBTypes, true, authAttemptPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testAuthAttemptsSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(authAttemptAllColumns) == len(authAttemptPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthAttempt{}
+	if err = randomize.Struct(seed, o, authAttemptDBTypes, true, authAttemptColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AuthAttempts().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, authAttemptDBTypes, true, authAttemptPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(authAttemptAllColumns, authAttemptPrimaryKeyColumns) {
+		fields = authAttemptAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			authAttemptAllColumns,
+			authAttemptPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := AuthAttemptSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testAuthAttemptsUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(authAttemptAllColumns) == len(authAttemptPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := AuthAttempt{}
+	if err = randomize.Struct(seed, &o, authAttemptDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert AuthAttempt: %s", err)
+	}
+
+	count, err := AuthAttempts().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, authAttemptDBTypes, false, authAttemptPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize AuthAttempt struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert AuthAttempt: %s", err)
+	}
+
+	count, err = AuthAttempts().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
@@ -13,105 +13,237 @@ import "testing"
 // Separating the tests thusly grants avoidance of Postgres deadlocks.
 func TestParent(t *testing.T) {
 	t.Run("AccessTokens", testAccessTokens)
+	t.Run("APIKeys", testAPIKeys)
 	t.Run("AppUserProfiles", testAppUserProfiles)
+	t.Run("AuthAttempts", testAuthAttempts)
+	t.Run("EmailVerificationTokens", testEmailVerificationTokens)
+	t.Run("Identities", testIdentities)
+	t.Run("Jobs", testJobs)
+	t.Run("MfaRecoveryCodes", testMfaRecoveryCodes)
+	t.Run("MfaTokens", testMfaTokens)
+	t.Run("OidcAuthRequests", testOidcAuthRequests)
+	t.Run("OutboxMessages", testOutboxMessages)
 	t.Run("PasswordResetTokens", testPasswordResetTokens)
 	t.Run("PushTokens", testPushTokens)
 	t.Run("RefreshTokens", testRefreshTokens)
+	t.Run("ScheduledTasks", testScheduledTasks)
+	t.Run("UserTotps", testUserTotps)
 	t.Run("Users", testUsers)
 }
 
 func TestDelete(t *testing.T) {
 	t.Run("AccessTokens", testAccessTokensDelete)
+	t.Run("APIKeys", testAPIKeysDelete)
 	t.Run("AppUserProfiles", testAppUserProfilesDelete)
+	t.Run("AuthAttempts", testAuthAttemptsDelete)
+	t.Run("EmailVerificationTokens", testEmailVerificationTokensDelete)
+	t.Run("Identities", testIdentitiesDelete)
+	t.Run("Jobs", testJobsDelete)
+	t.Run("MfaRecoveryCodes", testMfaRecoveryCodesDelete)
+	t.Run("MfaTokens", testMfaTokensDelete)
+	t.Run("OidcAuthRequests", testOidcAuthRequestsDelete)
+	t.Run("OutboxMessages", testOutboxMessagesDelete)
 	t.Run("PasswordResetTokens", testPasswordResetTokensDelete)
 	t.Run("PushTokens", testPushTokensDelete)
 	t.Run("RefreshTokens", testRefreshTokensDelete)
+	t.Run("ScheduledTasks", testScheduledTasksDelete)
+	t.Run("UserTotps", testUserTotpsDelete)
 	t.Run("Users", testUsersDelete)
 }
 
 func TestQueryDeleteAll(t *testing.T) {
 	t.Run("AccessTokens", testAccessTokensQueryDeleteAll)
+	t.Run("APIKeys", testAPIKeysQueryDeleteAll)
 	t.Run("AppUserProfiles", testAppUserProfilesQueryDeleteAll)
+	t.Run("AuthAttempts", testAuthAttemptsQueryDeleteAll)
+	t.Run("EmailVerificationTokens", testEmailVerificationTokensQueryDeleteAll)
+	t.Run("Identities", testIdentitiesQueryDeleteAll)
+	t.Run("Jobs", testJobsQueryDeleteAll)
+	t.Run("MfaRecoveryCodes", testMfaRecoveryCodesQueryDeleteAll)
+	t.Run("MfaTokens", testMfaTokensQueryDeleteAll)
+	t.Run("OidcAuthRequests", testOidcAuthRequestsQueryDeleteAll)
+	t.Run("OutboxMessages", testOutboxMessagesQueryDeleteAll)
 	t.Run("PasswordResetTokens", testPasswordResetTokensQueryDeleteAll)
 	t.Run("PushTokens", testPushTokensQueryDeleteAll)
 	t.Run("RefreshTokens", testRefreshTokensQueryDeleteAll)
+	t.Run("ScheduledTasks", testScheduledTasksQueryDeleteAll)
+	t.Run("UserTotps", testUserTotpsQueryDeleteAll)
 	t.Run("Users", testUsersQueryDeleteAll)
 }
 
 func TestSliceDeleteAll(t *testing.T) {
 	t.Run("AccessTokens", testAccessTokensSliceDeleteAll)
+	t.Run("APIKeys", testAPIKeysSliceDeleteAll)
 	t.Run("AppUserProfiles", testAppUserProfilesSliceDeleteAll)
+	t.Run("AuthAttempts", testAuthAttemptsSliceDeleteAll)
+	t.Run("EmailVerificationTokens", testEmailVerificationTokensSliceDeleteAll)
+	t.Run("Identities", testIdentitiesSliceDeleteAll)
+	t.Run("Jobs", testJobsSliceDeleteAll)
+	t.Run("MfaRecoveryCodes", testMfaRecoveryCodesSliceDeleteAll)
+	t.Run("MfaTokens", testMfaTokensSliceDeleteAll)
+	t.Run("OidcAuthRequests", testOidcAuthRequestsSliceDeleteAll)
+	t.Run("OutboxMessages", testOutboxMessagesSliceDeleteAll)
 	t.Run("PasswordResetTokens", testPasswordResetTokensSliceDeleteAll)
 	t.Run("PushTokens", testPushTokensSliceDeleteAll)
 	t.Run("RefreshTokens", testRefreshTokensSliceDeleteAll)
+	t.Run("ScheduledTasks", testScheduledTasksSliceDeleteAll)
+	t.Run("UserTotps", testUserTotpsSliceDeleteAll)
 	t.Run("Users", testUsersSliceDeleteAll)
 }
 
 func TestExists(t *testing.T) {
 	t.Run("AccessTokens", testAccessTokensExists)
+	t.Run("APIKeys", testAPIKeysExists)
 	t.Run("AppUserProfiles", testAppUserProfilesExists)
+	t.Run("AuthAttempts", testAuthAttemptsExists)
+	t.Run("EmailVerificationTokens", testEmailVerificationTokensExists)
+	t.Run("Identities", testIdentitiesExists)
+	t.Run("Jobs", testJobsExists)
+	t.Run("MfaRecoveryCodes", testMfaRecoveryCodesExists)
+	t.Run("MfaTokens", testMfaTokensExists)
+	t.Run("OidcAuthRequests", testOidcAuthRequestsExists)
+	t.Run("OutboxMessages", testOutboxMessagesExists)
 	t.Run("PasswordResetTokens", testPasswordResetTokensExists)
 	t.Run("PushTokens", testPushTokensExists)
 	t.Run("RefreshTokens", testRefreshTokensExists)
+	t.Run("ScheduledTasks", testScheduledTasksExists)
+	t.Run("UserTotps", testUserTotpsExists)
 	t.Run("Users", testUsersExists)
 }
 
 func TestFind(t *testing.T) {
 	t.Run("AccessTokens", testAccessTokensFind)
+	t.Run("APIKeys", testAPIKeysFind)
 	t.Run("AppUserProfiles", testAppUserProfilesFind)
+	t.Run("AuthAttempts", testAuthAttemptsFind)
+	t.Run("EmailVerificationTokens", testEmailVerificationTokensFind)
+	t.Run("Identities", testIdentitiesFind)
+	t.Run("Jobs", testJobsFind)
+	t.Run("MfaRecoveryCodes", testMfaRecoveryCodesFind)
+	t.Run("MfaTokens", testMfaTokensFind)
+	t.Run("OidcAuthRequests", testOidcAuthRequestsFind)
+	t.Run("OutboxMessages", testOutboxMessagesFind)
 	t.Run("PasswordResetTokens", testPasswordResetTokensFind)
 	t.Run("PushTokens", testPushTokensFind)
 	t.Run("RefreshTokens", testRefreshTokensFind)
+	t.Run("ScheduledTasks", testScheduledTasksFind)
+	t.Run("UserTotps", testUserTotpsFind)
 	t.Run("Users", testUsersFind)
 }
 
 func TestBind(t *testing.T) {
 	t.Run("AccessTokens", testAccessTokensBind)
+	t.Run("APIKeys", testAPIKeysBind)
 	t.Run("AppUserProfiles", testAppUserProfilesBind)
+	t.Run("AuthAttempts", testAuthAttemptsBind)
+	t.Run("EmailVerificationTokens", testEmailVerificationTokensBind)
+	t.Run("Identities", testIdentitiesBind)
+	t.Run("Jobs", testJobsBind)
+	t.Run("MfaRecoveryCodes", testMfaRecoveryCodesBind)
+	t.Run("MfaTokens", testMfaTokensBind)
+	t.Run("OidcAuthRequests", testOidcAuthRequestsBind)
+	t.Run("OutboxMessages", testOutboxMessagesBind)
 	t.Run("PasswordResetTokens", testPasswordResetTokensBind)
 	t.Run("PushTokens", testPushTokensBind)
 	t.Run("RefreshTokens", testRefreshTokensBind)
+	t.Run("ScheduledTasks", testScheduledTasksBind)
+	t.Run("UserTotps", testUserTotpsBind)
 	t.Run("Users", testUsersBind)
 }
 
 func TestOne(t *testing.T) {
 	t.Run("AccessTokens", testAccessTokensOne)
+	t.Run("APIKeys", testAPIKeysOne)
 	t.Run("AppUserProfiles", testAppUserProfilesOne)
+	t.Run("AuthAttempts", testAuthAttemptsOne)
+	t.Run("EmailVerificationTokens", testEmailVerificationTokensOne)
+	t.Run("Identities", testIdentitiesOne)
+	t.Run("Jobs", testJobsOne)
+	t.Run("MfaRecoveryCodes", testMfaRecoveryCodesOne)
+	t.Run("MfaTokens", testMfaTokensOne)
+	t.Run("OidcAuthRequests", testOidcAuthRequestsOne)
+	t.Run("OutboxMessages", testOutboxMessagesOne)
 	t.Run("PasswordResetTokens", testPasswordResetTokensOne)
 	t.Run("PushTokens", testPushTokensOne)
 	t.Run("RefreshTokens", testRefreshTokensOne)
+	t.Run("ScheduledTasks", testScheduledTasksOne)
+	t.Run("UserTotps", testUserTotpsOne)
 	t.Run("Users", testUsersOne)
 }
 
 func TestAll(t *testing.T) {
 	t.Run("AccessTokens", testAccessTokensAll)
+	t.Run("APIKeys", testAPIKeysAll)
 	t.Run("AppUserProfiles", testAppUserProfilesAll)
+	t.Run("AuthAttempts", testAuthAttemptsAll)
+	t.Run("EmailVerificationTokens", testEmailVerificationTokensAll)
+	t.Run("Identities", testIdentitiesAll)
+	t.Run("Jobs", testJobsAll)
+	t.Run("MfaRecoveryCodes", testMfaRecoveryCodesAll)
+	t.Run("MfaTokens", testMfaTokensAll)
+	t.Run("OidcAuthRequests", testOidcAuthRequestsAll)
+	t.Run("OutboxMessages", testOutboxMessagesAll)
 	t.Run("PasswordResetTokens", testPasswordResetTokensAll)
 	t.Run("PushTokens", testPushTokensAll)
 	t.Run("RefreshTokens", testRefreshTokensAll)
+	t.Run("ScheduledTasks", testScheduledTasksAll)
+	t.Run("UserTotps", testUserTotpsAll)
 	t.Run("Users", testUsersAll)
 }
 
 func TestCount(t *testing.T) {
 	t.Run("AccessTokens", testAccessTokensCount)
+	t.Run("APIKeys", testAPIKeysCount)
 	t.Run("AppUserProfiles", testAppUserProfilesCount)
+	t.Run("AuthAttempts", testAuthAttemptsCount)
+	t.Run("EmailVerificationTokens", testEmailVerificationTokensCount)
+	t.Run("Identities", testIdentitiesCount)
+	t.Run("Jobs", testJobsCount)
+	t.Run("MfaRecoveryCodes", testMfaRecoveryCodesCount)
+	t.Run("MfaTokens", testMfaTokensCount)
+	t.Run("OidcAuthRequests", testOidcAuthRequestsCount)
+	t.Run("OutboxMessages", testOutboxMessagesCount)
 	t.Run("PasswordResetTokens", testPasswordResetTokensCount)
 	t.Run("PushTokens", testPushTokensCount)
 	t.Run("RefreshTokens", testRefreshTokensCount)
+	t.Run("ScheduledTasks", testScheduledTasksCount)
+	t.Run("UserTotps", testUserTotpsCount)
 	t.Run("Users", testUsersCount)
 }
 
 func TestInsert(t *testing.T) {
 	t.Run("AccessTokens", testAccessTokensInsert)
 	t.Run("AccessTokens", testAccessTokensInsertWhitelist)
+	t.Run("APIKeys", testAPIKeysInsert)
+	t.Run("APIKeys", testAPIKeysInsertWhitelist)
 	t.Run("AppUserProfiles", testAppUserProfilesInsert)
 	t.Run("AppUserProfiles", testAppUserProfilesInsertWhitelist)
+	t.Run("AuthAttempts", testAuthAttemptsInsert)
+	t.Run("AuthAttempts", testAuthAttemptsInsertWhitelist)
+	t.Run("EmailVerificationTokens", testEmailVerificationTokensInsert)
+	t.Run("EmailVerificationTokens", testEmailVerificationTokensInsertWhitelist)
+	t.Run("Identities", testIdentitiesInsert)
+	t.Run("Identities", testIdentitiesInsertWhitelist)
+	t.Run("Jobs", testJobsInsert)
+	t.Run("Jobs", testJobsInsertWhitelist)
+	t.Run("MfaRecoveryCodes", testMfaRecoveryCodesInsert)
+	t.Run("MfaRecoveryCodes", testMfaRecoveryCodesInsertWhitelist)
+	t.Run("MfaTokens", testMfaTokensInsert)
+	t.Run("MfaTokens", testMfaTokensInsertWhitelist)
+	t.Run("OidcAuthRequests", testOidcAuthRequestsInsert)
+	t.Run("OidcAuthRequests", testOidcAuthRequestsInsertWhitelist)
+	t.Run("OutboxMessages", testOutboxMessagesInsert)
+	t.Run("OutboxMessages", testOutboxMessagesInsertWhitelist)
 	t.Run("PasswordResetTokens", testPasswordResetTokensInsert)
 	t.Run("PasswordResetTokens", testPasswordResetTokensInsertWhitelist)
 	t.Run("PushTokens", testPushTokensInsert)
 	t.Run("PushTokens", testPushTokensInsertWhitelist)
 	t.Run("RefreshTokens", testRefreshTokensInsert)
 	t.Run("RefreshTokens", testRefreshTokensInsertWhitelist)
+	t.Run("ScheduledTasks", testScheduledTasksInsert)
+	t.Run("ScheduledTasks", testScheduledTasksInsertWhitelist)
+	t.Run("UserTotps", testUserTotpsInsert)
+	t.Run("UserTotps", testUserTotpsInsertWhitelist)
 	t.Run("Users", testUsersInsert)
 	t.Run("Users", testUsersInsertWhitelist)
 }
@@ -120,22 +252,34 @@ func TestInsert(t *testing.T) {
 // or deadlocks can occur.
 func TestToOne(t *testing.T) {
 	t.Run("AccessTokenToUserUsingUser", testAccessTokenToOneUserUsingUser)
+	t.Run("APIKeyToUserUsingUser", testAPIKeyToOneUserUsingUser)
 	t.Run("AppUserProfileToUserUsingUser", testAppUserProfileToOneUserUsingUser)
+	t.Run("EmailVerificationTokenToUserUsingUser", testEmailVerificationTokenToOneUserUsingUser)
+	t.Run("IdentityToUserUsingUser", testIdentityToOneUserUsingUser)
+	t.Run("MfaRecoveryCodeToUserUsingUser", testMfaRecoveryCodeToOneUserUsingUser)
+	t.Run("MfaTokenToUserUsingUser", testMfaTokenToOneUserUsingUser)
 	t.Run("PasswordResetTokenToUserUsingUser", testPasswordResetTokenToOneUserUsingUser)
 	t.Run("PushTokenToUserUsingUser", testPushTokenToOneUserUsingUser)
 	t.Run("RefreshTokenToUserUsingUser", testRefreshTokenToOneUserUsingUser)
+	t.Run("UserTotpToUserUsingUser", testUserTotpToOneUserUsingUser)
 }
 
 // TestOneToOne tests cannot be run in parallel
 // or deadlocks can occur.
 func TestOneToOne(t *testing.T) {
 	t.Run("UserToAppUserProfileUsingAppUserProfile", testUserOneToOneAppUserProfileUsingAppUserProfile)
+	t.Run("UserToUserTotpUsingUserTotp", testUserOneToOneUserTotpUsingUserTotp)
 }
 
 // TestToMany tests cannot be run in parallel
 // or deadlocks can occur.
 func TestToMany(t *testing.T) {
 	t.Run("UserToAccessTokens", testUserToManyAccessTokens)
+	t.Run("UserToAPIKeys", testUserToManyAPIKeys)
+	t.Run("UserToEmailVerificationTokens", testUserToManyEmailVerificationTokens)
+	t.Run("UserToIdentities", testUserToManyIdentities)
+	t.Run("UserToMfaRecoveryCodes", testUserToManyMfaRecoveryCodes)
+	t.Run("UserToMfaTokens", testUserToManyMfaTokens)
 	t.Run("UserToPasswordResetTokens", testUserToManyPasswordResetTokens)
 	t.Run("UserToPushTokens", testUserToManyPushTokens)
 	t.Run("UserToRefreshTokens", testUserToManyRefreshTokens)
@@ -145,10 +289,16 @@ func TestToMany(t *testing.T) {
 // or deadlocks can occur.
 func TestToOneSet(t *testing.T) {
 	t.Run("AccessTokenToUserUsingAccessTokens", testAccessTokenToOneSetOpUserUsingUser)
+	t.Run("APIKeyToUserUsingAPIKeys", testAPIKeyToOneSetOpUserUsingUser)
 	t.Run("AppUserProfileToUserUsingAppUserProfile", testAppUserProfileToOneSetOpUserUsingUser)
+	t.Run("EmailVerificationTokenToUserUsingEmailVerificationTokens", testEmailVerificationTokenToOneSetOpUserUsingUser)
+	t.Run("IdentityToUserUsingIdentities", testIdentityToOneSetOpUserUsingUser)
+	t.Run("MfaRecoveryCodeToUserUsingMfaRecoveryCodes", testMfaRecoveryCodeToOneSetOpUserUsingUser)
+	t.Run("MfaTokenToUserUsingMfaTokens", testMfaTokenToOneSetOpUserUsingUser)
 	t.Run("PasswordResetTokenToUserUsingPasswordResetTokens", testPasswordResetTokenToOneSetOpUserUsingUser)
 	t.Run("PushTokenToUserUsingPushTokens", testPushTokenToOneSetOpUserUsingUser)
 	t.Run("RefreshTokenToUserUsingRefreshTokens", testRefreshTokenToOneSetOpUserUsingUser)
+	t.Run("UserTotpToUserUsingUserTotp", testUserTotpToOneSetOpUserUsingUser)
 }
 
 // TestToOneRemove tests cannot be run in parallel
@@ -159,6 +309,7 @@ func TestToOneRemove(t *testing.T) {}
 // or deadlocks can occur.
 func TestOneToOneSet(t *testing.T) {
 	t.Run("UserToAppUserProfileUsingAppUserProfile", testUserOneToOneSetOpAppUserProfileUsingAppUserProfile)
+	t.Run("UserToUserTotpUsingUserTotp", testUserOneToOneSetOpUserTotpUsingUserTotp)
 }
 
 // TestOneToOneRemove tests cannot be run in parallel
@@ -169,6 +320,11 @@ func TestOneToOneRemove(t *testing.T) {}
 // or deadlocks can occur.
 func TestToManyAdd(t *testing.T) {
 	t.Run("UserToAccessTokens", testUserToManyAddOpAccessTokens)
+	t.Run("UserToAPIKeys", testUserToManyAddOpAPIKeys)
+	t.Run("UserToEmailVerificationTokens", testUserToManyAddOpEmailVerificationTokens)
+	t.Run("UserToIdentities", testUserToManyAddOpIdentities)
+	t.Run("UserToMfaRecoveryCodes", testUserToManyAddOpMfaRecoveryCodes)
+	t.Run("UserToMfaTokens", testUserToManyAddOpMfaTokens)
 	t.Run("UserToPasswordResetTokens", testUserToManyAddOpPasswordResetTokens)
 	t.Run("UserToPushTokens", testUserToManyAddOpPushTokens)
 	t.Run("UserToRefreshTokens", testUserToManyAddOpRefreshTokens)
@@ -184,45 +340,100 @@ func TestToManyRemove(t *testing.T) {}
 
 func TestReload(t *testing.T) {
 	t.Run("AccessTokens", testAccessTokensReload)
+	t.Run("APIKeys", testAPIKeysReload)
 	t.Run("AppUserProfiles", testAppUserProfilesReload)
+	t.Run("AuthAttempts", testAuthAttemptsReload)
+	t.Run("EmailVerificationTokens", testEmailVerificationTokensReload)
+	t.Run("Identities", testIdentitiesReload)
+	t.Run("Jobs", testJobsReload)
+	t.Run("MfaRecoveryCodes", testMfaRecoveryCodesReload)
+	t.Run("MfaTokens", testMfaTokensReload)
+	t.Run("OidcAuthRequests", testOidcAuthRequestsReload)
+	t.Run("OutboxMessages", testOutboxMessagesReload)
 	t.Run("PasswordResetTokens", testPasswordResetTokensReload)
 	t.Run("PushTokens", testPushTokensReload)
 	t.Run("RefreshTokens", testRefreshTokensReload)
+	t.Run("ScheduledTasks", testScheduledTasksReload)
+	t.Run("UserTotps", testUserTotpsReload)
 	t.Run("Users", testUsersReload)
 }
 
 func TestReloadAll(t *testing.T) {
 	t.Run("AccessTokens", testAccessTokensReloadAll)
+	t.Run("APIKeys", testAPIKeysReloadAll)
 	t.Run("AppUserProfiles", testAppUserProfilesReloadAll)
+	t.Run("AuthAttempts", testAuthAttemptsReloadAll)
+	t.Run("EmailVerificationTokens", testEmailVerificationTokensReloadAll)
+	t.Run("Identities", testIdentitiesReloadAll)
+	t.Run("Jobs", testJobsReloadAll)
+	t.Run("MfaRecoveryCodes", testMfaRecoveryCodesReloadAll)
+	t.Run("MfaTokens", testMfaTokensReloadAll)
+	t.Run("OidcAuthRequests", testOidcAuthRequestsReloadAll)
+	t.Run("OutboxMessages", testOutboxMessagesReloadAll)
 	t.Run("PasswordResetTokens", testPasswordResetTokensReloadAll)
 	t.Run("PushTokens", testPushTokensReloadAll)
 	t.Run("RefreshTokens", testRefreshTokensReloadAll)
+	t.Run("ScheduledTasks", testScheduledTasksReloadAll)
+	t.Run("UserTotps", testUserTotpsReloadAll)
 	t.Run("Users", testUsersReloadAll)
 }
 
 func TestSelect(t *testing.T) {
 	t.Run("AccessTokens", testAccessTokensSelect)
+	t.Run("APIKeys", testAPIKeysSelect)
 	t.Run("AppUserProfiles", testAppUserProfilesSelect)
+	t.Run("AuthAttempts", testAuthAttemptsSelect)
+	t.Run("EmailVerificationTokens", testEmailVerificationTokensSelect)
+	t.Run("Identities", testIdentitiesSelect)
+	t.Run("Jobs", testJobsSelect)
+	t.Run("MfaRecoveryCodes", testMfaRecoveryCodesSelect)
+	t.Run("MfaTokens", testMfaTokensSelect)
+	t.Run("OidcAuthRequests", testOidcAuthRequestsSelect)
+	t.Run("OutboxMessages", testOutboxMessagesSelect)
 	t.Run("PasswordResetTokens", testPasswordResetTokensSelect)
 	t.Run("PushTokens", testPushTokensSelect)
 	t.Run("RefreshTokens", testRefreshTokensSelect)
+	t.Run("ScheduledTasks", testScheduledTasksSelect)
+	t.Run("UserTotps", testUserTotpsSelect)
 	t.Run("Users", testUsersSelect)
 }
 
 func TestUpdate(t *testing.T) {
 	t.Run("AccessTokens", testAccessTokensUpdate)
+	t.Run("APIKeys", testAPIKeysUpdate)
 	t.Run("AppUserProfiles", testAppUserProfilesUpdate)
+	t.Run("AuthAttempts", testAuthAttemptsUpdate)
+	t.Run("EmailVerificationTokens", testEmailVerificationTokensUpdate)
+	t.Run("Identities", testIdentitiesUpdate)
+	t.Run("Jobs", testJobsUpdate)
+	t.Run("MfaRecoveryCodes", testMfaRecoveryCodesUpdate)
+	t.Run("MfaTokens", testMfaTokensUpdate)
+	t.Run("OidcAuthRequests", testOidcAuthRequestsUpdate)
+	t.Run("OutboxMessages", testOutboxMessagesUpdate)
 	t.Run("PasswordResetTokens", testPasswordResetTokensUpdate)
 	t.Run("PushTokens", testPushTokensUpdate)
 	t.Run("RefreshTokens", testRefreshTokensUpdate)
+	t.Run("ScheduledTasks", testScheduledTasksUpdate)
+	t.Run("UserTotps", testUserTotpsUpdate)
 	t.Run("Users", testUsersUpdate)
 }
 
 func TestSliceUpdateAll(t *testing.T) {
 	t.Run("AccessTokens", testAccessTokensSliceUpdateAll)
+	t.Run("APIKeys", testAPIKeysSliceUpdateAll)
 	t.Run("AppUserProfiles", testAppUserProfilesSliceUpdateAll)
+	t.Run("AuthAttempts", testAuthAttemptsSliceUpdateAll)
+	t.Run("EmailVerificationTokens", testEmailVerificationTokensSliceUpdateAll)
+	t.Run("Identities", testIdentitiesSliceUpdateAll)
+	t.Run("Jobs", testJobsSliceUpdateAll)
+	t.Run("MfaRecoveryCodes", testMfaRecoveryCodesSliceUpdateAll)
+	t.Run("MfaTokens", testMfaTokensSliceUpdateAll)
+	t.Run("OidcAuthRequests", testOidcAuthRequestsSliceUpdateAll)
+	t.Run("OutboxMessages", testOutboxMessagesSliceUpdateAll)
 	t.Run("PasswordResetTokens", testPasswordResetTokensSliceUpdateAll)
 	t.Run("PushTokens", testPushTokensSliceUpdateAll)
 	t.Run("RefreshTokens", testRefreshTokensSliceUpdateAll)
+	t.Run("ScheduledTasks", testScheduledTasksSliceUpdateAll)
+	t.Run("UserTotps", testUserTotpsSliceUpdateAll)
 	t.Run("Users", testUsersSliceUpdateAll)
 }
@@ -4,17 +4,39 @@
 package models
 
 var TableNames = struct {
-	AccessTokens        string
-	AppUserProfiles     string
-	PasswordResetTokens string
-	PushTokens          string
-	RefreshTokens       string
-	Users               string
+	AccessTokens            string
+	APIKeys                 string
+	AppUserProfiles         string
+	AuthAttempts            string
+	EmailVerificationTokens string
+	Identities              string
+	Jobs                    string
+	MfaRecoveryCodes        string
+	MfaTokens               string
+	OidcAuthRequests        string
+	OutboxMessages          string
+	PasswordResetTokens     string
+	PushTokens              string
+	RefreshTokens           string
+	ScheduledTasks          string
+	UserTotps               string
+	Users                   string
 }{
-	AccessTokens:        "access_tokens",
-	AppUserProfiles:     "app_user_profiles",
-	PasswordResetTokens: "password_reset_tokens",
-	PushTokens:          "push_tokens",
-	RefreshTokens:       "refresh_tokens",
-	Users:               "users",
+	AccessTokens:            "access_tokens",
+	APIKeys:                 "api_keys",
+	AppUserProfiles:         "app_user_profiles",
+	AuthAttempts:            "auth_attempts",
+	EmailVerificationTokens: "email_verification_tokens",
+	Identities:              "identities",
+	Jobs:                    "jobs",
+	MfaRecoveryCodes:        "mfa_recovery_codes",
+	MfaTokens:               "mfa_tokens",
+	OidcAuthRequests:        "oidc_auth_requests",
+	OutboxMessages:          "outbox_messages",
+	PasswordResetTokens:     "password_reset_tokens",
+	PushTokens:              "push_tokens",
+	RefreshTokens:           "refresh_tokens",
+	ScheduledTasks:          "scheduled_tasks",
+	UserTotps:               "user_totps",
+	Users:                   "users",
 }
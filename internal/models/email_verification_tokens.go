@@ -0,0 +1,895 @@
+// Code generated by SQLBoiler 4.14.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// EmailVerificationToken is an object representing the database table.
+type EmailVerificationToken struct {
+	Token      string    `boil:"token" json:"token" toml:"token" yaml:"token"`
+	ValidUntil time.Time `boil:"valid_until" json:"valid_until" toml:"valid_until" yaml:"valid_until"`
+	UserID     string    `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	CreatedAt  time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt  time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+
+	R *emailVerificationTokenR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L emailVerificationTokenL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var EmailVerificationTokenColumns = struct {
+	Token      string
+	ValidUntil string
+	UserID     string
+	CreatedAt  string
+	UpdatedAt  string
+}{
+	Token:      "token",
+	ValidUntil: "valid_until",
+	UserID:     "user_id",
+	CreatedAt:  "created_at",
+	UpdatedAt:  "updated_at",
+}
+
+var EmailVerificationTokenTableColumns = struct {
+	Token      string
+	ValidUntil string
+	UserID     string
+	CreatedAt  string
+	UpdatedAt  string
+}{
+	Token:      "email_verification_tokens.token",
+	ValidUntil: "email_verification_tokens.valid_until",
+	UserID:     "email_verification_tokens.user_id",
+	CreatedAt:  "email_verification_tokens.created_at",
+	UpdatedAt:  "email_verification_tokens.updated_at",
+}
+
+// Generated where
+
+var EmailVerificationTokenWhere = struct {
+	Token      whereHelperstring
+	ValidUntil whereHelpertime_Time
+	UserID     whereHelperstring
+	CreatedAt  whereHelpertime_Time
+	UpdatedAt  whereHelpertime_Time
+}{
+	Token:      whereHelperstring{field: "\"email_verification_tokens\".\"token\""},
+	ValidUntil: whereHelpertime_Time{field: "\"email_verification_tokens\".\"valid_until\""},
+	UserID:     whereHelperstring{field: "\"email_verification_tokens\".\"user_id\""},
+	CreatedAt:  whereHelpertime_Time{field: "\"email_verification_tokens\".\"created_at\""},
+	UpdatedAt:  whereHelpertime_Time{field: "\"email_verification_tokens\".\"updated_at\""},
+}
+
+// EmailVerificationTokenRels is where relationship names are stored.
+var EmailVerificationTokenRels = struct {
+	User string
+}{
+	User: "User",
+}
+
+// emailVerificationTokenR is where relationships are stored.
+type emailVerificationTokenR struct {
+	User *User `boil:"User" json:"User" toml:"User" yaml:"User"`
+}
+
+// NewStruct creates a new relationship struct
+func (*emailVerificationTokenR) NewStruct() *emailVerificationTokenR {
+	return &emailVerificationTokenR{}
+}
+
+func (r *emailVerificationTokenR) GetUser() *User {
+	if r == nil {
+		return nil
+	}
+	return r.User
+}
+
+// emailVerificationTokenL is where Load methods for each relationship are stored.
+type emailVerificationTokenL struct{}
+
+var (
+	emailVerificationTokenAllColumns            = []string{"token", "valid_until", "user_id", "created_at", "updated_at"}
+	emailVerificationTokenColumnsWithoutDefault = []string{"valid_until", "user_id", "created_at", "updated_at"}
+	emailVerificationTokenColumnsWithDefault    = []string{"token"}
+	emailVerificationTokenPrimaryKeyColumns     = []string{"token"}
+	emailVerificationTokenGeneratedColumns      = []string{}
+)
+
+type (
+	// EmailVerificationTokenSlice is an alias for a slice of pointers to EmailVerificationToken.
+	// This should almost always be used instead of []EmailVerificationToken.
+	EmailVerificationTokenSlice []*EmailVerificationToken
+
+	emailVerificationTokenQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	emailVerificationTokenType                 = reflect.TypeOf(&EmailVerificationToken{})
+	emailVerificationTokenMapping              = queries.MakeStructMapping(emailVerificationTokenType)
+	emailVerificationTokenPrimaryKeyMapping, _ = queries.BindMapping(emailVerificationTokenType, emailVerificationTokenMapping, emailVerificationTokenPrimaryKeyColumns)
+	emailVerificationTokenInsertCacheMut       sync.RWMutex
+	emailVerificationTokenInsertCache          = make(map[string]insertCache)
+	emailVerificationTokenUpdateCacheMut       sync.RWMutex
+	emailVerificationTokenUpdateCache          = make(map[string]updateCache)
+	emailVerificationTokenUpsertCacheMut       sync.RWMutex
+	emailVerificationTokenUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single emailVerificationToken record from the query.
+func (q emailVerificationTokenQuery) One(ctx context.Context, exec boil.ContextExecutor) (*EmailVerificationToken, error) {
+	o := &EmailVerificationToken{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for email_verification_tokens")
+	}
+
+	return o, nil
+}
+
+// All returns all EmailVerificationToken records from the query.
+func (q emailVerificationTokenQuery) All(ctx context.Context, exec boil.ContextExecutor) (EmailVerificationTokenSlice, error) {
+	var o []*EmailVerificationToken
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to EmailVerificationToken slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all EmailVerificationToken records in the query.
+func (q emailVerificationTokenQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count email_verification_tokens rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q emailVerificationTokenQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if email_verification_tokens exists")
+	}
+
+	return count > 0, nil
+}
+
+// User pointed to by the foreign key.
+func (o *EmailVerificationToken) User(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.UserID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Users(queryMods...)
+}
+
+// LoadUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (emailVerificationTokenL) LoadUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeEmailVerificationToken interface{}, mods queries.Applicator) error {
+	var slice []*EmailVerificationToken
+	var object *EmailVerificationToken
+
+	if singular {
+		var ok bool
+		object, ok = maybeEmailVerificationToken.(*EmailVerificationToken)
+		if !ok {
+			object = new(EmailVerificationToken)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeEmailVerificationToken)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeEmailVerificationToken))
+			}
+		}
+	} else {
+		s, ok := maybeEmailVerificationToken.(*[]*EmailVerificationToken)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeEmailVerificationToken)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeEmailVerificationToken))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &emailVerificationTokenR{}
+		}
+		args = append(args, object.UserID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &emailVerificationTokenR{}
+			}
+
+			for _, a := range args {
+				if a == obj.UserID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.UserID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`users`),
+		qm.WhereIn(`users.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.User = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.EmailVerificationTokens = append(foreign.R.EmailVerificationTokens, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.UserID == foreign.ID {
+				local.R.User = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.EmailVerificationTokens = append(foreign.R.EmailVerificationTokens, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetUser of the emailVerificationToken to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.EmailVerificationTokens.
+func (o *EmailVerificationToken) SetUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"email_verification_tokens\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, emailVerificationTokenPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.Token}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.UserID = related.ID
+	if o.R == nil {
+		o.R = &emailVerificationTokenR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			EmailVerificationTokens: EmailVerificationTokenSlice{o},
+		}
+	} else {
+		related.R.EmailVerificationTokens = append(related.R.EmailVerificationTokens, o)
+	}
+
+	return nil
+}
+
+// EmailVerificationTokens retrieves all the records using an executor.
+func EmailVerificationTokens(mods ...qm.QueryMod) emailVerificationTokenQuery {
+	mods = append(mods, qm.From("\"email_verification_tokens\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"email_verification_tokens\".*"})
+	}
+
+	return emailVerificationTokenQuery{q}
+}
+
+// FindEmailVerificationToken retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindEmailVerificationToken(ctx context.Context, exec boil.ContextExecutor, token string, selectCols ...string) (*EmailVerificationToken, error) {
+	emailVerificationTokenObj := &EmailVerificationToken{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"email_verification_tokens\" where \"token\"=$1", sel,
+	)
+
+	q := queries.Raw(query, token)
+
+	err := q.Bind(ctx, exec, emailVerificationTokenObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from email_verification_tokens")
+	}
+
+	return emailVerificationTokenObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *EmailVerificationToken) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no email_verification_tokens provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(emailVerificationTokenColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	emailVerificationTokenInsertCacheMut.RLock()
+	cache, cached := emailVerificationTokenInsertCache[key]
+	emailVerificationTokenInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			emailVerificationTokenAllColumns,
+			emailVerificationTokenColumnsWithDefault,
+			emailVerificationTokenColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(emailVerificationTokenType, emailVerificationTokenMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(emailVerificationTokenType, emailVerificationTokenMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"email_verification_tokens\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"email_verification_tokens\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into email_verification_tokens")
+	}
+
+	if !cached {
+		emailVerificationTokenInsertCacheMut.Lock()
+		emailVerificationTokenInsertCache[key] = cache
+		emailVerificationTokenInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the EmailVerificationToken.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *EmailVerificationToken) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	key := makeCacheKey(columns, nil)
+	emailVerificationTokenUpdateCacheMut.RLock()
+	cache, cached := emailVerificationTokenUpdateCache[key]
+	emailVerificationTokenUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			emailVerificationTokenAllColumns,
+			emailVerificationTokenPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update email_verification_tokens, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"email_verification_tokens\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, emailVerificationTokenPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(emailVerificationTokenType, emailVerificationTokenMapping, append(wl, emailVerificationTokenPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update email_verification_tokens row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for email_verification_tokens")
+	}
+
+	if !cached {
+		emailVerificationTokenUpdateCacheMut.Lock()
+		emailVerificationTokenUpdateCache[key] = cache
+		emailVerificationTokenUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q emailVerificationTokenQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for email_verification_tokens")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for email_verification_tokens")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o EmailVerificationTokenSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), emailVerificationTokenPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"email_verification_tokens\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, emailVerificationTokenPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in emailVerificationToken slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all emailVerificationToken")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *EmailVerificationToken) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no email_verification_tokens provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		o.UpdatedAt = currTime
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(emailVerificationTokenColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	emailVerificationTokenUpsertCacheMut.RLock()
+	cache, cached := emailVerificationTokenUpsertCache[key]
+	emailVerificationTokenUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			emailVerificationTokenAllColumns,
+			emailVerificationTokenColumnsWithDefault,
+			emailVerificationTokenColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			emailVerificationTokenAllColumns,
+			emailVerificationTokenPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert email_verification_tokens, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(emailVerificationTokenPrimaryKeyColumns))
+			copy(conflict, emailVerificationTokenPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"email_verification_tokens\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(emailVerificationTokenType, emailVerificationTokenMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(emailVerificationTokenType, emailVerificationTokenMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert email_verification_tokens")
+	}
+
+	if !cached {
+		emailVerificationTokenUpsertCacheMut.Lock()
+		emailVerificationTokenUpsertCache[key] = cache
+		emailVerificationTokenUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single EmailVerificationToken record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *EmailVerificationToken) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no EmailVerificationToken provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), emailVerificationTokenPrimaryKeyMapping)
+	sql := "DELETE FROM \"email_verification_tokens\" WHERE \"token\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from email_verification_tokens")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for email_verification_tokens")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q emailVerificationTokenQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no emailVerificationTokenQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from email_verification_tokens")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for email_verification_tokens")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o EmailVerificationTokenSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), emailVerificationTokenPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"email_verification_tokens\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, emailVerificationTokenPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from emailVerificationToken slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for email_verification_tokens")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *EmailVerificationToken) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindEmailVerificationToken(ctx, exec, o.Token)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *EmailVerificationTokenSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := EmailVerificationTokenSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), emailVerificationTokenPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"email_verification_tokens\".* FROM \"email_verification_tokens\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, emailVerificationTokenPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in EmailVerificationTokenSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// EmailVerificationTokenExists checks if the EmailVerificationToken row exists.
+func EmailVerificationTokenExists(ctx context.Context, exec boil.ContextExecutor, token string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"email_verification_tokens\" where \"token\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, token)
+	}
+	row := exec.QueryRowContext(ctx, sql, token)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if email_verification_tokens exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the EmailVerificationToken row exists.
+func (o *EmailVerificationToken) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return EmailVerificationTokenExists(ctx, exec, o.Token)
+}
@@ -0,0 +1,701 @@
+// Code generated by SQLBoiler 4.14.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testMfaRecoveryCodes(t *testing.T) {
+	t.Parallel()
+
+	query := MfaRecoveryCodes()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testMfaRecoveryCodesDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MfaRecoveryCode{}
+	if err = randomize.Struct(seed, o, mfaRecoveryCodeDBTypes, true, mfaRecoveryCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := MfaRecoveryCodes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testMfaRecoveryCodesQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MfaRecoveryCode{}
+	if err = randomize.Struct(seed, o, mfaRecoveryCodeDBTypes, true, mfaRecoveryCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := MfaRecoveryCodes().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := MfaRecoveryCodes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testMfaRecoveryCodesSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MfaRecoveryCode{}
+	if err = randomize.Struct(seed, o, mfaRecoveryCodeDBTypes, true, mfaRecoveryCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := MfaRecoveryCodeSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := MfaRecoveryCodes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testMfaRecoveryCodesExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MfaRecoveryCode{}
+	if err = randomize.Struct(seed, o, mfaRecoveryCodeDBTypes, true, mfaRecoveryCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := MfaRecoveryCodeExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if MfaRecoveryCode exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected MfaRecoveryCodeExists to return true, but got false.")
+	}
+}
+
+func testMfaRecoveryCodesFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MfaRecoveryCode{}
+	if err = randomize.Struct(seed, o, mfaRecoveryCodeDBTypes, true, mfaRecoveryCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	mfaRecoveryCodeFound, err := FindMfaRecoveryCode(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if mfaRecoveryCodeFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testMfaRecoveryCodesBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MfaRecoveryCode{}
+	if err = randomize.Struct(seed, o, mfaRecoveryCodeDBTypes, true, mfaRecoveryCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = MfaRecoveryCodes().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testMfaRecoveryCodesOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MfaRecoveryCode{}
+	if err = randomize.Struct(seed, o, mfaRecoveryCodeDBTypes, true, mfaRecoveryCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := MfaRecoveryCodes().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testMfaRecoveryCodesAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	mfaRecoveryCodeOne := &MfaRecoveryCode{}
+	mfaRecoveryCodeTwo := &MfaRecoveryCode{}
+	if err = randomize.Struct(seed, mfaRecoveryCodeOne, mfaRecoveryCodeDBTypes, false, mfaRecoveryCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+	if err = randomize.Struct(seed, mfaRecoveryCodeTwo, mfaRecoveryCodeDBTypes, false, mfaRecoveryCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = mfaRecoveryCodeOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = mfaRecoveryCodeTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := MfaRecoveryCodes().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testMfaRecoveryCodesCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	mfaRecoveryCodeOne := &MfaRecoveryCode{}
+	mfaRecoveryCodeTwo := &MfaRecoveryCode{}
+	if err = randomize.Struct(seed, mfaRecoveryCodeOne, mfaRecoveryCodeDBTypes, false, mfaRecoveryCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+	if err = randomize.Struct(seed, mfaRecoveryCodeTwo, mfaRecoveryCodeDBTypes, false, mfaRecoveryCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = mfaRecoveryCodeOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = mfaRecoveryCodeTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := MfaRecoveryCodes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func testMfaRecoveryCodesInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MfaRecoveryCode{}
+	if err = randomize.Struct(seed, o, mfaRecoveryCodeDBTypes, true, mfaRecoveryCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := MfaRecoveryCodes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testMfaRecoveryCodesInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MfaRecoveryCode{}
+	if err = randomize.Struct(seed, o, mfaRecoveryCodeDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(mfaRecoveryCodeColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := MfaRecoveryCodes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testMfaRecoveryCodeToOneUserUsingUser(t *testing.T) {
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var local MfaRecoveryCode
+	var foreign User
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &local, mfaRecoveryCodeDBTypes, false, mfaRecoveryCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &foreign, userDBTypes, false, userColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize User struct: %s", err)
+	}
+
+	if err := foreign.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	local.UserID = foreign.ID
+	if err := local.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.User().One(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check.ID != foreign.ID {
+		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
+	}
+
+	slice := MfaRecoveryCodeSlice{&local}
+	if err = local.L.LoadUser(ctx, tx, false, (*[]*MfaRecoveryCode)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.User == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.User = nil
+	if err = local.L.LoadUser(ctx, tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.User == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+}
+
+func testMfaRecoveryCodeToOneSetOpUserUsingUser(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a MfaRecoveryCode
+	var b, c User
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, mfaRecoveryCodeDBTypes, false, strmangle.SetComplement(mfaRecoveryCodePrimaryKeyColumns, mfaRecoveryCodeColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, userDBTypes, false, strmangle.SetComplement(userPrimaryKeyColumns, userColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, userDBTypes, false, strmangle.SetComplement(userPrimaryKeyColumns, userColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*User{&b, &c} {
+		err = a.SetUser(ctx, tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.User != x {
+			t.Error("relationship struct not set to correct value")
+		}
+
+		if x.R.MfaRecoveryCodes[0] != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+		if a.UserID != x.ID {
+			t.Error("foreign key was wrong value", a.UserID)
+		}
+
+		zero := reflect.Zero(reflect.TypeOf(a.UserID))
+		reflect.Indirect(reflect.ValueOf(&a.UserID)).Set(zero)
+
+		if err = a.Reload(ctx, tx); err != nil {
+			t.Fatal("failed to reload", err)
+		}
+
+		if a.UserID != x.ID {
+			t.Error("foreign key was wrong value", a.UserID, x.ID)
+		}
+	}
+}
+
+func testMfaRecoveryCodesReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MfaRecoveryCode{}
+	if err = randomize.Struct(seed, o, mfaRecoveryCodeDBTypes, true, mfaRecoveryCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testMfaRecoveryCodesReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MfaRecoveryCode{}
+	if err = randomize.Struct(seed, o, mfaRecoveryCodeDBTypes, true, mfaRecoveryCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := MfaRecoveryCodeSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testMfaRecoveryCodesSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MfaRecoveryCode{}
+	if err = randomize.Struct(seed, o, mfaRecoveryCodeDBTypes, true, mfaRecoveryCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := MfaRecoveryCodes().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	mfaRecoveryCodeDBTypes = map[string]string{`ID`: `uuid`, `UserID`: `uuid`, `CodeHash`: `text`, `UsedAt`: `timestamp with time zone`, `CreatedAt`: `timestamp with time zone`, `UpdatedAt`: `timestamp with time zone`}
+	_                      = bytes.MinRead
+)
+
+func testMfaRecoveryCodesUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(mfaRecoveryCodePrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(mfaRecoveryCodeAllColumns) == len(mfaRecoveryCodePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MfaRecoveryCode{}
+	if err = randomize.Struct(seed, o, mfaRecoveryCodeDBTypes, true, mfaRecoveryCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := MfaRecoveryCodes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, mfaRecoveryCodeDBTypes, true, mfaRecoveryCodePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testMfaRecoveryCodesSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(mfaRecoveryCodeAllColumns) == len(mfaRecoveryCodePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &MfaRecoveryCode{}
+	if err = randomize.Struct(seed, o, mfaRecoveryCodeDBTypes, true, mfaRecoveryCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := MfaRecoveryCodes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, mfaRecoveryCodeDBTypes, true, mfaRecoveryCodePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(mfaRecoveryCodeAllColumns, mfaRecoveryCodePrimaryKeyColumns) {
+		fields = mfaRecoveryCodeAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			mfaRecoveryCodeAllColumns,
+			mfaRecoveryCodePrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := MfaRecoveryCodeSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testMfaRecoveryCodesUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(mfaRecoveryCodeAllColumns) == len(mfaRecoveryCodePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := MfaRecoveryCode{}
+	if err = randomize.Struct(seed, &o, mfaRecoveryCodeDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert MfaRecoveryCode: %s", err)
+	}
+
+	count, err := MfaRecoveryCodes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, mfaRecoveryCodeDBTypes, false, mfaRecoveryCodePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize MfaRecoveryCode struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert MfaRecoveryCode: %s", err)
+	}
+
+	count, err = MfaRecoveryCodes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
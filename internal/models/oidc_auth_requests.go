@@ -0,0 +1,728 @@
+// Code generated by SQLBoiler 4.14.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// OidcAuthRequest is an object representing the database table.
+type OidcAuthRequest struct {
+	State        string    `boil:"state" json:"state" toml:"state" yaml:"state"`
+	Provider     string    `boil:"provider" json:"provider" toml:"provider" yaml:"provider"`
+	Nonce        string    `boil:"nonce" json:"nonce" toml:"nonce" yaml:"nonce"`
+	CodeVerifier string    `boil:"code_verifier" json:"code_verifier" toml:"code_verifier" yaml:"code_verifier"`
+	ValidUntil   time.Time `boil:"valid_until" json:"valid_until" toml:"valid_until" yaml:"valid_until"`
+	CreatedAt    time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt    time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+
+	R *oidcAuthRequestR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L oidcAuthRequestL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var OidcAuthRequestColumns = struct {
+	State        string
+	Provider     string
+	Nonce        string
+	CodeVerifier string
+	ValidUntil   string
+	CreatedAt    string
+	UpdatedAt    string
+}{
+	State:        "state",
+	Provider:     "provider",
+	Nonce:        "nonce",
+	CodeVerifier: "code_verifier",
+	ValidUntil:   "valid_until",
+	CreatedAt:    "created_at",
+	UpdatedAt:    "updated_at",
+}
+
+var OidcAuthRequestTableColumns = struct {
+	State        string
+	Provider     string
+	Nonce        string
+	CodeVerifier string
+	ValidUntil   string
+	CreatedAt    string
+	UpdatedAt    string
+}{
+	State:        "oidc_auth_requests.state",
+	Provider:     "oidc_auth_requests.provider",
+	Nonce:        "oidc_auth_requests.nonce",
+	CodeVerifier: "oidc_auth_requests.code_verifier",
+	ValidUntil:   "oidc_auth_requests.valid_until",
+	CreatedAt:    "oidc_auth_requests.created_at",
+	UpdatedAt:    "oidc_auth_requests.updated_at",
+}
+
+// Generated where
+
+var OidcAuthRequestWhere = struct {
+	State        whereHelperstring
+	Provider     whereHelperstring
+	Nonce        whereHelperstring
+	CodeVerifier whereHelperstring
+	ValidUntil   whereHelpertime_Time
+	CreatedAt    whereHelpertime_Time
+	UpdatedAt    whereHelpertime_Time
+}{
+	State:        whereHelperstring{field: "\"oidc_auth_requests\".\"state\""},
+	Provider:     whereHelperstring{field: "\"oidc_auth_requests\".\"provider\""},
+	Nonce:        whereHelperstring{field: "\"oidc_auth_requests\".\"nonce\""},
+	CodeVerifier: whereHelperstring{field: "\"oidc_auth_requests\".\"code_verifier\""},
+	ValidUntil:   whereHelpertime_Time{field: "\"oidc_auth_requests\".\"valid_until\""},
+	CreatedAt:    whereHelpertime_Time{field: "\"oidc_auth_requests\".\"created_at\""},
+	UpdatedAt:    whereHelpertime_Time{field: "\"oidc_auth_requests\".\"updated_at\""},
+}
+
+// OidcAuthRequestRels is where relationship names are stored.
+var OidcAuthRequestRels = struct {
+}{}
+
+// oidcAuthRequestR is where relationships are stored.
+type oidcAuthRequestR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*oidcAuthRequestR) NewStruct() *oidcAuthRequestR {
+	return &oidcAuthRequestR{}
+}
+
+// oidcAuthRequestL is where Load methods for each relationship are stored.
+type oidcAuthRequestL struct{}
+
+var (
+	oidcAuthRequestAllColumns            = []string{"state", "provider", "nonce", "code_verifier", "valid_until", "created_at", "updated_at"}
+	oidcAuthRequestColumnsWithoutDefault = []string{"state", "provider", "nonce", "code_verifier", "valid_until", "created_at", "updated_at"}
+	oidcAuthRequestColumnsWithDefault    = []string{}
+	oidcAuthRequestPrimaryKeyColumns     = []string{"state"}
+	oidcAuthRequestGeneratedColumns      = []string{}
+)
+
+type (
+	// OidcAuthRequestSlice is an alias for a slice of pointers to OidcAuthRequest.
+	// This should almost always be used instead of []OidcAuthRequest.
+	OidcAuthRequestSlice []*OidcAuthRequest
+
+	oidcAuthRequestQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	oidcAuthRequestType                 = reflect.TypeOf(&OidcAuthRequest{})
+	oidcAuthRequestMapping              = queries.MakeStructMapping(oidcAuthRequestType)
+	oidcAuthRequestPrimaryKeyMapping, _ = queries.BindMapping(oidcAuthRequestType, oidcAuthRequestMapping, oidcAuthRequestPrimaryKeyColumns)
+	oidcAuthRequestInsertCacheMut       sync.RWMutex
+	oidcAuthRequestInsertCache          = make(map[string]insertCache)
+	oidcAuthRequestUpdateCacheMut       sync.RWMutex
+	oidcAuthRequestUpdateCache          = make(map[string]updateCache)
+	oidcAuthRequestUpsertCacheMut       sync.RWMutex
+	oidcAuthRequestUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single oidcAuthRequest record from the query.
+func (q oidcAuthRequestQuery) One(ctx context.Context, exec boil.ContextExecutor) (*OidcAuthRequest, error) {
+	o := &OidcAuthRequest{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for oidc_auth_requests")
+	}
+
+	return o, nil
+}
+
+// All returns all OidcAuthRequest records from the query.
+func (q oidcAuthRequestQuery) All(ctx context.Context, exec boil.ContextExecutor) (OidcAuthRequestSlice, error) {
+	var o []*OidcAuthRequest
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to OidcAuthRequest slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all OidcAuthRequest records in the query.
+func (q oidcAuthRequestQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count oidc_auth_requests rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q oidcAuthRequestQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if oidc_auth_requests exists")
+	}
+
+	return count > 0, nil
+}
+
+// OidcAuthRequests retrieves all the records using an executor.
+func OidcAuthRequests(mods ...qm.QueryMod) oidcAuthRequestQuery {
+	mods = append(mods, qm.From("\"oidc_auth_requests\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"oidc_auth_requests\".*"})
+	}
+
+	return oidcAuthRequestQuery{q}
+}
+
+// FindOidcAuthRequest retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindOidcAuthRequest(ctx context.Context, exec boil.ContextExecutor, state string, selectCols ...string) (*OidcAuthRequest, error) {
+	oidcAuthRequestObj := &OidcAuthRequest{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"oidc_auth_requests\" where \"state\"=$1", sel,
+	)
+
+	q := queries.Raw(query, state)
+
+	err := q.Bind(ctx, exec, oidcAuthRequestObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from oidc_auth_requests")
+	}
+
+	return oidcAuthRequestObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *OidcAuthRequest) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no oidc_auth_requests provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(oidcAuthRequestColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	oidcAuthRequestInsertCacheMut.RLock()
+	cache, cached := oidcAuthRequestInsertCache[key]
+	oidcAuthRequestInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			oidcAuthRequestAllColumns,
+			oidcAuthRequestColumnsWithDefault,
+			oidcAuthRequestColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(oidcAuthRequestType, oidcAuthRequestMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(oidcAuthRequestType, oidcAuthRequestMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"oidc_auth_requests\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"oidc_auth_requests\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into oidc_auth_requests")
+	}
+
+	if !cached {
+		oidcAuthRequestInsertCacheMut.Lock()
+		oidcAuthRequestInsertCache[key] = cache
+		oidcAuthRequestInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the OidcAuthRequest.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *OidcAuthRequest) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	key := makeCacheKey(columns, nil)
+	oidcAuthRequestUpdateCacheMut.RLock()
+	cache, cached := oidcAuthRequestUpdateCache[key]
+	oidcAuthRequestUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			oidcAuthRequestAllColumns,
+			oidcAuthRequestPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update oidc_auth_requests, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"oidc_auth_requests\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, oidcAuthRequestPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(oidcAuthRequestType, oidcAuthRequestMapping, append(wl, oidcAuthRequestPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update oidc_auth_requests row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for oidc_auth_requests")
+	}
+
+	if !cached {
+		oidcAuthRequestUpdateCacheMut.Lock()
+		oidcAuthRequestUpdateCache[key] = cache
+		oidcAuthRequestUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q oidcAuthRequestQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for oidc_auth_requests")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for oidc_auth_requests")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o OidcAuthRequestSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), oidcAuthRequestPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"oidc_auth_requests\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, oidcAuthRequestPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in oidcAuthRequest slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all oidcAuthRequest")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *OidcAuthRequest) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no oidc_auth_requests provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		o.UpdatedAt = currTime
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(oidcAuthRequestColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	oidcAuthRequestUpsertCacheMut.RLock()
+	cache, cached := oidcAuthRequestUpsertCache[key]
+	oidcAuthRequestUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			oidcAuthRequestAllColumns,
+			oidcAuthRequestColumnsWithDefault,
+			oidcAuthRequestColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			oidcAuthRequestAllColumns,
+			oidcAuthRequestPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert oidc_auth_requests, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(oidcAuthRequestPrimaryKeyColumns))
+			copy(conflict, oidcAuthRequestPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"oidc_auth_requests\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(oidcAuthRequestType, oidcAuthRequestMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(oidcAuthRequestType, oidcAuthRequestMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert oidc_auth_requests")
+	}
+
+	if !cached {
+		oidcAuthRequestUpsertCacheMut.Lock()
+		oidcAuthRequestUpsertCache[key] = cache
+		oidcAuthRequestUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single OidcAuthRequest record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *OidcAuthRequest) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no OidcAuthRequest provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), oidcAuthRequestPrimaryKeyMapping)
+	sql := "DELETE FROM \"oidc_auth_requests\" WHERE \"state\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from oidc_auth_requests")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for oidc_auth_requests")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q oidcAuthRequestQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no oidcAuthRequestQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from oidc_auth_requests")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for oidc_auth_requests")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o OidcAuthRequestSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), oidcAuthRequestPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"oidc_auth_requests\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, oidcAuthRequestPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from oidcAuthRequest slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for oidc_auth_requests")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *OidcAuthRequest) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindOidcAuthRequest(ctx, exec, o.State)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *OidcAuthRequestSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := OidcAuthRequestSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), oidcAuthRequestPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"oidc_auth_requests\".* FROM \"oidc_auth_requests\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, oidcAuthRequestPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in OidcAuthRequestSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// OidcAuthRequestExists checks if the OidcAuthRequest row exists.
+func OidcAuthRequestExists(ctx context.Context, exec boil.ContextExecutor, state string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"oidc_auth_requests\" where \"state\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, state)
+	}
+	row := exec.QueryRowContext(ctx, sql, state)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if oidc_auth_requests exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the OidcAuthRequest row exists.
+func (o *OidcAuthRequest) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return OidcAuthRequestExists(ctx, exec, o.State)
+}
@@ -0,0 +1,591 @@
+// Code generated by SQLBoiler 4.14.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testOidcAuthRequests(t *testing.T) {
+	t.Parallel()
+
+	query := OidcAuthRequests()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testOidcAuthRequestsDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OidcAuthRequest{}
+	if err = randomize.Struct(seed, o, oidcAuthRequestDBTypes, true, oidcAuthRequestColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := OidcAuthRequests().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testOidcAuthRequestsQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OidcAuthRequest{}
+	if err = randomize.Struct(seed, o, oidcAuthRequestDBTypes, true, oidcAuthRequestColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := OidcAuthRequests().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := OidcAuthRequests().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testOidcAuthRequestsSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OidcAuthRequest{}
+	if err = randomize.Struct(seed, o, oidcAuthRequestDBTypes, true, oidcAuthRequestColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := OidcAuthRequestSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := OidcAuthRequests().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testOidcAuthRequestsExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OidcAuthRequest{}
+	if err = randomize.Struct(seed, o, oidcAuthRequestDBTypes, true, oidcAuthRequestColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := OidcAuthRequestExists(ctx, tx, o.State)
+	if err != nil {
+		t.Errorf("Unable to check if OidcAuthRequest exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected OidcAuthRequestExists to return true, but got false.")
+	}
+}
+
+func testOidcAuthRequestsFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OidcAuthRequest{}
+	if err = randomize.Struct(seed, o, oidcAuthRequestDBTypes, true, oidcAuthRequestColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	oidcAuthRequestFound, err := FindOidcAuthRequest(ctx, tx, o.State)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if oidcAuthRequestFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testOidcAuthRequestsBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OidcAuthRequest{}
+	if err = randomize.Struct(seed, o, oidcAuthRequestDBTypes, true, oidcAuthRequestColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = OidcAuthRequests().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testOidcAuthRequestsOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OidcAuthRequest{}
+	if err = randomize.Struct(seed, o, oidcAuthRequestDBTypes, true, oidcAuthRequestColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := OidcAuthRequests().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testOidcAuthRequestsAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	oidcAuthRequestOne := &OidcAuthRequest{}
+	oidcAuthRequestTwo := &OidcAuthRequest{}
+	if err = randomize.Struct(seed, oidcAuthRequestOne, oidcAuthRequestDBTypes, false, oidcAuthRequestColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+	if err = randomize.Struct(seed, oidcAuthRequestTwo, oidcAuthRequestDBTypes, false, oidcAuthRequestColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = oidcAuthRequestOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = oidcAuthRequestTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := OidcAuthRequests().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testOidcAuthRequestsCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	oidcAuthRequestOne := &OidcAuthRequest{}
+	oidcAuthRequestTwo := &OidcAuthRequest{}
+	if err = randomize.Struct(seed, oidcAuthRequestOne, oidcAuthRequestDBTypes, false, oidcAuthRequestColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+	if err = randomize.Struct(seed, oidcAuthRequestTwo, oidcAuthRequestDBTypes, false, oidcAuthRequestColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = oidcAuthRequestOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = oidcAuthRequestTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := OidcAuthRequests().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func testOidcAuthRequestsInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OidcAuthRequest{}
+	if err = randomize.Struct(seed, o, oidcAuthRequestDBTypes, true, oidcAuthRequestColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := OidcAuthRequests().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testOidcAuthRequestsInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OidcAuthRequest{}
+	if err = randomize.Struct(seed, o, oidcAuthRequestDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(oidcAuthRequestColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := OidcAuthRequests().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testOidcAuthRequestsReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OidcAuthRequest{}
+	if err = randomize.Struct(seed, o, oidcAuthRequestDBTypes, true, oidcAuthRequestColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testOidcAuthRequestsReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OidcAuthRequest{}
+	if err = randomize.Struct(seed, o, oidcAuthRequestDBTypes, true, oidcAuthRequestColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := OidcAuthRequestSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testOidcAuthRequestsSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OidcAuthRequest{}
+	if err = randomize.Struct(seed, o, oidcAuthRequestDBTypes, true, oidcAuthRequestColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := OidcAuthRequests().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	oidcAuthRequestDBTypes = map[string]string{`State`: `text`, `Provider`: `character varying`, `Nonce`: `text`, `CodeVerifier`: `text`, `ValidUntil`: `timestamp with time zone`, `CreatedAt`: `timestamp with time zone`, `UpdatedAt`: `timestamp with time zone`}
+	_                      = bytes.MinRead
+)
+
+func testOidcAuthRequestsUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(oidcAuthRequestPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(oidcAuthRequestAllColumns) == len(oidcAuthRequestPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OidcAuthRequest{}
+	if err = randomize.Struct(seed, o, oidcAuthRequestDBTypes, true, oidcAuthRequestColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := OidcAuthRequests().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, oidcAuthRequestDBTypes, true, oidcAuthRequestPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testOidcAuthRequestsSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(oidcAuthRequestAllColumns) == len(oidcAuthRequestPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OidcAuthRequest{}
+	if err = randomize.Struct(seed, o, oidcAuthRequestDBTypes, true, oidcAuthRequestColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := OidcAuthRequests().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, oidcAuthRequestDBTypes, true, oidcAuthRequestPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(oidcAuthRequestAllColumns, oidcAuthRequestPrimaryKeyColumns) {
+		fields = oidcAuthRequestAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			oidcAuthRequestAllColumns,
+			oidcAuthRequestPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := OidcAuthRequestSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testOidcAuthRequestsUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(oidcAuthRequestAllColumns) == len(oidcAuthRequestPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := OidcAuthRequest{}
+	if err = randomize.Struct(seed, &o, oidcAuthRequestDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert OidcAuthRequest: %s", err)
+	}
+
+	count, err := OidcAuthRequests().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, oidcAuthRequestDBTypes, false, oidcAuthRequestPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize OidcAuthRequest struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert OidcAuthRequest: %s", err)
+	}
+
+	count, err = OidcAuthRequests().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
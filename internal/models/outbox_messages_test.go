@@ -0,0 +1,591 @@
+// Code generated by SQLBoiler 4.14.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testOutboxMessages(t *testing.T) {
+	t.Parallel()
+
+	query := OutboxMessages()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testOutboxMessagesDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OutboxMessage{}
+	if err = randomize.Struct(seed, o, outboxMessageDBTypes, true, outboxMessageColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := OutboxMessages().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testOutboxMessagesQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OutboxMessage{}
+	if err = randomize.Struct(seed, o, outboxMessageDBTypes, true, outboxMessageColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := OutboxMessages().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := OutboxMessages().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testOutboxMessagesSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OutboxMessage{}
+	if err = randomize.Struct(seed, o, outboxMessageDBTypes, true, outboxMessageColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := OutboxMessageSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := OutboxMessages().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testOutboxMessagesExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OutboxMessage{}
+	if err = randomize.Struct(seed, o, outboxMessageDBTypes, true, outboxMessageColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := OutboxMessageExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if OutboxMessage exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected OutboxMessageExists to return true, but got false.")
+	}
+}
+
+func testOutboxMessagesFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OutboxMessage{}
+	if err = randomize.Struct(seed, o, outboxMessageDBTypes, true, outboxMessageColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	outboxMessageFound, err := FindOutboxMessage(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if outboxMessageFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testOutboxMessagesBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OutboxMessage{}
+	if err = randomize.Struct(seed, o, outboxMessageDBTypes, true, outboxMessageColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = OutboxMessages().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testOutboxMessagesOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OutboxMessage{}
+	if err = randomize.Struct(seed, o, outboxMessageDBTypes, true, outboxMessageColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := OutboxMessages().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testOutboxMessagesAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	outboxMessageOne := &OutboxMessage{}
+	outboxMessageTwo := &OutboxMessage{}
+	if err = randomize.Struct(seed, outboxMessageOne, outboxMessageDBTypes, false, outboxMessageColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+	if err = randomize.Struct(seed, outboxMessageTwo, outboxMessageDBTypes, false, outboxMessageColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = outboxMessageOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = outboxMessageTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := OutboxMessages().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testOutboxMessagesCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	outboxMessageOne := &OutboxMessage{}
+	outboxMessageTwo := &OutboxMessage{}
+	if err = randomize.Struct(seed, outboxMessageOne, outboxMessageDBTypes, false, outboxMessageColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+	if err = randomize.Struct(seed, outboxMessageTwo, outboxMessageDBTypes, false, outboxMessageColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = outboxMessageOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = outboxMessageTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := OutboxMessages().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func testOutboxMessagesInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OutboxMessage{}
+	if err = randomize.Struct(seed, o, outboxMessageDBTypes, true, outboxMessageColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := OutboxMessages().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testOutboxMessagesInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OutboxMessage{}
+	if err = randomize.Struct(seed, o, outboxMessageDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(outboxMessageColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := OutboxMessages().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testOutboxMessagesReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OutboxMessage{}
+	if err = randomize.Struct(seed, o, outboxMessageDBTypes, true, outboxMessageColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testOutboxMessagesReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OutboxMessage{}
+	if err = randomize.Struct(seed, o, outboxMessageDBTypes, true, outboxMessageColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := OutboxMessageSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testOutboxMessagesSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OutboxMessage{}
+	if err = randomize.Struct(seed, o, outboxMessageDBTypes, true, outboxMessageColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := OutboxMessages().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	outboxMessageDBTypes = map[string]string{`ID`: `bigint`, `Topic`: `character varying`, `AggregateKey`: `character varying`, `Payload`: `jsonb`, `Attempts`: `integer`, `NextAttemptAt`: `timestamp with time zone`, `LastError`: `text`, `CreatedAt`: `timestamp with time zone`, `UpdatedAt`: `timestamp with time zone`}
+	_                    = bytes.MinRead
+)
+
+func testOutboxMessagesUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(outboxMessagePrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(outboxMessageAllColumns) == len(outboxMessagePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OutboxMessage{}
+	if err = randomize.Struct(seed, o, outboxMessageDBTypes, true, outboxMessageColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := OutboxMessages().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, outboxMessageDBTypes, true, outboxMessagePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testOutboxMessagesSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(outboxMessageAllColumns) == len(outboxMessagePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &OutboxMessage{}
+	if err = randomize.Struct(seed, o, outboxMessageDBTypes, true, outboxMessageColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := OutboxMessages().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, outboxMessageDBTypes, true, outboxMessagePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(outboxMessageAllColumns, outboxMessagePrimaryKeyColumns) {
+		fields = outboxMessageAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			outboxMessageAllColumns,
+			outboxMessagePrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := OutboxMessageSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testOutboxMessagesUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(outboxMessageAllColumns) == len(outboxMessagePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := OutboxMessage{}
+	if err = randomize.Struct(seed, &o, outboxMessageDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert OutboxMessage: %s", err)
+	}
+
+	count, err := OutboxMessages().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, outboxMessageDBTypes, false, outboxMessagePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize OutboxMessage struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert OutboxMessage: %s", err)
+	}
+
+	count, err = OutboxMessages().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
@@ -8,13 +8,35 @@ import "testing"
 func TestUpsert(t *testing.T) {
 	t.Run("AccessTokens", testAccessTokensUpsert)
 
+	t.Run("APIKeys", testAPIKeysUpsert)
+
 	t.Run("AppUserProfiles", testAppUserProfilesUpsert)
 
+	t.Run("AuthAttempts", testAuthAttemptsUpsert)
+
+	t.Run("EmailVerificationTokens", testEmailVerificationTokensUpsert)
+
+	t.Run("Identities", testIdentitiesUpsert)
+
+	t.Run("Jobs", testJobsUpsert)
+
+	t.Run("MfaRecoveryCodes", testMfaRecoveryCodesUpsert)
+
+	t.Run("MfaTokens", testMfaTokensUpsert)
+
+	t.Run("OidcAuthRequests", testOidcAuthRequestsUpsert)
+
+	t.Run("OutboxMessages", testOutboxMessagesUpsert)
+
 	t.Run("PasswordResetTokens", testPasswordResetTokensUpsert)
 
 	t.Run("PushTokens", testPushTokensUpsert)
 
 	t.Run("RefreshTokens", testRefreshTokensUpsert)
 
+	t.Run("ScheduledTasks", testScheduledTasksUpsert)
+
+	t.Run("UserTotps", testUserTotpsUpsert)
+
 	t.Run("Users", testUsersUpsert)
 }
@@ -0,0 +1,767 @@
+// Code generated by SQLBoiler 4.14.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// ScheduledTask is an object representing the database table.
+type ScheduledTask struct {
+	Name            string      `boil:"name" json:"name" toml:"name" yaml:"name"`
+	LastRunAt       null.Time   `boil:"last_run_at" json:"last_run_at,omitempty" toml:"last_run_at" yaml:"last_run_at,omitempty"`
+	LastDurationMS  null.Int64  `boil:"last_duration_ms" json:"last_duration_ms,omitempty" toml:"last_duration_ms" yaml:"last_duration_ms,omitempty"`
+	LastError       null.String `boil:"last_error" json:"last_error,omitempty" toml:"last_error" yaml:"last_error,omitempty"`
+	LastSucceededAt null.Time   `boil:"last_succeeded_at" json:"last_succeeded_at,omitempty" toml:"last_succeeded_at" yaml:"last_succeeded_at,omitempty"`
+	CreatedAt       time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt       time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+
+	R *scheduledTaskR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L scheduledTaskL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ScheduledTaskColumns = struct {
+	Name            string
+	LastRunAt       string
+	LastDurationMS  string
+	LastError       string
+	LastSucceededAt string
+	CreatedAt       string
+	UpdatedAt       string
+}{
+	Name:            "name",
+	LastRunAt:       "last_run_at",
+	LastDurationMS:  "last_duration_ms",
+	LastError:       "last_error",
+	LastSucceededAt: "last_succeeded_at",
+	CreatedAt:       "created_at",
+	UpdatedAt:       "updated_at",
+}
+
+var ScheduledTaskTableColumns = struct {
+	Name            string
+	LastRunAt       string
+	LastDurationMS  string
+	LastError       string
+	LastSucceededAt string
+	CreatedAt       string
+	UpdatedAt       string
+}{
+	Name:            "scheduled_tasks.name",
+	LastRunAt:       "scheduled_tasks.last_run_at",
+	LastDurationMS:  "scheduled_tasks.last_duration_ms",
+	LastError:       "scheduled_tasks.last_error",
+	LastSucceededAt: "scheduled_tasks.last_succeeded_at",
+	CreatedAt:       "scheduled_tasks.created_at",
+	UpdatedAt:       "scheduled_tasks.updated_at",
+}
+
+// Generated where
+
+type whereHelpernull_Int64 struct{ field string }
+
+func (w whereHelpernull_Int64) EQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int64) NEQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int64) LT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int64) LTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int64) GT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int64) GTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_Int64) IN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Int64) NIN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+var ScheduledTaskWhere = struct {
+	Name            whereHelperstring
+	LastRunAt       whereHelpernull_Time
+	LastDurationMS  whereHelpernull_Int64
+	LastError       whereHelpernull_String
+	LastSucceededAt whereHelpernull_Time
+	CreatedAt       whereHelpertime_Time
+	UpdatedAt       whereHelpertime_Time
+}{
+	Name:            whereHelperstring{field: "\"scheduled_tasks\".\"name\""},
+	LastRunAt:       whereHelpernull_Time{field: "\"scheduled_tasks\".\"last_run_at\""},
+	LastDurationMS:  whereHelpernull_Int64{field: "\"scheduled_tasks\".\"last_duration_ms\""},
+	LastError:       whereHelpernull_String{field: "\"scheduled_tasks\".\"last_error\""},
+	LastSucceededAt: whereHelpernull_Time{field: "\"scheduled_tasks\".\"last_succeeded_at\""},
+	CreatedAt:       whereHelpertime_Time{field: "\"scheduled_tasks\".\"created_at\""},
+	UpdatedAt:       whereHelpertime_Time{field: "\"scheduled_tasks\".\"updated_at\""},
+}
+
+// ScheduledTaskRels is where relationship names are stored.
+var ScheduledTaskRels = struct {
+}{}
+
+// scheduledTaskR is where relationships are stored.
+type scheduledTaskR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*scheduledTaskR) NewStruct() *scheduledTaskR {
+	return &scheduledTaskR{}
+}
+
+// scheduledTaskL is where Load methods for each relationship are stored.
+type scheduledTaskL struct{}
+
+var (
+	scheduledTaskAllColumns            = []string{"name", "last_run_at", "last_duration_ms", "last_error", "last_succeeded_at", "created_at", "updated_at"}
+	scheduledTaskColumnsWithoutDefault = []string{"name", "created_at", "updated_at"}
+	scheduledTaskColumnsWithDefault    = []string{"last_run_at", "last_duration_ms", "last_error", "last_succeeded_at"}
+	scheduledTaskPrimaryKeyColumns     = []string{"name"}
+	scheduledTaskGeneratedColumns      = []string{}
+)
+
+type (
+	// ScheduledTaskSlice is an alias for a slice of pointers to ScheduledTask.
+	// This should almost always be used instead of []ScheduledTask.
+	ScheduledTaskSlice []*ScheduledTask
+
+	scheduledTaskQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	scheduledTaskType                 = reflect.TypeOf(&ScheduledTask{})
+	scheduledTaskMapping              = queries.MakeStructMapping(scheduledTaskType)
+	scheduledTaskPrimaryKeyMapping, _ = queries.BindMapping(scheduledTaskType, scheduledTaskMapping, scheduledTaskPrimaryKeyColumns)
+	scheduledTaskInsertCacheMut       sync.RWMutex
+	scheduledTaskInsertCache          = make(map[string]insertCache)
+	scheduledTaskUpdateCacheMut       sync.RWMutex
+	scheduledTaskUpdateCache          = make(map[string]updateCache)
+	scheduledTaskUpsertCacheMut       sync.RWMutex
+	scheduledTaskUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single scheduledTask record from the query.
+func (q scheduledTaskQuery) One(ctx context.Context, exec boil.ContextExecutor) (*ScheduledTask, error) {
+	o := &ScheduledTask{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for scheduled_tasks")
+	}
+
+	return o, nil
+}
+
+// All returns all ScheduledTask records from the query.
+func (q scheduledTaskQuery) All(ctx context.Context, exec boil.ContextExecutor) (ScheduledTaskSlice, error) {
+	var o []*ScheduledTask
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to ScheduledTask slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all ScheduledTask records in the query.
+func (q scheduledTaskQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count scheduled_tasks rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q scheduledTaskQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if scheduled_tasks exists")
+	}
+
+	return count > 0, nil
+}
+
+// ScheduledTasks retrieves all the records using an executor.
+func ScheduledTasks(mods ...qm.QueryMod) scheduledTaskQuery {
+	mods = append(mods, qm.From("\"scheduled_tasks\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"scheduled_tasks\".*"})
+	}
+
+	return scheduledTaskQuery{q}
+}
+
+// FindScheduledTask retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindScheduledTask(ctx context.Context, exec boil.ContextExecutor, name string, selectCols ...string) (*ScheduledTask, error) {
+	scheduledTaskObj := &ScheduledTask{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"scheduled_tasks\" where \"name\"=$1", sel,
+	)
+
+	q := queries.Raw(query, name)
+
+	err := q.Bind(ctx, exec, scheduledTaskObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from scheduled_tasks")
+	}
+
+	return scheduledTaskObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *ScheduledTask) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no scheduled_tasks provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(scheduledTaskColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	scheduledTaskInsertCacheMut.RLock()
+	cache, cached := scheduledTaskInsertCache[key]
+	scheduledTaskInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			scheduledTaskAllColumns,
+			scheduledTaskColumnsWithDefault,
+			scheduledTaskColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(scheduledTaskType, scheduledTaskMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(scheduledTaskType, scheduledTaskMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"scheduled_tasks\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"scheduled_tasks\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into scheduled_tasks")
+	}
+
+	if !cached {
+		scheduledTaskInsertCacheMut.Lock()
+		scheduledTaskInsertCache[key] = cache
+		scheduledTaskInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the ScheduledTask.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *ScheduledTask) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	key := makeCacheKey(columns, nil)
+	scheduledTaskUpdateCacheMut.RLock()
+	cache, cached := scheduledTaskUpdateCache[key]
+	scheduledTaskUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			scheduledTaskAllColumns,
+			scheduledTaskPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update scheduled_tasks, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"scheduled_tasks\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, scheduledTaskPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(scheduledTaskType, scheduledTaskMapping, append(wl, scheduledTaskPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update scheduled_tasks row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for scheduled_tasks")
+	}
+
+	if !cached {
+		scheduledTaskUpdateCacheMut.Lock()
+		scheduledTaskUpdateCache[key] = cache
+		scheduledTaskUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q scheduledTaskQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for scheduled_tasks")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for scheduled_tasks")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ScheduledTaskSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), scheduledTaskPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"scheduled_tasks\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, scheduledTaskPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in scheduledTask slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all scheduledTask")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *ScheduledTask) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no scheduled_tasks provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		o.UpdatedAt = currTime
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(scheduledTaskColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	scheduledTaskUpsertCacheMut.RLock()
+	cache, cached := scheduledTaskUpsertCache[key]
+	scheduledTaskUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			scheduledTaskAllColumns,
+			scheduledTaskColumnsWithDefault,
+			scheduledTaskColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			scheduledTaskAllColumns,
+			scheduledTaskPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert scheduled_tasks, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(scheduledTaskPrimaryKeyColumns))
+			copy(conflict, scheduledTaskPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"scheduled_tasks\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(scheduledTaskType, scheduledTaskMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(scheduledTaskType, scheduledTaskMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert scheduled_tasks")
+	}
+
+	if !cached {
+		scheduledTaskUpsertCacheMut.Lock()
+		scheduledTaskUpsertCache[key] = cache
+		scheduledTaskUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single ScheduledTask record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *ScheduledTask) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no ScheduledTask provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), scheduledTaskPrimaryKeyMapping)
+	sql := "DELETE FROM \"scheduled_tasks\" WHERE \"name\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from scheduled_tasks")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for scheduled_tasks")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q scheduledTaskQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no scheduledTaskQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from scheduled_tasks")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for scheduled_tasks")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ScheduledTaskSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), scheduledTaskPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"scheduled_tasks\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, scheduledTaskPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from scheduledTask slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for scheduled_tasks")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *ScheduledTask) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindScheduledTask(ctx, exec, o.Name)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ScheduledTaskSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ScheduledTaskSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), scheduledTaskPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"scheduled_tasks\".* FROM \"scheduled_tasks\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, scheduledTaskPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in ScheduledTaskSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ScheduledTaskExists checks if the ScheduledTask row exists.
+func ScheduledTaskExists(ctx context.Context, exec boil.ContextExecutor, name string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"scheduled_tasks\" where \"name\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, name)
+	}
+	row := exec.QueryRowContext(ctx, sql, name)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if scheduled_tasks exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the ScheduledTask row exists.
+func (o *ScheduledTask) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return ScheduledTaskExists(ctx, exec, o.Name)
+}
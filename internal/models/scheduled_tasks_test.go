@@ -0,0 +1,591 @@
+// Code generated by SQLBoiler 4.14.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testScheduledTasks(t *testing.T) {
+	t.Parallel()
+
+	query := ScheduledTasks()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testScheduledTasksDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ScheduledTask{}
+	if err = randomize.Struct(seed, o, scheduledTaskDBTypes, true, scheduledTaskColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := ScheduledTasks().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testScheduledTasksQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ScheduledTask{}
+	if err = randomize.Struct(seed, o, scheduledTaskDBTypes, true, scheduledTaskColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := ScheduledTasks().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := ScheduledTasks().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testScheduledTasksSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ScheduledTask{}
+	if err = randomize.Struct(seed, o, scheduledTaskDBTypes, true, scheduledTaskColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := ScheduledTaskSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := ScheduledTasks().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testScheduledTasksExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ScheduledTask{}
+	if err = randomize.Struct(seed, o, scheduledTaskDBTypes, true, scheduledTaskColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := ScheduledTaskExists(ctx, tx, o.Name)
+	if err != nil {
+		t.Errorf("Unable to check if ScheduledTask exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected ScheduledTaskExists to return true, but got false.")
+	}
+}
+
+func testScheduledTasksFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ScheduledTask{}
+	if err = randomize.Struct(seed, o, scheduledTaskDBTypes, true, scheduledTaskColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	scheduledTaskFound, err := FindScheduledTask(ctx, tx, o.Name)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if scheduledTaskFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testScheduledTasksBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ScheduledTask{}
+	if err = randomize.Struct(seed, o, scheduledTaskDBTypes, true, scheduledTaskColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = ScheduledTasks().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testScheduledTasksOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ScheduledTask{}
+	if err = randomize.Struct(seed, o, scheduledTaskDBTypes, true, scheduledTaskColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := ScheduledTasks().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testScheduledTasksAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	scheduledTaskOne := &ScheduledTask{}
+	scheduledTaskTwo := &ScheduledTask{}
+	if err = randomize.Struct(seed, scheduledTaskOne, scheduledTaskDBTypes, false, scheduledTaskColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+	if err = randomize.Struct(seed, scheduledTaskTwo, scheduledTaskDBTypes, false, scheduledTaskColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = scheduledTaskOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = scheduledTaskTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := ScheduledTasks().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testScheduledTasksCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	scheduledTaskOne := &ScheduledTask{}
+	scheduledTaskTwo := &ScheduledTask{}
+	if err = randomize.Struct(seed, scheduledTaskOne, scheduledTaskDBTypes, false, scheduledTaskColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+	if err = randomize.Struct(seed, scheduledTaskTwo, scheduledTaskDBTypes, false, scheduledTaskColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = scheduledTaskOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = scheduledTaskTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ScheduledTasks().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func testScheduledTasksInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ScheduledTask{}
+	if err = randomize.Struct(seed, o, scheduledTaskDBTypes, true, scheduledTaskColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ScheduledTasks().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testScheduledTasksInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ScheduledTask{}
+	if err = randomize.Struct(seed, o, scheduledTaskDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(scheduledTaskColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ScheduledTasks().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testScheduledTasksReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ScheduledTask{}
+	if err = randomize.Struct(seed, o, scheduledTaskDBTypes, true, scheduledTaskColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testScheduledTasksReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ScheduledTask{}
+	if err = randomize.Struct(seed, o, scheduledTaskDBTypes, true, scheduledTaskColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := ScheduledTaskSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testScheduledTasksSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ScheduledTask{}
+	if err = randomize.Struct(seed, o, scheduledTaskDBTypes, true, scheduledTaskColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := ScheduledTasks().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	scheduledTaskDBTypes = map[string]string{`Name`: `character varying`, `LastRunAt`: `timestamp with time zone`, `LastDurationMS`: `bigint`, `LastError`: `text`, `LastSucceededAt`: `timestamp with time zone`, `CreatedAt`: `timestamp with time zone`, `UpdatedAt`: `timestamp with time zone`}
+	_                    = bytes.MinRead
+)
+
+func testScheduledTasksUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(scheduledTaskPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(scheduledTaskAllColumns) == len(scheduledTaskPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ScheduledTask{}
+	if err = randomize.Struct(seed, o, scheduledTaskDBTypes, true, scheduledTaskColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ScheduledTasks().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, scheduledTaskDBTypes, true, scheduledTaskPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testScheduledTasksSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(scheduledTaskAllColumns) == len(scheduledTaskPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ScheduledTask{}
+	if err = randomize.Struct(seed, o, scheduledTaskDBTypes, true, scheduledTaskColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ScheduledTasks().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, scheduledTaskDBTypes, true, scheduledTaskPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(scheduledTaskAllColumns, scheduledTaskPrimaryKeyColumns) {
+		fields = scheduledTaskAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			scheduledTaskAllColumns,
+			scheduledTaskPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := ScheduledTaskSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testScheduledTasksUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(scheduledTaskAllColumns) == len(scheduledTaskPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := ScheduledTask{}
+	if err = randomize.Struct(seed, &o, scheduledTaskDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert ScheduledTask: %s", err)
+	}
+
+	count, err := ScheduledTasks().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, scheduledTaskDBTypes, false, scheduledTaskPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize ScheduledTask struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert ScheduledTask: %s", err)
+	}
+
+	count, err = ScheduledTasks().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
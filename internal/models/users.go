@@ -80,44 +80,6 @@ var UserTableColumns = struct {
 
 // Generated where
 
-type whereHelpernull_String struct{ field string }
-
-func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
 type whereHelperbool struct{ field string }
 
 func (w whereHelperbool) EQ(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
@@ -127,27 +89,6 @@ func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field
 func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
 func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
 
-type whereHelpertypes_StringArray struct{ field string }
-
-func (w whereHelpertypes_StringArray) EQ(x types.StringArray) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
-}
-func (w whereHelpertypes_StringArray) NEQ(x types.StringArray) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
-}
-func (w whereHelpertypes_StringArray) LT(x types.StringArray) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpertypes_StringArray) LTE(x types.StringArray) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpertypes_StringArray) GT(x types.StringArray) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpertypes_StringArray) GTE(x types.StringArray) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
 var UserWhere = struct {
 	ID                  whereHelperstring
 	Username            whereHelpernull_String
@@ -170,26 +111,44 @@ var UserWhere = struct {
 
 // UserRels is where relationship names are stored.
 var UserRels = struct {
-	AppUserProfile      string
-	AccessTokens        string
-	PasswordResetTokens string
-	PushTokens          string
-	RefreshTokens       string
+	AppUserProfile          string
+	UserTotp                string
+	AccessTokens            string
+	APIKeys                 string
+	EmailVerificationTokens string
+	Identities              string
+	MfaRecoveryCodes        string
+	MfaTokens               string
+	PasswordResetTokens     string
+	PushTokens              string
+	RefreshTokens           string
 }{
-	AppUserProfile:      "AppUserProfile",
-	AccessTokens:        "AccessTokens",
-	PasswordResetTokens: "PasswordResetTokens",
-	PushTokens:          "PushTokens",
-	RefreshTokens:       "RefreshTokens",
+	AppUserProfile:          "AppUserProfile",
+	UserTotp:                "UserTotp",
+	AccessTokens:            "AccessTokens",
+	APIKeys:                 "APIKeys",
+	EmailVerificationTokens: "EmailVerificationTokens",
+	Identities:              "Identities",
+	MfaRecoveryCodes:        "MfaRecoveryCodes",
+	MfaTokens:               "MfaTokens",
+	PasswordResetTokens:     "PasswordResetTokens",
+	PushTokens:              "PushTokens",
+	RefreshTokens:           "RefreshTokens",
 }
 
 // userR is where relationships are stored.
 type userR struct {
-	AppUserProfile      *AppUserProfile         `boil:"AppUserProfile" json:"AppUserProfile" toml:"AppUserProfile" yaml:"AppUserProfile"`
-	AccessTokens        AccessTokenSlice        `boil:"AccessTokens" json:"AccessTokens" toml:"AccessTokens" yaml:"AccessTokens"`
-	PasswordResetTokens PasswordResetTokenSlice `boil:"PasswordResetTokens" json:"PasswordResetTokens" toml:"PasswordResetTokens" yaml:"PasswordResetTokens"`
-	PushTokens          PushTokenSlice          `boil:"PushTokens" json:"PushTokens" toml:"PushTokens" yaml:"PushTokens"`
-	RefreshTokens       RefreshTokenSlice       `boil:"RefreshTokens" json:"RefreshTokens" toml:"RefreshTokens" yaml:"RefreshTokens"`
+	AppUserProfile          *AppUserProfile             `boil:"AppUserProfile" json:"AppUserProfile" toml:"AppUserProfile" yaml:"AppUserProfile"`
+	UserTotp                *UserTotp                   `boil:"UserTotp" json:"UserTotp" toml:"UserTotp" yaml:"UserTotp"`
+	AccessTokens            AccessTokenSlice            `boil:"AccessTokens" json:"AccessTokens" toml:"AccessTokens" yaml:"AccessTokens"`
+	APIKeys                 APIKeySlice                 `boil:"APIKeys" json:"APIKeys" toml:"APIKeys" yaml:"APIKeys"`
+	EmailVerificationTokens EmailVerificationTokenSlice `boil:"EmailVerificationTokens" json:"EmailVerificationTokens" toml:"EmailVerificationTokens" yaml:"EmailVerificationTokens"`
+	Identities              IdentitySlice               `boil:"Identities" json:"Identities" toml:"Identities" yaml:"Identities"`
+	MfaRecoveryCodes        MfaRecoveryCodeSlice        `boil:"MfaRecoveryCodes" json:"MfaRecoveryCodes" toml:"MfaRecoveryCodes" yaml:"MfaRecoveryCodes"`
+	MfaTokens               MfaTokenSlice               `boil:"MfaTokens" json:"MfaTokens" toml:"MfaTokens" yaml:"MfaTokens"`
+	PasswordResetTokens     PasswordResetTokenSlice     `boil:"PasswordResetTokens" json:"PasswordResetTokens" toml:"PasswordResetTokens" yaml:"PasswordResetTokens"`
+	PushTokens              PushTokenSlice              `boil:"PushTokens" json:"PushTokens" toml:"PushTokens" yaml:"PushTokens"`
+	RefreshTokens           RefreshTokenSlice           `boil:"RefreshTokens" json:"RefreshTokens" toml:"RefreshTokens" yaml:"RefreshTokens"`
 }
 
 // NewStruct creates a new relationship struct
@@ -204,6 +163,13 @@ func (r *userR) GetAppUserProfile() *AppUserProfile {
 	return r.AppUserProfile
 }
 
+func (r *userR) GetUserTotp() *UserTotp {
+	if r == nil {
+		return nil
+	}
+	return r.UserTotp
+}
+
 func (r *userR) GetAccessTokens() AccessTokenSlice {
 	if r == nil {
 		return nil
@@ -211,6 +177,41 @@ func (r *userR) GetAccessTokens() AccessTokenSlice {
 	return r.AccessTokens
 }
 
+func (r *userR) GetAPIKeys() APIKeySlice {
+	if r == nil {
+		return nil
+	}
+	return r.APIKeys
+}
+
+func (r *userR) GetEmailVerificationTokens() EmailVerificationTokenSlice {
+	if r == nil {
+		return nil
+	}
+	return r.EmailVerificationTokens
+}
+
+func (r *userR) GetIdentities() IdentitySlice {
+	if r == nil {
+		return nil
+	}
+	return r.Identities
+}
+
+func (r *userR) GetMfaRecoveryCodes() MfaRecoveryCodeSlice {
+	if r == nil {
+		return nil
+	}
+	return r.MfaRecoveryCodes
+}
+
+func (r *userR) GetMfaTokens() MfaTokenSlice {
+	if r == nil {
+		return nil
+	}
+	return r.MfaTokens
+}
+
 func (r *userR) GetPasswordResetTokens() PasswordResetTokenSlice {
 	if r == nil {
 		return nil
@@ -345,6 +346,17 @@ func (o *User) AppUserProfile(mods ...qm.QueryMod) appUserProfileQuery {
 	return AppUserProfiles(queryMods...)
 }
 
+// UserTotp pointed to by the foreign key.
+func (o *User) UserTotp(mods ...qm.QueryMod) userTotpQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"user_id\" = ?", o.ID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return UserTotps(queryMods...)
+}
+
 // AccessTokens retrieves all the access_token's AccessTokens with an executor.
 func (o *User) AccessTokens(mods ...qm.QueryMod) accessTokenQuery {
 	var queryMods []qm.QueryMod
@@ -359,6 +371,76 @@ func (o *User) AccessTokens(mods ...qm.QueryMod) accessTokenQuery {
 	return AccessTokens(queryMods...)
 }
 
+// APIKeys retrieves all the api_key's APIKeys with an executor.
+func (o *User) APIKeys(mods ...qm.QueryMod) apiKeyQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"api_keys\".\"user_id\"=?", o.ID),
+	)
+
+	return APIKeys(queryMods...)
+}
+
+// EmailVerificationTokens retrieves all the email_verification_token's EmailVerificationTokens with an executor.
+func (o *User) EmailVerificationTokens(mods ...qm.QueryMod) emailVerificationTokenQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"email_verification_tokens\".\"user_id\"=?", o.ID),
+	)
+
+	return EmailVerificationTokens(queryMods...)
+}
+
+// Identities retrieves all the identity's Identities with an executor.
+func (o *User) Identities(mods ...qm.QueryMod) identityQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"identities\".\"user_id\"=?", o.ID),
+	)
+
+	return Identities(queryMods...)
+}
+
+// MfaRecoveryCodes retrieves all the mfa_recovery_code's MfaRecoveryCodes with an executor.
+func (o *User) MfaRecoveryCodes(mods ...qm.QueryMod) mfaRecoveryCodeQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"mfa_recovery_codes\".\"user_id\"=?", o.ID),
+	)
+
+	return MfaRecoveryCodes(queryMods...)
+}
+
+// MfaTokens retrieves all the mfa_token's MfaTokens with an executor.
+func (o *User) MfaTokens(mods ...qm.QueryMod) mfaTokenQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"mfa_tokens\".\"user_id\"=?", o.ID),
+	)
+
+	return MfaTokens(queryMods...)
+}
+
 // PasswordResetTokens retrieves all the password_reset_token's PasswordResetTokens with an executor.
 func (o *User) PasswordResetTokens(mods ...qm.QueryMod) passwordResetTokenQuery {
 	var queryMods []qm.QueryMod
@@ -510,6 +592,115 @@ func (userL) LoadAppUserProfile(ctx context.Context, e boil.ContextExecutor, sin
 	return nil
 }
 
+// LoadUserTotp allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-1 relationship.
+func (userL) LoadUserTotp(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`user_totps`),
+		qm.WhereIn(`user_totps.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load UserTotp")
+	}
+
+	var resultSlice []*UserTotp
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice UserTotp")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for user_totps")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_totps")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.UserTotp = foreign
+		if foreign.R == nil {
+			foreign.R = &userTotpR{}
+		}
+		foreign.R.User = object
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ID == foreign.UserID {
+				local.R.UserTotp = foreign
+				if foreign.R == nil {
+					foreign.R = &userTotpR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadAccessTokens allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (userL) LoadAccessTokens(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
@@ -617,9 +808,9 @@ func (userL) LoadAccessTokens(ctx context.Context, e boil.ContextExecutor, singu
 	return nil
 }
 
-// LoadPasswordResetTokens allows an eager lookup of values, cached into the
+// LoadAPIKeys allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (userL) LoadPasswordResetTokens(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+func (userL) LoadAPIKeys(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
 	var slice []*User
 	var object *User
 
@@ -673,8 +864,8 @@ func (userL) LoadPasswordResetTokens(ctx context.Context, e boil.ContextExecutor
 	}
 
 	query := NewQuery(
-		qm.From(`password_reset_tokens`),
-		qm.WhereIn(`password_reset_tokens.user_id in ?`, args...),
+		qm.From(`api_keys`),
+		qm.WhereIn(`api_keys.user_id in ?`, args...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -682,26 +873,26 @@ func (userL) LoadPasswordResetTokens(ctx context.Context, e boil.ContextExecutor
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load password_reset_tokens")
+		return errors.Wrap(err, "failed to eager load api_keys")
 	}
 
-	var resultSlice []*PasswordResetToken
+	var resultSlice []*APIKey
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice password_reset_tokens")
+		return errors.Wrap(err, "failed to bind eager loaded slice api_keys")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on password_reset_tokens")
+		return errors.Wrap(err, "failed to close results in eager load on api_keys")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for password_reset_tokens")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for api_keys")
 	}
 
 	if singular {
-		object.R.PasswordResetTokens = resultSlice
+		object.R.APIKeys = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &passwordResetTokenR{}
+				foreign.R = &apiKeyR{}
 			}
 			foreign.R.User = object
 		}
@@ -711,9 +902,9 @@ func (userL) LoadPasswordResetTokens(ctx context.Context, e boil.ContextExecutor
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
 			if local.ID == foreign.UserID {
-				local.R.PasswordResetTokens = append(local.R.PasswordResetTokens, foreign)
+				local.R.APIKeys = append(local.R.APIKeys, foreign)
 				if foreign.R == nil {
-					foreign.R = &passwordResetTokenR{}
+					foreign.R = &apiKeyR{}
 				}
 				foreign.R.User = local
 				break
@@ -724,9 +915,9 @@ func (userL) LoadPasswordResetTokens(ctx context.Context, e boil.ContextExecutor
 	return nil
 }
 
-// LoadPushTokens allows an eager lookup of values, cached into the
+// LoadEmailVerificationTokens allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (userL) LoadPushTokens(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+func (userL) LoadEmailVerificationTokens(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
 	var slice []*User
 	var object *User
 
@@ -780,8 +971,8 @@ func (userL) LoadPushTokens(ctx context.Context, e boil.ContextExecutor, singula
 	}
 
 	query := NewQuery(
-		qm.From(`push_tokens`),
-		qm.WhereIn(`push_tokens.user_id in ?`, args...),
+		qm.From(`email_verification_tokens`),
+		qm.WhereIn(`email_verification_tokens.user_id in ?`, args...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -789,26 +980,26 @@ func (userL) LoadPushTokens(ctx context.Context, e boil.ContextExecutor, singula
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load push_tokens")
+		return errors.Wrap(err, "failed to eager load email_verification_tokens")
 	}
 
-	var resultSlice []*PushToken
+	var resultSlice []*EmailVerificationToken
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice push_tokens")
+		return errors.Wrap(err, "failed to bind eager loaded slice email_verification_tokens")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on push_tokens")
+		return errors.Wrap(err, "failed to close results in eager load on email_verification_tokens")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for push_tokens")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for email_verification_tokens")
 	}
 
 	if singular {
-		object.R.PushTokens = resultSlice
+		object.R.EmailVerificationTokens = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &pushTokenR{}
+				foreign.R = &emailVerificationTokenR{}
 			}
 			foreign.R.User = object
 		}
@@ -818,9 +1009,9 @@ func (userL) LoadPushTokens(ctx context.Context, e boil.ContextExecutor, singula
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
 			if local.ID == foreign.UserID {
-				local.R.PushTokens = append(local.R.PushTokens, foreign)
+				local.R.EmailVerificationTokens = append(local.R.EmailVerificationTokens, foreign)
 				if foreign.R == nil {
-					foreign.R = &pushTokenR{}
+					foreign.R = &emailVerificationTokenR{}
 				}
 				foreign.R.User = local
 				break
@@ -831,9 +1022,9 @@ func (userL) LoadPushTokens(ctx context.Context, e boil.ContextExecutor, singula
 	return nil
 }
 
-// LoadRefreshTokens allows an eager lookup of values, cached into the
+// LoadIdentities allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (userL) LoadRefreshTokens(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+func (userL) LoadIdentities(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
 	var slice []*User
 	var object *User
 
@@ -887,8 +1078,8 @@ func (userL) LoadRefreshTokens(ctx context.Context, e boil.ContextExecutor, sing
 	}
 
 	query := NewQuery(
-		qm.From(`refresh_tokens`),
-		qm.WhereIn(`refresh_tokens.user_id in ?`, args...),
+		qm.From(`identities`),
+		qm.WhereIn(`identities.user_id in ?`, args...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -896,26 +1087,26 @@ func (userL) LoadRefreshTokens(ctx context.Context, e boil.ContextExecutor, sing
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load refresh_tokens")
+		return errors.Wrap(err, "failed to eager load identities")
 	}
 
-	var resultSlice []*RefreshToken
+	var resultSlice []*Identity
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice refresh_tokens")
+		return errors.Wrap(err, "failed to bind eager loaded slice identities")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on refresh_tokens")
+		return errors.Wrap(err, "failed to close results in eager load on identities")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for refresh_tokens")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for identities")
 	}
 
 	if singular {
-		object.R.RefreshTokens = resultSlice
+		object.R.Identities = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &refreshTokenR{}
+				foreign.R = &identityR{}
 			}
 			foreign.R.User = object
 		}
@@ -925,9 +1116,9 @@ func (userL) LoadRefreshTokens(ctx context.Context, e boil.ContextExecutor, sing
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
 			if local.ID == foreign.UserID {
-				local.R.RefreshTokens = append(local.R.RefreshTokens, foreign)
+				local.R.Identities = append(local.R.Identities, foreign)
 				if foreign.R == nil {
-					foreign.R = &refreshTokenR{}
+					foreign.R = &identityR{}
 				}
 				foreign.R.User = local
 				break
@@ -938,23 +1129,608 @@ func (userL) LoadRefreshTokens(ctx context.Context, e boil.ContextExecutor, sing
 	return nil
 }
 
-// SetAppUserProfile of the user to the related item.
-// Sets o.R.AppUserProfile to related.
-// Adds o to related.R.User.
-func (o *User) SetAppUserProfile(ctx context.Context, exec boil.ContextExecutor, insert bool, related *AppUserProfile) error {
-	var err error
-
-	if insert {
-		related.UserID = o.ID
+// LoadMfaRecoveryCodes allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadMfaRecoveryCodes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
 
-		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`mfa_recovery_codes`),
+		qm.WhereIn(`mfa_recovery_codes.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load mfa_recovery_codes")
+	}
+
+	var resultSlice []*MfaRecoveryCode
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice mfa_recovery_codes")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on mfa_recovery_codes")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for mfa_recovery_codes")
+	}
+
+	if singular {
+		object.R.MfaRecoveryCodes = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &mfaRecoveryCodeR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.MfaRecoveryCodes = append(local.R.MfaRecoveryCodes, foreign)
+				if foreign.R == nil {
+					foreign.R = &mfaRecoveryCodeR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadMfaTokens allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadMfaTokens(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`mfa_tokens`),
+		qm.WhereIn(`mfa_tokens.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load mfa_tokens")
+	}
+
+	var resultSlice []*MfaToken
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice mfa_tokens")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on mfa_tokens")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for mfa_tokens")
+	}
+
+	if singular {
+		object.R.MfaTokens = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &mfaTokenR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.MfaTokens = append(local.R.MfaTokens, foreign)
+				if foreign.R == nil {
+					foreign.R = &mfaTokenR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadPasswordResetTokens allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadPasswordResetTokens(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`password_reset_tokens`),
+		qm.WhereIn(`password_reset_tokens.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load password_reset_tokens")
+	}
+
+	var resultSlice []*PasswordResetToken
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice password_reset_tokens")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on password_reset_tokens")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for password_reset_tokens")
+	}
+
+	if singular {
+		object.R.PasswordResetTokens = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &passwordResetTokenR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.PasswordResetTokens = append(local.R.PasswordResetTokens, foreign)
+				if foreign.R == nil {
+					foreign.R = &passwordResetTokenR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadPushTokens allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadPushTokens(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`push_tokens`),
+		qm.WhereIn(`push_tokens.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load push_tokens")
+	}
+
+	var resultSlice []*PushToken
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice push_tokens")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on push_tokens")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for push_tokens")
+	}
+
+	if singular {
+		object.R.PushTokens = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &pushTokenR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.PushTokens = append(local.R.PushTokens, foreign)
+				if foreign.R == nil {
+					foreign.R = &pushTokenR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadRefreshTokens allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadRefreshTokens(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`refresh_tokens`),
+		qm.WhereIn(`refresh_tokens.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load refresh_tokens")
+	}
+
+	var resultSlice []*RefreshToken
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice refresh_tokens")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on refresh_tokens")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for refresh_tokens")
+	}
+
+	if singular {
+		object.R.RefreshTokens = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &refreshTokenR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.RefreshTokens = append(local.R.RefreshTokens, foreign)
+				if foreign.R == nil {
+					foreign.R = &refreshTokenR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetAppUserProfile of the user to the related item.
+// Sets o.R.AppUserProfile to related.
+// Adds o to related.R.User.
+func (o *User) SetAppUserProfile(ctx context.Context, exec boil.ContextExecutor, insert bool, related *AppUserProfile) error {
+	var err error
+
+	if insert {
+		related.UserID = o.ID
+
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	} else {
+		updateQuery := fmt.Sprintf(
+			"UPDATE \"app_user_profiles\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+			strmangle.WhereClause("\"", "\"", 2, appUserProfilePrimaryKeyColumns),
+		)
+		values := []interface{}{o.ID, related.UserID}
+
+		if boil.IsDebug(ctx) {
+			writer := boil.DebugWriterFrom(ctx)
+			fmt.Fprintln(writer, updateQuery)
+			fmt.Fprintln(writer, values)
+		}
+		if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+			return errors.Wrap(err, "failed to update foreign table")
+		}
+
+		related.UserID = o.ID
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			AppUserProfile: related,
+		}
+	} else {
+		o.R.AppUserProfile = related
+	}
+
+	if related.R == nil {
+		related.R = &appUserProfileR{
+			User: o,
+		}
+	} else {
+		related.R.User = o
+	}
+	return nil
+}
+
+// SetUserTotp of the user to the related item.
+// Sets o.R.UserTotp to related.
+// Adds o to related.R.User.
+func (o *User) SetUserTotp(ctx context.Context, exec boil.ContextExecutor, insert bool, related *UserTotp) error {
+	var err error
+
+	if insert {
+		related.UserID = o.ID
+
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
 			return errors.Wrap(err, "failed to insert into foreign table")
 		}
 	} else {
 		updateQuery := fmt.Sprintf(
-			"UPDATE \"app_user_profiles\" SET %s WHERE %s",
+			"UPDATE \"user_totps\" SET %s WHERE %s",
 			strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
-			strmangle.WhereClause("\"", "\"", 2, appUserProfilePrimaryKeyColumns),
+			strmangle.WhereClause("\"", "\"", 2, userTotpPrimaryKeyColumns),
 		)
 		values := []interface{}{o.ID, related.UserID}
 
@@ -972,14 +1748,14 @@ func (o *User) SetAppUserProfile(ctx context.Context, exec boil.ContextExecutor,
 
 	if o.R == nil {
 		o.R = &userR{
-			AppUserProfile: related,
+			UserTotp: related,
 		}
 	} else {
-		o.R.AppUserProfile = related
+		o.R.UserTotp = related
 	}
 
 	if related.R == nil {
-		related.R = &appUserProfileR{
+		related.R = &userTotpR{
 			User: o,
 		}
 	} else {
@@ -1041,6 +1817,271 @@ func (o *User) AddAccessTokens(ctx context.Context, exec boil.ContextExecutor, i
 	return nil
 }
 
+// AddAPIKeys adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.APIKeys.
+// Sets related.R.User appropriately.
+func (o *User) AddAPIKeys(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*APIKey) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"api_keys\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, apiKeyPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			APIKeys: related,
+		}
+	} else {
+		o.R.APIKeys = append(o.R.APIKeys, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &apiKeyR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddEmailVerificationTokens adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.EmailVerificationTokens.
+// Sets related.R.User appropriately.
+func (o *User) AddEmailVerificationTokens(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*EmailVerificationToken) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"email_verification_tokens\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, emailVerificationTokenPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.Token}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			EmailVerificationTokens: related,
+		}
+	} else {
+		o.R.EmailVerificationTokens = append(o.R.EmailVerificationTokens, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &emailVerificationTokenR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddIdentities adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.Identities.
+// Sets related.R.User appropriately.
+func (o *User) AddIdentities(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Identity) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"identities\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, identityPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			Identities: related,
+		}
+	} else {
+		o.R.Identities = append(o.R.Identities, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &identityR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddMfaRecoveryCodes adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.MfaRecoveryCodes.
+// Sets related.R.User appropriately.
+func (o *User) AddMfaRecoveryCodes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*MfaRecoveryCode) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"mfa_recovery_codes\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, mfaRecoveryCodePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			MfaRecoveryCodes: related,
+		}
+	} else {
+		o.R.MfaRecoveryCodes = append(o.R.MfaRecoveryCodes, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &mfaRecoveryCodeR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddMfaTokens adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.MfaTokens.
+// Sets related.R.User appropriately.
+func (o *User) AddMfaTokens(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*MfaToken) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"mfa_tokens\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, mfaTokenPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.Token}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			MfaTokens: related,
+		}
+	} else {
+		o.R.MfaTokens = append(o.R.MfaTokens, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &mfaTokenR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
 // AddPasswordResetTokens adds the given related objects to the existing relationships
 // of the user, optionally inserting them as new records.
 // Appends related to o.R.PasswordResetTokens.
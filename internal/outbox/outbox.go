@@ -0,0 +1,152 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// States of outbox messages, published messages are deleted.
+const (
+	StatePending = "pending"
+	// StateDead is reached once all attempts failed or the handler returned a permanent error. Dead messages keep
+	// holding back later messages of their aggregate until they're retried (see Retry) or discarded (see Discard).
+	StateDead = "dead"
+)
+
+var (
+	ErrTopicRequired   = errors.New("outbox message topic is required")
+	ErrMessageNotFound = errors.New("dead outbox message not found")
+)
+
+// Message is a side effect to be published once the transaction writing it commits.
+type Message struct {
+	Topic string
+	// AggregateKey orders messages: messages with the same key are published in the order they were enqueued and
+	// a message failing to publish holds back later ones. Messages without a key are published in any order.
+	AggregateKey string
+	// Payload is encoded as JSON.
+	Payload interface{}
+}
+
+// Enqueue writes the messages to the outbox using the executor, which should be the transaction whose outcome
+// the messages depend on, e.g. within a db.TxFn.
+func Enqueue(ctx context.Context, exec boil.ContextExecutor, msgs ...Message) error {
+	now := time.Now()
+
+	for _, msg := range msgs {
+		if len(msg.Topic) == 0 {
+			return ErrTopicRequired
+		}
+
+		payload, err := json.Marshal(msg.Payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode payload of outbox message %q: %w", msg.Topic, err)
+		}
+
+		m := &models.OutboxMessage{
+			Topic:         msg.Topic,
+			AggregateKey:  null.NewString(msg.AggregateKey, len(msg.AggregateKey) > 0),
+			Payload:       payload,
+			State:         StatePending,
+			NextAttemptAt: now,
+		}
+		if err := m.Insert(ctx, exec, boil.Infer()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EnqueueFn returns a db.TxFn enqueuing the messages, e.g. to compose it with other TxFns.
+func EnqueueFn(ctx context.Context, msgs ...Message) db.TxFn {
+	return func(exec boil.ContextExecutor) error {
+		return Enqueue(ctx, exec, msgs...)
+	}
+}
+
+// HandlerFunc publishes a message. Returning an error retries the message with exponential backoff, unless it's
+// marked via Permanent or the message's attempts are exhausted. Messages are published at least once, e.g. after
+// a crash, thus handlers must be idempotent.
+type HandlerFunc func(ctx context.Context, msg *models.OutboxMessage) error
+
+// Handle returns a HandlerFunc decoding the JSON payload of the message into T.
+func Handle[T any](fn func(ctx context.Context, payload T) error) HandlerFunc {
+	return func(ctx context.Context, msg *models.OutboxMessage) error {
+		var payload T
+		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+			return Permanent(fmt.Errorf("failed to decode payload: %w", err))
+		}
+
+		return fn(ctx, payload)
+	}
+}
+
+// Retry schedules a dead message to be published again immediately, granting it another full set of attempts.
+// Later messages of its aggregate stay held back until it's published.
+func Retry(ctx context.Context, exec boil.ContextExecutor, id int64) error {
+	n, err := models.OutboxMessages(
+		models.OutboxMessageWhere.ID.EQ(id),
+		models.OutboxMessageWhere.State.EQ(StateDead),
+	).UpdateAll(ctx, exec, models.M{
+		models.OutboxMessageColumns.State:         StatePending,
+		models.OutboxMessageColumns.Attempts:      0,
+		models.OutboxMessageColumns.NextAttemptAt: time.Now(),
+		models.OutboxMessageColumns.UpdatedAt:     time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrMessageNotFound
+	}
+
+	return nil
+}
+
+// Discard deletes a dead message without publishing it, releasing later messages of its aggregate.
+func Discard(ctx context.Context, exec boil.ContextExecutor, id int64) error {
+	n, err := models.OutboxMessages(
+		models.OutboxMessageWhere.ID.EQ(id),
+		models.OutboxMessageWhere.State.EQ(StateDead),
+	).DeleteAll(ctx, exec)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrMessageNotFound
+	}
+
+	return nil
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks the error as permanent, the message is moved to the dead state without being retried.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether the error was marked as permanent.
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
@@ -0,0 +1,79 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/outbox"
+	"github.com/driif/echo-go-starter/internal/server/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type userRegistered struct {
+	UserID string `json:"userId"`
+}
+
+func TestEnqueueRequiresTopic(t *testing.T) {
+	err := outbox.Enqueue(context.Background(), nil, outbox.Message{Payload: userRegistered{}})
+	assert.ErrorIs(t, err, outbox.ErrTopicRequired)
+
+	err = outbox.EnqueueFn(context.Background(), outbox.Message{})(nil)
+	assert.ErrorIs(t, err, outbox.ErrTopicRequired)
+}
+
+func TestHandle(t *testing.T) {
+	var received userRegistered
+	handler := outbox.Handle(func(_ context.Context, payload userRegistered) error {
+		received = payload
+		return nil
+	})
+
+	err := handler(context.Background(), &models.OutboxMessage{Payload: []byte(`{"userId":"f6ede5d8-e22a-4ca5-aa12-67821865a3e5"}`)})
+	require.NoError(t, err)
+	assert.Equal(t, "f6ede5d8-e22a-4ca5-aa12-67821865a3e5", received.UserID)
+
+	err = handler(context.Background(), &models.OutboxMessage{Payload: []byte(`[]`)})
+	require.Error(t, err)
+	assert.True(t, outbox.IsPermanent(err))
+
+	cause := errors.New("webhook unavailable")
+	err = outbox.Handle(func(_ context.Context, _ userRegistered) error { return cause })(context.Background(), &models.OutboxMessage{Payload: []byte(`{}`)})
+	assert.ErrorIs(t, err, cause)
+	assert.False(t, outbox.IsPermanent(err))
+}
+
+func TestPermanent(t *testing.T) {
+	cause := errors.New("invalid webhook URL")
+	err := outbox.Permanent(cause)
+
+	assert.True(t, outbox.IsPermanent(err))
+	assert.True(t, outbox.IsPermanent(errors.Join(errors.New("wrapped"), err)))
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, cause.Error(), err.Error())
+	assert.False(t, outbox.IsPermanent(cause))
+	assert.False(t, outbox.IsPermanent(nil))
+}
+
+func TestRelayRegister(t *testing.T) {
+	r := outbox.NewRelay(nil, config.Outbox{})
+	noop := func(context.Context, *models.OutboxMessage) error { return nil }
+
+	r.Register("user.registered", noop)
+	r.Register("user.deleted", noop)
+	assert.Equal(t, []string{"user.deleted", "user.registered"}, r.Topics())
+
+	assert.Panics(t, func() { r.Register("user.registered", noop) })
+}
+
+func TestRelayStartWithoutHandlers(t *testing.T) {
+	r := outbox.NewRelay(nil, config.Outbox{})
+	r.Start()
+	r.Stop()
+
+	assert.Panics(t, func() {
+		r.Register("user.registered", func(context.Context, *models.OutboxMessage) error { return nil })
+	})
+}
@@ -0,0 +1,247 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"expvar"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/jobs"
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server/config"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// metrics are published via expvar, counting published and failed messages.
+var metrics = expvar.NewMap("outbox")
+
+// defaultBatchSize is used if no positive batch size is configured. Batches are kept small as their messages stay
+// locked until all of them are published.
+const defaultBatchSize = 10
+
+// defaultMaxAttempts is used if no positive max attempts are configured.
+const defaultMaxAttempts = 10
+
+// claimQuery locks due pending messages of the given topics which are the oldest message of their aggregate, thus
+// earlier pending or dead messages hold back later ones. Messages locked by concurrent relays are skipped, which also
+// holds back later messages of their aggregate.
+const claimQuery = `SELECT m.* FROM outbox_messages m
+WHERE m.topic = ANY($1) AND m.state = 'pending' AND m.next_attempt_at <= $2
+	AND NOT EXISTS (SELECT 1 FROM outbox_messages p WHERE p.aggregate_key = m.aggregate_key AND p.state IN ('pending', 'dead') AND p.id < m.id)
+ORDER BY m.id
+LIMIT $3
+FOR UPDATE OF m SKIP LOCKED`
+
+// Relay publishes outbox messages using the handlers registered by topic.
+type Relay struct {
+	db       *sql.DB
+	config   config.Outbox
+	handlers map[string]HandlerFunc
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewRelay returns a relay, handlers must be registered before starting it.
+func NewRelay(db *sql.DB, config config.Outbox) *Relay {
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultMaxAttempts
+	}
+
+	return &Relay{
+		db:       db,
+		config:   config,
+		handlers: make(map[string]HandlerFunc),
+	}
+}
+
+// Register adds the handler publishing messages of the topic, registering a topic twice panics.
+// Messages of topics without handler are kept, holding back later messages of their aggregate.
+func (r *Relay) Register(topic string, handler HandlerFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel != nil {
+		panic(fmt.Sprintf("outbox: handler %q registered after starting the relay", topic))
+	}
+	if _, ok := r.handlers[topic]; ok {
+		panic(fmt.Sprintf("outbox: handler %q registered multiple times", topic))
+	}
+
+	r.handlers[topic] = handler
+}
+
+// Topics returns the sorted topics of the registered handlers.
+func (r *Relay) Topics() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	topics := make([]string, 0, len(r.handlers))
+	for topic := range r.handlers {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+
+	return topics
+}
+
+// Start publishes messages in the background until Stop is called.
+func (r *Relay) Start() {
+	topics := r.Topics()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	if len(topics) == 0 {
+		log.Debug().Msg("No outbox handlers registered, not starting outbox relay")
+		return
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		for {
+			n, err := r.Run(ctx)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				log.Error().Err(err).Msg("Failed to relay outbox messages")
+			}
+
+			// a full batch indicates more messages are due
+			if err == nil && n == r.config.BatchSize {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(r.config.PollInterval):
+			}
+		}
+	}()
+
+	log.Info().Strs("topics", topics).Msg("Started outbox relay")
+}
+
+// Stop cancels publishing and waits for the current batch to finish. Messages of an interrupted batch are
+// published again later.
+func (r *Relay) Stop() {
+	r.mu.Lock()
+	cancel := r.cancel
+	r.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	r.wg.Wait()
+}
+
+// Run publishes a single batch of due messages within a transaction holding their locks, returning the number of
+// messages claimed. The transaction and up to BatchSize row locks are held while the handlers are called one after
+// another, e.g. during network calls, thus the batch size should be small. Published messages are deleted, failed
+// messages are scheduled for a retry with backoff or moved to the dead state.
+func (r *Relay) Run(ctx context.Context) (int, error) {
+	topics := r.Topics()
+
+	var claimed int
+	err := db.WithTransaction(ctx, r.db, func(tx boil.ContextExecutor) error {
+		var msgs []*models.OutboxMessage
+		if err := queries.Raw(claimQuery, pq.Array(topics), time.Now(), r.config.BatchSize).Bind(ctx, tx, &msgs); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+		claimed = len(msgs)
+
+		for _, msg := range msgs {
+			if err := r.publish(ctx, tx, msg); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return claimed, err
+}
+
+// publish calls the message's handler and records the outcome within the relay's transaction.
+func (r *Relay) publish(ctx context.Context, tx boil.ContextExecutor, msg *models.OutboxMessage) error {
+	l := log.With().Int64("messageID", msg.ID).Str("topic", msg.Topic).Str("aggregateKey", msg.AggregateKey.String).Logger()
+
+	r.mu.Lock()
+	handler := r.handlers[msg.Topic]
+	r.mu.Unlock()
+
+	err := call(ctx, handler, msg)
+	if err == nil {
+		metrics.Add("published", 1)
+		_, err = msg.Delete(ctx, tx)
+		return err
+	}
+
+	// interrupted by Stop, the message is retried once the transaction rolled back
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	msg.Attempts++
+	msg.LastError = null.StringFrom(err.Error())
+
+	if IsPermanent(err) || msg.Attempts >= r.config.MaxAttempts {
+		l.Error().Err(err).Int("attempt", msg.Attempts).Msg("Failed to publish outbox message permanently")
+		metrics.Add("dead", 1)
+
+		msg.State = StateDead
+	} else {
+		delay := jobs.Backoff(msg.Attempts, r.config.BackoffBase, r.config.BackoffMax)
+		// spreads retries of messages failed simultaneously, e.g. due to an unavailable dependency
+		delay += time.Duration(rand.Int63n(int64(delay)/10 + 1))
+
+		l.Warn().Err(err).Int("attempt", msg.Attempts).Dur("retryIn", delay).Msg("Failed to publish outbox message, retrying")
+		metrics.Add("failed", 1)
+
+		msg.NextAttemptAt = time.Now().Add(delay)
+	}
+
+	_, err = msg.Update(ctx, tx, boil.Whitelist(models.OutboxMessageColumns.State, models.OutboxMessageColumns.Attempts,
+		models.OutboxMessageColumns.NextAttemptAt, models.OutboxMessageColumns.LastError, models.OutboxMessageColumns.UpdatedAt))
+
+	return err
+}
+
+// call runs the handler, recovering panics.
+func call(ctx context.Context, handler HandlerFunc, msg *models.OutboxMessage) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("outbox handler panicked: %v", p)
+		}
+	}()
+
+	// the handler's own transactions are separate from the relay's, transactions started within them are nested
+	return handler(db.WithTxScope(ctx), msg)
+}
@@ -0,0 +1,102 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron(t *testing.T) {
+	// Friday
+	from := time.Date(2026, 10, 16, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 10, 16, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 10, 16, 10, 15, 0, 0, time.UTC)},
+		{"5/15 * * * *", time.Date(2026, 10, 16, 10, 20, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC)},
+		{"30 9-17 * * mon-fri", time.Date(2026, 10, 16, 10, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// either the day of month or the day of week matches if both are restricted
+		{"0 0 20 * sun", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{"0,30 12 * * *", time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 10, 16, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := scheduler.Parse(tt.spec, time.UTC)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.Next(from))
+		})
+	}
+}
+
+func TestParseCronNever(t *testing.T) {
+	s, err := scheduler.Parse("0 0 30 2 *", time.UTC)
+	require.NoError(t, err)
+	assert.True(t, s.Next(time.Now()).IsZero())
+}
+
+func TestParseCronLocation(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Vienna")
+	require.NoError(t, err)
+
+	s, err := scheduler.Parse("30 2 * * *", loc)
+	require.NoError(t, err)
+
+	// 02:30 doesn't exist on the day daylight saving time starts, thus the activation is skipped
+	assert.Equal(t, time.Date(2026, 3, 30, 2, 30, 0, 0, loc), s.Next(time.Date(2026, 3, 29, 0, 0, 0, 0, loc)))
+
+	next := s.Next(time.Date(2026, 10, 16, 12, 0, 0, 0, loc))
+	assert.Equal(t, time.Date(2026, 10, 17, 0, 30, 0, 0, time.UTC), next.UTC())
+}
+
+func TestParseEvery(t *testing.T) {
+	s, err := scheduler.Parse("@every 15m", time.UTC)
+	require.NoError(t, err)
+
+	from := time.Date(2026, 10, 16, 10, 7, 30, 0, time.UTC)
+	next := s.Next(from)
+	assert.Equal(t, time.Date(2026, 10, 16, 10, 15, 0, 0, time.UTC), next)
+	assert.Equal(t, time.Date(2026, 10, 16, 10, 30, 0, 0, time.UTC), s.Next(next))
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@every",
+		"@every 1ms",
+		"@every soon",
+		"@sometimes",
+	} {
+		_, err := scheduler.Parse(spec, time.UTC)
+		assert.ErrorIs(t, err, scheduler.ErrInvalidSchedule, spec)
+	}
+}
+
+func TestLockKey(t *testing.T) {
+	assert.Equal(t, scheduler.LockKey("cleanup"), scheduler.LockKey("cleanup"))
+	assert.NotEqual(t, scheduler.LockKey("cleanup"), scheduler.LockKey("reports"))
+}
@@ -0,0 +1,302 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"expvar"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server/config"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/rs/zerolog/log"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// metrics are published via expvar, counting runs, failed runs and ticks skipped as another replica ran the task.
+var metrics = expvar.NewMap("scheduler")
+
+// bookkeepingTimeout limits recording a task's run, which also happens during shutdown.
+const bookkeepingTimeout = 10 * time.Second
+
+// TaskFunc is executed on every activation of a task's schedule. The context is canceled once the scheduler stops.
+type TaskFunc func(ctx context.Context) error
+
+type task struct {
+	name     string
+	spec     string
+	schedule Schedule
+	fn       TaskFunc
+
+	mu      sync.Mutex
+	nextRun time.Time
+}
+
+// TaskStatus describes a registered task and its last run, which might have happened on another replica.
+type TaskStatus struct {
+	Name     string
+	Schedule string
+	// NextRunAt is the next activation as seen by this replica, zero if the scheduler isn't running.
+	NextRunAt time.Time
+	// Running reports whether the last run didn't finish yet (or crashed).
+	Running         bool
+	LastRunAt       null.Time
+	LastDuration    time.Duration
+	LastError       null.String
+	LastSucceededAt null.Time
+}
+
+// Scheduler executes periodic tasks once cluster-wide. On every activation, replicas compete for a Postgres advisory
+// lock keyed by the task's name; the winner runs the task unless another replica already did for this activation.
+type Scheduler struct {
+	db       *sql.DB
+	location *time.Location
+
+	mu     sync.Mutex
+	tasks  []*task
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New returns a scheduler evaluating cron expressions in the configured timezone.
+func New(db *sql.DB, config config.Scheduler) (*Scheduler, error) {
+	location, err := time.LoadLocation(config.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load scheduler timezone %q: %w", config.Timezone, err)
+	}
+
+	return &Scheduler{
+		db:       db,
+		location: location,
+	}, nil
+}
+
+// Register adds a task running on the schedule (see Parse). Invalid schedules, registering a name twice or
+// registering after starting the scheduler panic.
+func (s *Scheduler) Register(name string, spec string, fn TaskFunc) {
+	schedule, err := Parse(spec, s.location)
+	if err != nil {
+		panic(fmt.Sprintf("scheduler: task %q: %v", name, err))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		panic(fmt.Sprintf("scheduler: task %q registered after starting the scheduler", name))
+	}
+	for _, t := range s.tasks {
+		if t.name == name {
+			panic(fmt.Sprintf("scheduler: task %q registered multiple times", name))
+		}
+	}
+
+	s.tasks = append(s.tasks, &task{name: name, spec: spec, schedule: schedule, fn: fn})
+}
+
+// Start runs the registered tasks in the background until Stop is called.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	names := make([]string, 0, len(s.tasks))
+	for _, t := range s.tasks {
+		names = append(names, t.name)
+
+		s.wg.Add(1)
+		go s.loop(ctx, t)
+	}
+
+	log.Info().Strs("tasks", names).Str("timezone", s.location.String()).Msg("Started scheduler")
+}
+
+// Stop cancels running tasks and waits for them to return.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	s.wg.Wait()
+}
+
+// Status returns the status of all registered tasks in the order they were registered.
+func (s *Scheduler) Status(ctx context.Context) ([]TaskStatus, error) {
+	s.mu.Lock()
+	tasks := make([]*task, len(s.tasks))
+	copy(tasks, s.tasks)
+	s.mu.Unlock()
+
+	names := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		names = append(names, t.name)
+	}
+
+	records, err := models.ScheduledTasks(models.ScheduledTaskWhere.Name.IN(names)).All(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*models.ScheduledTask, len(records))
+	for _, r := range records {
+		byName[r.Name] = r
+	}
+
+	res := make([]TaskStatus, 0, len(tasks))
+	for _, t := range tasks {
+		t.mu.Lock()
+		status := TaskStatus{Name: t.name, Schedule: t.spec, NextRunAt: t.nextRun}
+		t.mu.Unlock()
+
+		if r, ok := byName[t.name]; ok {
+			status.Running = r.LastRunAt.Valid && !r.LastDurationMS.Valid
+			status.LastRunAt = r.LastRunAt
+			status.LastDuration = time.Duration(r.LastDurationMS.Int64) * time.Millisecond
+			status.LastError = r.LastError
+			status.LastSucceededAt = r.LastSucceededAt
+		}
+
+		res = append(res, status)
+	}
+
+	return res, nil
+}
+
+func (s *Scheduler) loop(ctx context.Context, t *task) {
+	defer s.wg.Done()
+
+	for {
+		next := t.schedule.Next(time.Now())
+		if next.IsZero() {
+			log.Warn().Str("task", t.name).Str("schedule", t.spec).Msg("Scheduled task never runs again")
+			return
+		}
+
+		t.mu.Lock()
+		t.nextRun = next
+		t.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if err := s.tick(ctx, t, next); err != nil && !errors.Is(err, context.Canceled) {
+			log.Error().Err(err).Str("task", t.name).Msg("Failed to run scheduled task")
+		}
+	}
+}
+
+// tick runs the task for the activation at the given time if this replica acquires the task's lock and no other
+// replica ran the task for this activation yet.
+func (s *Scheduler) tick(ctx context.Context, t *task, at time.Time) error {
+	// advisory locks are held by the session, thus a dedicated connection is used until the lock is released
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	key := LockKey(t.name)
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&locked); err != nil {
+		return err
+	}
+	if !locked {
+		log.Debug().Str("task", t.name).Msg("Scheduled task is locked by another replica, skipping")
+		metrics.Add("skipped", 1)
+		return nil
+	}
+	defer func() {
+		unlockCtx, cancel := context.WithTimeout(context.Background(), bookkeepingTimeout)
+		defer cancel()
+
+		if _, err := conn.ExecContext(unlockCtx, "SELECT pg_advisory_unlock($1)", key); err != nil {
+			log.Error().Err(err).Str("task", t.name).Msg("Failed to release scheduled task lock")
+		}
+	}()
+
+	record, err := models.FindScheduledTask(ctx, s.db, t.name)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	// another replica acquired the lock first and already finished this activation
+	if record != nil && record.LastRunAt.Valid && !record.LastRunAt.Time.Before(at) {
+		log.Debug().Str("task", t.name).Msg("Scheduled task already ran on another replica, skipping")
+		metrics.Add("skipped", 1)
+		return nil
+	}
+
+	start := time.Now()
+	record = &models.ScheduledTask{Name: t.name, LastRunAt: null.TimeFrom(start)}
+	if err := record.Upsert(ctx, s.db, true, []string{models.ScheduledTaskColumns.Name},
+		boil.Whitelist(models.ScheduledTaskColumns.LastRunAt, models.ScheduledTaskColumns.LastDurationMS,
+			models.ScheduledTaskColumns.LastError, models.ScheduledTaskColumns.UpdatedAt),
+		boil.Infer()); err != nil {
+		return err
+	}
+
+	runErr := run(ctx, t)
+	duration := time.Since(start)
+	metrics.Add("runs", 1)
+
+	l := log.With().Str("task", t.name).Dur("duration", duration).Logger()
+	record.LastDurationMS = null.Int64From(duration.Milliseconds())
+	columns := []string{models.ScheduledTaskColumns.LastDurationMS, models.ScheduledTaskColumns.UpdatedAt}
+	if runErr != nil {
+		l.Error().Err(runErr).Msg("Scheduled task failed")
+		metrics.Add("errors", 1)
+		record.LastError = null.StringFrom(runErr.Error())
+		columns = append(columns, models.ScheduledTaskColumns.LastError)
+	} else {
+		l.Info().Msg("Scheduled task succeeded")
+		record.LastSucceededAt = null.TimeFrom(time.Now())
+		columns = append(columns, models.ScheduledTaskColumns.LastSucceededAt)
+	}
+
+	updateCtx, cancel := context.WithTimeout(context.Background(), bookkeepingTimeout)
+	defer cancel()
+
+	_, err = record.Update(updateCtx, s.db, boil.Whitelist(columns...))
+	return err
+}
+
+// run calls the task's function, recovering panics.
+func run(ctx context.Context, t *task) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("task panicked: %v", r)
+		}
+	}()
+
+	// transactions started by the task within another one are nested
+	return t.fn(db.WithTxScope(ctx))
+}
+
+// LockKey returns the advisory lock key of the task with the given name.
+func LockKey(name string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte("scheduler:" + name))
+
+	return int64(h.Sum64())
+}
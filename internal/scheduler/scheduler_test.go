@@ -0,0 +1,34 @@
+package scheduler_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/driif/echo-go-starter/internal/scheduler"
+	"github.com/driif/echo-go-starter/internal/server/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	_, err := scheduler.New(nil, config.Scheduler{Timezone: "Europe/Vienna"})
+	require.NoError(t, err)
+
+	_, err = scheduler.New(nil, config.Scheduler{Timezone: "Nowhere/Unknown"})
+	assert.Error(t, err)
+}
+
+func TestRegister(t *testing.T) {
+	s, err := scheduler.New(nil, config.Scheduler{Timezone: "UTC"})
+	require.NoError(t, err)
+
+	noop := func(context.Context) error { return nil }
+
+	s.Register("cleanup", "@hourly", noop)
+	assert.Panics(t, func() { s.Register("cleanup", "@daily", noop) })
+	assert.Panics(t, func() { s.Register("reports", "every day", noop) })
+
+	s.Start()
+	s.Stop()
+	assert.Panics(t, func() { s.Register("reports", "@daily", noop) })
+}
@@ -2,8 +2,10 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -30,6 +32,37 @@ type Database struct {
 	MaxOpenConns     int
 	MaxIdleConns     int
 	ConnMaxLifetime  time.Duration
+	// Replicas are optional read replicas, sharing all other settings with the primary.
+	Replicas DatabaseReplicas
+}
+
+// DatabaseReplicas configures read replicas and the routing of read queries to them.
+type DatabaseReplicas struct {
+	// Hosts of the replicas, optionally including the port (`host:port`), the primary's port is used otherwise.
+	Hosts               []string
+	HealthCheckInterval time.Duration
+	HealthCheckTimeout  time.Duration
+	// PrimaryReadWindow sends the reads of a request to the primary for the duration after it wrote.
+	PrimaryReadWindow time.Duration
+}
+
+// Replica returns the configuration of the replica running on host (`host` or `host:port`).
+func (c Database) Replica(host string) (Database, error) {
+	replica := c
+	replica.Replicas = DatabaseReplicas{}
+	replica.Host = host
+
+	if h, p, err := net.SplitHostPort(host); err == nil {
+		port, err := strconv.Atoi(p)
+		if err != nil {
+			return Database{}, fmt.Errorf("invalid port of replica %q: %w", host, err)
+		}
+
+		replica.Host = h
+		replica.Port = port
+	}
+
+	return replica, nil
 }
 
 // ConnectionString generates a connection string to be passed to sql.Open or equivalents, assuming Postgres syntax
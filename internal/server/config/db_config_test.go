@@ -67,3 +67,41 @@ func TestDatabaseConnectionString(t *testing.T) {
 		})
 	}
 }
+
+func TestDatabaseReplica(t *testing.T) {
+	primary := config.Database{
+		Host:     "primary",
+		Port:     5432,
+		Username: "simple",
+		Password: "database_config",
+		Database: "simple_database_config",
+		Replicas: config.DatabaseReplicas{Hosts: []string{"replica", "replica-2:5433"}},
+	}
+
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"replica", "host=replica port=5432 user=simple password=database_config dbname=simple_database_config sslmode=disable"},
+		{"replica-2:5433", "host=replica-2 port=5433 user=simple password=database_config dbname=simple_database_config sslmode=disable"},
+	}
+
+	for _, tt := range tests {
+		replica, err := primary.Replica(tt.host)
+		if err != nil {
+			t.Fatalf("failed to get replica config: %v", err)
+		}
+
+		if got := replica.ConnectionString(); got != tt.want {
+			t.Errorf("invalid connection string, got %q, want %q", got, tt.want)
+		}
+
+		if len(replica.Replicas.Hosts) != 0 {
+			t.Errorf("replica config must not contain replicas, got %v", replica.Replicas.Hosts)
+		}
+	}
+
+	if _, err := primary.Replica("replica:port"); err == nil {
+		t.Error("expected error for invalid port")
+	}
+}
@@ -4,9 +4,15 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"time"
 
+	"github.com/driif/echo-go-starter/internal/mailer/transport"
 	"github.com/driif/echo-go-starter/internal/server/config/env"
+	"github.com/driif/echo-go-starter/pkg/auth/hashing"
+	"github.com/driif/echo-go-starter/pkg/auth/jwt"
+	"github.com/driif/echo-go-starter/pkg/auth/lockout"
+	"github.com/driif/echo-go-starter/pkg/auth/oidc"
 	"github.com/driif/echo-go-starter/pkg/logs"
 	"github.com/driif/echo-go-starter/pkg/tests"
 	"github.com/rs/zerolog"
@@ -26,6 +32,9 @@ type EchoServer struct {
 	EnableSecureMiddleware         bool
 	EnableCacheControlMiddleware   bool
 	SecureMiddleware               EchoServerSecureMiddleware
+	// TrustedProxies are the IPs or CIDR ranges of the reverse proxies whose X-Forwarded-For header determines the
+	// client IP. The remote address of the connection is used if empty.
+	TrustedProxies []string
 }
 
 // PprofServer represents a subset of pprof's config relevant to the app server.
@@ -78,6 +87,141 @@ type LoggerServer struct {
 	PrettyPrintConsole bool
 }
 
+// TokenStrategy selects the kind of access tokens issued.
+type TokenStrategy string
+
+const (
+	// TokenStrategyOpaque issues random tokens persisted in the access_tokens table.
+	TokenStrategyOpaque TokenStrategy = "opaque"
+	// TokenStrategyJWT issues short-lived signed tokens, which are verified without hitting the database.
+	TokenStrategyJWT TokenStrategy = "jwt"
+)
+
+func (t TokenStrategy) String() string {
+	return string(t)
+}
+
+// AuthServer represents a subset of auth config relevant to the app server.
+type AuthServer struct {
+	TokenStrategy TokenStrategy
+	// JWT is used to issue access tokens with TokenStrategyJWT, but also to verify them with TokenStrategyOpaque
+	// if secrets are configured, so both kinds of tokens are accepted while migrating between strategies.
+	JWT                 jwt.Config
+	AccessTokenValidity time.Duration
+	// RefreshTokenValidity is the maximum age of refresh tokens, as they're rotated on use it limits the inactivity of sessions.
+	RefreshTokenValidity time.Duration
+	DefaultUserScopes    []string
+	// RegistrationRequiresVerification keeps newly registered users inactive until they confirmed their email address.
+	RegistrationRequiresVerification bool
+	EmailVerificationTokenValidity   time.Duration
+	PasswordResetTokenValidity       time.Duration
+	Attempts                         AuthAttempts
+	MFA                              AuthMFA
+	APIKeyMaxPerUser                 int
+	OIDC                             AuthOIDC
+}
+
+// AuthOIDC configures login via external OpenID Connect providers.
+type AuthOIDC struct {
+	Providers []oidc.ProviderConfig
+	// AutoProvision creates users for unknown identities, otherwise identities have to be linked to existing users.
+	AutoProvision     bool
+	DefaultUserScopes []string
+	// AuthRequestValidity limits the time between starting the login and completing it with the provider's callback.
+	AuthRequestValidity time.Duration
+}
+
+// AuthMFA configures two-factor authentication via TOTP.
+type AuthMFA struct {
+	// Issuer is shown in authenticator apps.
+	Issuer string
+	// RequiredScopes force users possessing any of these scopes to complete (and, if not yet enrolled, set up) 2FA on login.
+	RequiredScopes []string
+	// TokenValidity of the MFA token issued after verifying the password.
+	TokenValidity time.Duration
+	// TokenMaxAttempts invalidates the MFA token after the given number of wrong codes.
+	TokenMaxAttempts  int
+	RecoveryCodeCount int
+}
+
+// AuthAttempts configures the throttling of failed login, forgot-password and resend verification attempts,
+// tracked separately per username and per client IP.
+type AuthAttempts struct {
+	Enabled  bool
+	Username lockout.Policy
+	IP       lockout.Policy
+}
+
+// Cleanup configures the periodic deletion of expired tokens and other stale auth state.
+type Cleanup struct {
+	Enabled  bool
+	Interval time.Duration
+	// Jitter delays each run randomly by up to the given duration, so multiple replicas don't run simultaneously.
+	Jitter time.Duration
+	// BatchSize limits the rows deleted per statement to keep locks and transactions short.
+	BatchSize int
+}
+
+// Jobs configures the worker processing background jobs.
+type Jobs struct {
+	Enabled      bool
+	Concurrency  int
+	PollInterval time.Duration
+	// Lease limits the duration of a job, jobs still running afterwards are considered crashed and picked up again.
+	Lease time.Duration
+	// BackoffBase is the delay of the first retry, doubled for every further attempt up to BackoffMax.
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+}
+
+// Outbox configures the relay publishing messages written to the transactional outbox.
+type Outbox struct {
+	Enabled      bool
+	PollInterval time.Duration
+	// BatchSize limits the messages claimed per transaction, at most one per aggregate key. Claimed messages stay
+	// locked within the transaction until all of them are published.
+	BatchSize int
+	// MaxAttempts limits the attempts before a message is moved to the dead state.
+	MaxAttempts int
+	// BackoffBase is the delay of the first retry, doubled for every further attempt up to BackoffMax.
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+}
+
+// Scheduler configures the periodic tasks executed once cluster-wide.
+type Scheduler struct {
+	Enabled bool
+	// Timezone cron expressions are evaluated in, e.g. "Europe/Vienna".
+	Timezone string
+}
+
+// MailerTransporter selects the transport used to send mails.
+type MailerTransporter string
+
+const (
+	MailerTransporterMock MailerTransporter = "mock"
+	MailerTransporterSMTP MailerTransporter = "smtp"
+)
+
+func (t MailerTransporter) String() string {
+	return string(t)
+}
+
+// Mailer represents a subset of mailer config relevant to the app server.
+type Mailer struct {
+	DefaultSender               string
+	Send                        bool
+	WebTemplatesEmailBaseDirAbs string
+	Transporter                 MailerTransporter
+}
+
+// FrontendServer represents a subset of frontend config relevant to the app server, e.g. used for links within mails.
+type FrontendServer struct {
+	BaseURL                   string
+	PasswordResetEndpoint     string
+	EmailVerificationEndpoint string
+}
+
 // Server represents the config of the Server relevant to the app server, containing all the other config structs.
 type Server struct {
 	Database   Database
@@ -85,10 +229,16 @@ type Server struct {
 	Pprof      PprofServer
 	Paths      PathsServer
 	Management ManagementServer
-	//Mailer     Mailer
-	//SMTP       transport.SMTPMailTransportConfig
-	//Frontend   FrontendServer
-	Logger LoggerServer
+	Auth       AuthServer
+	Cleanup    Cleanup
+	Jobs       Jobs
+	Scheduler  Scheduler
+	Outbox     Outbox
+	Hashing    hashing.Config
+	Mailer     Mailer
+	SMTP       transport.SMTPMailTransportConfig
+	Frontend   FrontendServer
+	Logger     LoggerServer
 	//Push       PushService
 	//FCMConfig  provider.FCMConfig
 }
@@ -126,6 +276,12 @@ func DefaultServiceConfigFromEnv() Server {
 			MaxOpenConns:    env.GetEnvAsInt("DB_MAX_OPEN_CONNS", runtime.NumCPU()*2),
 			MaxIdleConns:    env.GetEnvAsInt("DB_MAX_IDLE_CONNS", 1),
 			ConnMaxLifetime: time.Second * time.Duration(env.GetEnvAsInt("DB_CONN_MAX_LIFETIME_SEC", 60)),
+			Replicas: DatabaseReplicas{
+				Hosts:               env.GetEnvAsStringArrTrimmed("PGREPLICA_HOSTS", []string{}),
+				HealthCheckInterval: time.Second * time.Duration(env.GetEnvAsInt("DB_REPLICA_HEALTH_CHECK_INTERVAL_SEC", 10)),
+				HealthCheckTimeout:  time.Second * time.Duration(env.GetEnvAsInt("DB_REPLICA_HEALTH_CHECK_TIMEOUT_SEC", 2)),
+				PrimaryReadWindow:   time.Millisecond * time.Duration(env.GetEnvAsInt("DB_REPLICA_PRIMARY_READ_WINDOW_MS", 2000)),
+			},
 		},
 		Echo: EchoServer{
 			Debug:                          env.GetEnvAsBool("SERVER_ECHO_DEBUG", false),
@@ -139,6 +295,7 @@ func DefaultServiceConfigFromEnv() Server {
 			EnableTrailingSlashMiddleware:  env.GetEnvAsBool("SERVER_ECHO_ENABLE_TRAILING_SLASH_MIDDLEWARE", true),
 			EnableSecureMiddleware:         env.GetEnvAsBool("SERVER_ECHO_ENABLE_SECURE_MIDDLEWARE", true),
 			EnableCacheControlMiddleware:   env.GetEnvAsBool("SERVER_ECHO_ENABLE_CACHE_CONTROL_MIDDLEWARE", true),
+			TrustedProxies:                 env.GetEnvAsStringArrTrimmed("SERVER_ECHO_TRUSTED_PROXIES", []string{}),
 			// see https://echo.labstack.com/middleware/secure
 			// see https://github.com/labstack/echo/blob/master/middleware/secure.go
 			SecureMiddleware: EchoServerSecureMiddleware{
@@ -174,6 +331,119 @@ func DefaultServiceConfigFromEnv() Server {
 				filepath.Join(env.GetProjectRootDir(), "/assets/mnt")}, ","),
 			ProbeWriteableTouchfile: env.GetEnv("SERVER_MANAGEMENT_PROBE_WRITEABLE_TOUCHFILE", ".healthy"),
 		},
+		Auth: AuthServer{
+			TokenStrategy: TokenStrategy(env.GetEnvEnum("SERVER_AUTH_TOKEN_STRATEGY", TokenStrategyOpaque.String(),
+				[]string{TokenStrategyOpaque.String(), TokenStrategyJWT.String()})),
+			JWT: jwt.Config{
+				Algorithm: jwt.Algorithm(env.GetEnvEnum("SERVER_AUTH_JWT_ALGORITHM", jwt.AlgorithmEdDSA.String(),
+					[]string{jwt.AlgorithmEdDSA.String(), jwt.AlgorithmHS256.String()})),
+				// the first secret signs new tokens, append previous secrets to keep their tokens valid while rotating
+				Secrets:  env.GetEnvAsStringArrTrimmed("SERVER_AUTH_JWT_SECRETS", []string{}),
+				Issuer:   env.GetEnv("SERVER_AUTH_JWT_ISSUER", ModuleName),
+				Validity: time.Second * time.Duration(env.GetEnvAsInt("SERVER_AUTH_JWT_VALIDITY_SEC", 900)),
+			},
+			AccessTokenValidity:              time.Second * time.Duration(env.GetEnvAsInt("SERVER_AUTH_ACCESS_TOKEN_VALIDITY", 86400)),
+			RefreshTokenValidity:             time.Second * time.Duration(env.GetEnvAsInt("SERVER_AUTH_REFRESH_TOKEN_VALIDITY", 2592000)),
+			DefaultUserScopes:                env.GetEnvAsStringArrTrimmed("SERVER_AUTH_DEFAULT_USER_SCOPES", []string{"app"}),
+			RegistrationRequiresVerification: env.GetEnvAsBool("SERVER_AUTH_REGISTRATION_REQUIRES_VERIFICATION", false),
+			EmailVerificationTokenValidity:   time.Second * time.Duration(env.GetEnvAsInt("SERVER_AUTH_EMAIL_VERIFICATION_TOKEN_VALIDITY", 86400)),
+			PasswordResetTokenValidity:       time.Second * time.Duration(env.GetEnvAsInt("SERVER_AUTH_PASSWORD_RESET_TOKEN_VALIDITY", 3600)),
+			APIKeyMaxPerUser:                 env.GetEnvAsInt("SERVER_AUTH_API_KEY_MAX_PER_USER", 25),
+			OIDC: AuthOIDC{
+				Providers:           oidcProvidersFromEnv(),
+				AutoProvision:       env.GetEnvAsBool("SERVER_AUTH_OIDC_AUTO_PROVISION", true),
+				DefaultUserScopes:   env.GetEnvAsStringArrTrimmed("SERVER_AUTH_OIDC_DEFAULT_USER_SCOPES", []string{"app"}),
+				AuthRequestValidity: time.Second * time.Duration(env.GetEnvAsInt("SERVER_AUTH_OIDC_AUTH_REQUEST_VALIDITY_SEC", 600)),
+			},
+			MFA: AuthMFA{
+				Issuer:            env.GetEnv("SERVER_AUTH_MFA_ISSUER", "echo-go-starter"),
+				RequiredScopes:    env.GetEnvAsStringArrTrimmed("SERVER_AUTH_MFA_REQUIRED_SCOPES", []string{}),
+				TokenValidity:     time.Second * time.Duration(env.GetEnvAsInt("SERVER_AUTH_MFA_TOKEN_VALIDITY_SEC", 300)),
+				TokenMaxAttempts:  env.GetEnvAsInt("SERVER_AUTH_MFA_TOKEN_MAX_ATTEMPTS", 5),
+				RecoveryCodeCount: env.GetEnvAsInt("SERVER_AUTH_MFA_RECOVERY_CODE_COUNT", 10),
+			},
+			Attempts: AuthAttempts{
+				Enabled: env.GetEnvAsBool("SERVER_AUTH_ATTEMPTS_ENABLED", true),
+				Username: lockout.Policy{
+					FreeAttempts:     env.GetEnvAsInt("SERVER_AUTH_ATTEMPTS_USERNAME_FREE_ATTEMPTS", 3),
+					BaseDelay:        time.Second * time.Duration(env.GetEnvAsInt("SERVER_AUTH_ATTEMPTS_USERNAME_BASE_DELAY_SEC", 1)),
+					MaxDelay:         time.Second * time.Duration(env.GetEnvAsInt("SERVER_AUTH_ATTEMPTS_USERNAME_MAX_DELAY_SEC", 60)),
+					LockoutThreshold: env.GetEnvAsInt("SERVER_AUTH_ATTEMPTS_USERNAME_LOCKOUT_THRESHOLD", 10),
+					LockoutDuration:  time.Second * time.Duration(env.GetEnvAsInt("SERVER_AUTH_ATTEMPTS_USERNAME_LOCKOUT_DURATION_SEC", 900)),
+					ResetAfter:       time.Second * time.Duration(env.GetEnvAsInt("SERVER_AUTH_ATTEMPTS_USERNAME_RESET_AFTER_SEC", 3600)),
+				},
+				// clients behind NAT or proxies share an IP, so it tolerates considerably more failures
+				IP: lockout.Policy{
+					FreeAttempts:     env.GetEnvAsInt("SERVER_AUTH_ATTEMPTS_IP_FREE_ATTEMPTS", 20),
+					BaseDelay:        time.Second * time.Duration(env.GetEnvAsInt("SERVER_AUTH_ATTEMPTS_IP_BASE_DELAY_SEC", 1)),
+					MaxDelay:         time.Second * time.Duration(env.GetEnvAsInt("SERVER_AUTH_ATTEMPTS_IP_MAX_DELAY_SEC", 30)),
+					LockoutThreshold: env.GetEnvAsInt("SERVER_AUTH_ATTEMPTS_IP_LOCKOUT_THRESHOLD", 100),
+					LockoutDuration:  time.Second * time.Duration(env.GetEnvAsInt("SERVER_AUTH_ATTEMPTS_IP_LOCKOUT_DURATION_SEC", 900)),
+					ResetAfter:       time.Second * time.Duration(env.GetEnvAsInt("SERVER_AUTH_ATTEMPTS_IP_RESET_AFTER_SEC", 3600)),
+				},
+			},
+		},
+		Cleanup: Cleanup{
+			Enabled:   env.GetEnvAsBool("SERVER_CLEANUP_ENABLED", true),
+			Interval:  time.Second * time.Duration(env.GetEnvAsInt("SERVER_CLEANUP_INTERVAL_SEC", 3600)),
+			Jitter:    time.Second * time.Duration(env.GetEnvAsInt("SERVER_CLEANUP_JITTER_SEC", 300)),
+			BatchSize: env.GetEnvAsInt("SERVER_CLEANUP_BATCH_SIZE", 1000),
+		},
+		Jobs: Jobs{
+			Enabled:      env.GetEnvAsBool("SERVER_JOBS_ENABLED", true),
+			Concurrency:  env.GetEnvAsInt("SERVER_JOBS_CONCURRENCY", 4),
+			PollInterval: time.Millisecond * time.Duration(env.GetEnvAsInt("SERVER_JOBS_POLL_INTERVAL_MS", 1000)),
+			Lease:        time.Second * time.Duration(env.GetEnvAsInt("SERVER_JOBS_LEASE_SEC", 300)),
+			BackoffBase:  time.Second * time.Duration(env.GetEnvAsInt("SERVER_JOBS_BACKOFF_BASE_SEC", 10)),
+			BackoffMax:   time.Second * time.Duration(env.GetEnvAsInt("SERVER_JOBS_BACKOFF_MAX_SEC", 21600)),
+		},
+		Scheduler: Scheduler{
+			Enabled:  env.GetEnvAsBool("SERVER_SCHEDULER_ENABLED", true),
+			Timezone: env.GetEnv("SERVER_SCHEDULER_TIMEZONE", "UTC"),
+		},
+		Outbox: Outbox{
+			Enabled:      env.GetEnvAsBool("SERVER_OUTBOX_ENABLED", true),
+			PollInterval: time.Millisecond * time.Duration(env.GetEnvAsInt("SERVER_OUTBOX_POLL_INTERVAL_MS", 500)),
+			BatchSize:    env.GetEnvAsInt("SERVER_OUTBOX_BATCH_SIZE", 10),
+			MaxAttempts:  env.GetEnvAsInt("SERVER_OUTBOX_MAX_ATTEMPTS", 10),
+			BackoffBase:  time.Second * time.Duration(env.GetEnvAsInt("SERVER_OUTBOX_BACKOFF_BASE_SEC", 1)),
+			BackoffMax:   time.Second * time.Duration(env.GetEnvAsInt("SERVER_OUTBOX_BACKOFF_MAX_SEC", 300)),
+		},
+		Hashing: hashing.Config{
+			Algorithm: hashing.Algorithm(env.GetEnvEnum("SERVER_HASHING_ALGORITHM", hashing.DefaultConfig.Algorithm.String(),
+				[]string{hashing.AlgorithmArgon2id.String(), hashing.AlgorithmBcrypt.String()})),
+			// https://datatracker.ietf.org/doc/html/rfc9106#section-4
+			Argon2: hashing.Argon2Params{
+				Time:    env.GetEnvAsUint32("SERVER_HASHING_ARGON2_TIME", hashing.DefaultConfig.Argon2.Time),
+				Memory:  env.GetEnvAsUint32("SERVER_HASHING_ARGON2_MEMORY_KIB", hashing.DefaultConfig.Argon2.Memory),
+				Threads: env.GetEnvAsUint8("SERVER_HASHING_ARGON2_THREADS", hashing.DefaultConfig.Argon2.Threads),
+				KeyLen:  env.GetEnvAsUint32("SERVER_HASHING_ARGON2_KEY_LEN", hashing.DefaultConfig.Argon2.KeyLen),
+				SaltLen: env.GetEnvAsUint32("SERVER_HASHING_ARGON2_SALT_LEN", hashing.DefaultConfig.Argon2.SaltLen),
+			},
+			BcryptCost: env.GetEnvAsInt("SERVER_HASHING_BCRYPT_COST", hashing.DefaultConfig.BcryptCost),
+		},
+		Mailer: Mailer{
+			DefaultSender:               env.GetEnv("SERVER_MAILER_DEFAULT_SENDER", "go-starter@example.com"),
+			Send:                        env.GetEnvAsBool("SERVER_MAILER_SEND", true),
+			WebTemplatesEmailBaseDirAbs: env.GetEnv("SERVER_MAILER_WEB_TEMPLATES_EMAIL_BASE_DIR_ABS", filepath.Join(env.GetProjectRootDir(), "/web/templates/email")), // /app/web/templates/email
+			Transporter: MailerTransporter(env.GetEnvEnum("SERVER_MAILER_TRANSPORTER", MailerTransporterMock.String(),
+				[]string{MailerTransporterMock.String(), MailerTransporterSMTP.String()})),
+		},
+		SMTP: transport.SMTPMailTransportConfig{
+			Host:     env.GetEnv("SERVER_SMTP_HOST", "localhost"),
+			Port:     env.GetEnvAsInt("SERVER_SMTP_PORT", 1025),
+			Username: env.GetEnv("SERVER_SMTP_USERNAME", ""),
+			Password: env.GetEnv("SERVER_SMTP_PASSWORD", ""),
+			AuthType: transport.SMTPAuthType(env.GetEnvEnum("SERVER_SMTP_AUTH_TYPE", transport.SMTPAuthTypeNone.String(),
+				[]string{transport.SMTPAuthTypeNone.String(), transport.SMTPAuthTypePlain.String(), transport.SMTPAuthTypeCRAMMD5.String()})),
+			Encryption: transport.SMTPEncryption(env.GetEnvEnum("SERVER_SMTP_ENCRYPTION", transport.SMTPEncryptionNone.String(),
+				[]string{transport.SMTPEncryptionNone.String(), transport.SMTPEncryptionTLS.String(), transport.SMTPEncryptionStartTLS.String()})),
+		},
+		Frontend: FrontendServer{
+			BaseURL:                   env.GetEnv("SERVER_FRONTEND_BASE_URL", "http://localhost:3000"),
+			PasswordResetEndpoint:     env.GetEnv("SERVER_FRONTEND_PASSWORD_RESET_ENDPOINT", "/set-new-password"),
+			EmailVerificationEndpoint: env.GetEnv("SERVER_FRONTEND_EMAIL_VERIFICATION_ENDPOINT", "/verify-email"),
+		},
 		Logger: LoggerServer{
 			Level:              logs.LogLevelFromString(env.GetEnv("SERVER_LOGGER_LEVEL", zerolog.DebugLevel.String())),
 			RequestLevel:       logs.LogLevelFromString(env.GetEnv("SERVER_LOGGER_REQUEST_LEVEL", zerolog.DebugLevel.String())),
@@ -187,3 +457,26 @@ func DefaultServiceConfigFromEnv() Server {
 	}
 
 }
+
+// oidcProvidersFromEnv reads the providers listed in SERVER_AUTH_OIDC_PROVIDERS, each configured via
+// SERVER_AUTH_OIDC_<NAME>_* with the name uppercased and dashes replaced by underscores.
+func oidcProvidersFromEnv() []oidc.ProviderConfig {
+	names := env.GetEnvAsStringArrTrimmed("SERVER_AUTH_OIDC_PROVIDERS", []string{})
+	providers := make([]oidc.ProviderConfig, 0, len(names))
+
+	for _, name := range names {
+		prefix := "SERVER_AUTH_OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+
+		providers = append(providers, oidc.ProviderConfig{
+			Name:         name,
+			Issuer:       env.GetEnv(prefix+"ISSUER", ""),
+			ClientID:     env.GetEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: env.GetEnv(prefix+"CLIENT_SECRET", ""),
+			RedirectURL:  env.GetEnv(prefix+"REDIRECT_URL", ""),
+			Scopes:       env.GetEnvAsStringArrTrimmed(prefix+"SCOPES", []string{"openid", "email", "profile"}),
+			LinkByEmail:  env.GetEnvAsBool(prefix+"LINK_BY_EMAIL", false),
+		})
+	}
+
+	return providers
+}
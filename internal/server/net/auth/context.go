@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/pkg/auth/jwt"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/driif/echo-go-starter/pkg/slices"
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// UserFromContext returns the user authenticated for the request, or nil if the request is unauthenticated.
+func UserFromContext(ctx context.Context) *models.User {
+	u, ok := ctx.Value(logs.CTXKeyUser).(*models.User)
+	if !ok {
+		return nil
+	}
+
+	return u
+}
+
+// UserFromEchoContext returns the user authenticated for the request, or nil if the request is unauthenticated.
+func UserFromEchoContext(c echo.Context) *models.User {
+	return UserFromContext(c.Request().Context())
+}
+
+// AccessTokenFromContext returns the access token used to authenticate the request, or nil if the request is unauthenticated.
+func AccessTokenFromContext(ctx context.Context) *models.AccessToken {
+	t, ok := ctx.Value(logs.CTXKeyAccessToken).(*models.AccessToken)
+	if !ok {
+		return nil
+	}
+
+	return t
+}
+
+// AccessTokenFromEchoContext returns the access token used to authenticate the request, or nil if the request is unauthenticated.
+func AccessTokenFromEchoContext(c echo.Context) *models.AccessToken {
+	return AccessTokenFromContext(c.Request().Context())
+}
+
+// TokenClaimsFromContext returns the claims of the JWT used to authenticate the request,
+// or nil if the request is unauthenticated or was authenticated via an opaque access token.
+func TokenClaimsFromContext(ctx context.Context) *jwt.Claims {
+	c, ok := ctx.Value(logs.CTXKeyTokenClaims).(*jwt.Claims)
+	if !ok {
+		return nil
+	}
+
+	return c
+}
+
+// APIKeyFromContext returns the API key used to authenticate the request, or nil if the request is unauthenticated
+// or was authenticated via access token.
+func APIKeyFromContext(ctx context.Context) *models.APIKey {
+	k, ok := ctx.Value(logs.CTXKeyAPIKey).(*models.APIKey)
+	if !ok {
+		return nil
+	}
+
+	return k
+}
+
+// LoadUserFromContext returns the authenticated user with all columns. Users authenticated via JWT are
+// constructed from the token's claims (ID, username and scopes only) and thus loaded from the database.
+func LoadUserFromContext(ctx context.Context, exec boil.ContextExecutor) (*models.User, error) {
+	user := UserFromContext(ctx)
+	if user == nil || TokenClaimsFromContext(ctx) == nil {
+		return user, nil
+	}
+
+	return models.FindUser(ctx, exec, user.ID)
+}
+
+// HasScopes checks whether all scopes provided are part of the user's scopes.
+func HasScopes(user *models.User, scopes ...Scope) bool {
+	if user == nil {
+		return false
+	}
+
+	s := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		s = append(s, scope.String())
+	}
+
+	return slices.ContainsAllString(user.Scopes, s...)
+}
@@ -0,0 +1,12 @@
+package auth
+
+const (
+	PasswordMinLength = 8
+	// PasswordMaxLength in bytes, bcrypt only considers the first 72 bytes of a password.
+	PasswordMaxLength = 72
+)
+
+// ValidPasswordLength checks whether the password satisfies our length requirements.
+func ValidPasswordLength(password string) bool {
+	return len(password) >= PasswordMinLength && len(password) <= PasswordMaxLength
+}
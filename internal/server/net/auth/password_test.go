@@ -0,0 +1,15 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/driif/echo-go-starter/internal/server/net/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidPasswordLength(t *testing.T) {
+	assert.False(t, auth.ValidPasswordLength("short"))
+	assert.True(t, auth.ValidPasswordLength("t3stp4ssw0rd"))
+	assert.False(t, auth.ValidPasswordLength(strings.Repeat("a", auth.PasswordMaxLength+1)))
+}
@@ -1,9 +1,15 @@
 package auth
 
+import (
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/pkg/slices"
+)
+
 type Scope string
 
 const (
 	AuthScopeApp        Scope = "app"
+	AuthScopeCMS        Scope = "cms"
 	AuthScopeAdmin      Scope = "admin"
 	AuthScopeSuperAdmin Scope = "superadmin"
 )
@@ -11,3 +17,43 @@ const (
 func (s Scope) String() string {
 	return string(s)
 }
+
+// KnownScopes returns all scopes which may be assigned to users.
+func KnownScopes() []Scope {
+	return []Scope{AuthScopeApp, AuthScopeCMS, AuthScopeAdmin, AuthScopeSuperAdmin}
+}
+
+// IsKnownScope checks whether the scope provided is one of KnownScopes.
+func IsKnownScope(scope string) bool {
+	for _, s := range KnownScopes() {
+		if s.String() == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CanGrantScopes checks whether the actor may assign (or revoke) the scopes provided. Superadmins may
+// assign all scopes, everybody else only the scopes they possess themselves.
+func CanGrantScopes(actor *models.User, scopes ...string) bool {
+	if actor == nil {
+		return false
+	}
+
+	if HasScopes(actor, AuthScopeSuperAdmin) {
+		return true
+	}
+
+	return slices.ContainsAllString(actor.Scopes, scopes...)
+}
+
+// CanManageUser checks whether the actor may modify the target user, which requires the actor
+// to be able to grant all of the target's scopes, see CanGrantScopes.
+func CanManageUser(actor *models.User, target *models.User) bool {
+	if target == nil {
+		return false
+	}
+
+	return CanGrantScopes(actor, target.Scopes...)
+}
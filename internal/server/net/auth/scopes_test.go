@@ -0,0 +1,40 @@
+package auth_test
+
+import (
+	"testing"
+
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server/net/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsKnownScope(t *testing.T) {
+	assert.True(t, auth.IsKnownScope("cms"))
+	assert.True(t, auth.IsKnownScope("superadmin"))
+	assert.False(t, auth.IsKnownScope("root"))
+	assert.False(t, auth.IsKnownScope(""))
+}
+
+func TestCanGrantScopes(t *testing.T) {
+	admin := &models.User{Scopes: []string{"cms", "admin"}}
+	superadmin := &models.User{Scopes: []string{"superadmin"}}
+
+	assert.True(t, auth.CanGrantScopes(admin))
+	assert.True(t, auth.CanGrantScopes(admin, "cms"))
+	assert.True(t, auth.CanGrantScopes(admin, "cms", "admin"))
+	assert.False(t, auth.CanGrantScopes(admin, "app"))
+	assert.False(t, auth.CanGrantScopes(admin, "cms", "superadmin"))
+	assert.True(t, auth.CanGrantScopes(superadmin, "app", "cms", "admin", "superadmin"))
+	assert.False(t, auth.CanGrantScopes(nil, "app"))
+}
+
+func TestCanManageUser(t *testing.T) {
+	admin := &models.User{Scopes: []string{"cms", "admin"}}
+	superadmin := &models.User{Scopes: []string{"cms", "admin", "superadmin"}}
+
+	assert.True(t, auth.CanManageUser(admin, &models.User{Scopes: []string{"cms"}}))
+	assert.False(t, auth.CanManageUser(admin, &models.User{Scopes: []string{"app"}}))
+	assert.False(t, auth.CanManageUser(admin, superadmin))
+	assert.True(t, auth.CanManageUser(superadmin, admin))
+	assert.False(t, auth.CanManageUser(admin, nil))
+}
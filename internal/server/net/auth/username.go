@@ -0,0 +1,19 @@
+package auth
+
+import (
+	"net/mail"
+)
+
+// UsernameMaxLength matches the length of users.username.
+const UsernameMaxLength = 255
+
+// ValidUsername checks whether the (normalized, see strs.ToUsernameFormat) username is a plain email address.
+func ValidUsername(username string) bool {
+	if len(username) == 0 || len(username) > UsernameMaxLength {
+		return false
+	}
+
+	addr, err := mail.ParseAddress(username)
+
+	return err == nil && addr.Address == username
+}
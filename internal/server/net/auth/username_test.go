@@ -0,0 +1,17 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/driif/echo-go-starter/internal/server/net/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidUsername(t *testing.T) {
+	assert.True(t, auth.ValidUsername("user1@example.com"))
+	assert.False(t, auth.ValidUsername(""))
+	assert.False(t, auth.ValidUsername("user1"))
+	assert.False(t, auth.ValidUsername("User <user1@example.com>"))
+	assert.False(t, auth.ValidUsername(strings.Repeat("a", auth.UsernameMaxLength)+"@example.com"))
+}
@@ -0,0 +1,297 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/internal/server/net/auth"
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+	"github.com/driif/echo-go-starter/pkg/auth/apikey"
+	"github.com/driif/echo-go-starter/pkg/auth/jwt"
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/driif/echo-go-starter/pkg/slices"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// AuthMode controls how the auth middleware treats requests with or without credentials.
+type AuthMode int
+
+const (
+	// AuthModeNone skips authentication completely.
+	AuthModeNone AuthMode = iota
+	// AuthModeRequired requires a valid access token, rejecting all unauthenticated requests.
+	AuthModeRequired
+	// AuthModeOptional validates the access token if one is provided, but allows unauthenticated requests.
+	AuthModeOptional
+)
+
+// String returns the string representation of the auth mode.
+func (m AuthMode) String() string {
+	switch m {
+	case AuthModeRequired:
+		return "required"
+	case AuthModeOptional:
+		return "optional"
+	default:
+		return "none"
+	}
+}
+
+const (
+	authScheme = "Bearer"
+	// HeaderAPIKey carries API keys, it's only considered if no Authorization header is provided.
+	HeaderAPIKey = "X-API-Key"
+
+	apiKeyLastUsedPrecision = time.Minute
+)
+
+var (
+	ErrAuthTokenMissing    = errs.NewHTTPError(http.StatusUnauthorized, "TOKEN_MISSING", "Authentication token is missing.")
+	ErrAuthTokenInvalid    = errs.NewHTTPError(http.StatusUnauthorized, "TOKEN_INVALID", "Authentication token is invalid or expired.")
+	ErrAuthUserDeactivated = errs.NewHTTPError(http.StatusForbidden, "USER_DEACTIVATED", "User account is deactivated.")
+	ErrAuthMissingScopes   = errs.NewHTTPError(http.StatusForbidden, "MISSING_SCOPES", "User is lacking the required scopes to access this resource.")
+
+	errAuthMalformedHeader = errors.New("malformed authorization header")
+)
+
+var (
+	DefaultAuthConfig = AuthConfig{
+		Skipper: middleware.DefaultSkipper,
+		Mode:    AuthModeRequired,
+	}
+)
+
+// AuthConfig defines the config for the auth middleware.
+type AuthConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper middleware.Skipper
+	// Mode controls whether authentication is required, optional or skipped.
+	Mode AuthMode
+	// Scopes the authenticated user must possess (all of them).
+	Scopes []auth.Scope
+	// DB is used to look up opaque access tokens.
+	DB *sql.DB
+	// JWT verifies signed access tokens, JWTs are rejected if nil.
+	JWT *jwt.KeySet
+}
+
+// Auth returns an auth middleware requiring a valid access token.
+func Auth(db *sql.DB) echo.MiddlewareFunc {
+	c := DefaultAuthConfig
+	c.DB = db
+	return AuthWithConfig(c)
+}
+
+// AuthWithConfig returns an auth middleware with config.
+//
+// The access token is expected as `Authorization: Bearer <token>` header, either as opaque UUID or as JWT,
+// alternatively an API key is accepted via `X-API-Key` header. Once validated, the authenticated *models.User
+// and *models.AccessToken (opaque), *jwt.Claims (JWT) or *models.APIKey are stored in the request's context, see
+// auth.UserFromContext, auth.AccessTokenFromContext, auth.TokenClaimsFromContext and auth.APIKeyFromContext.
+// JWTs are verified without hitting the database, so deactivations and scope changes only apply once they expire.
+func AuthWithConfig(config AuthConfig) echo.MiddlewareFunc {
+	if config.Skipper == nil {
+		config.Skipper = DefaultAuthConfig.Skipper
+	}
+	if config.DB == nil && config.Mode != AuthModeNone {
+		panic("auth middleware requires a database")
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if config.Mode == AuthModeNone || config.Skipper(c) {
+				return next(c)
+			}
+
+			header := c.Request().Header.Get(echo.HeaderAuthorization)
+			apiKeyHeader := c.Request().Header.Get(HeaderAPIKey)
+			if len(header) == 0 && len(apiKeyHeader) == 0 {
+				if config.Mode == AuthModeOptional {
+					return next(c)
+				}
+
+				return ErrAuthTokenMissing
+			}
+
+			var (
+				a   authentication
+				err error
+			)
+			if len(header) > 0 {
+				a, err = authenticateToken(c, config, header)
+			} else {
+				a, err = authenticateAPIKey(c, config, apiKeyHeader)
+			}
+			if err != nil {
+				return err
+			}
+
+			if !a.user.IsActive {
+				logs.LogFromEchoContext(c).Debug().Str("userID", a.user.ID).Msg("User is deactivated, rejecting request")
+				return ErrAuthUserDeactivated
+			}
+
+			if !auth.HasScopes(a.user, config.Scopes...) {
+				logs.LogFromEchoContext(c).Debug().Str("userID", a.user.ID).Strs("userScopes", a.user.Scopes).Msg("User is lacking required scopes, rejecting request")
+				return ErrAuthMissingScopes
+			}
+
+			c.SetRequest(c.Request().WithContext(authenticatedContext(c.Request().Context(), a)))
+
+			return next(c)
+		}
+	}
+}
+
+// authentication holds the authenticated user and the credentials used.
+type authentication struct {
+	user        *models.User
+	accessToken *models.AccessToken
+	claims      *jwt.Claims
+	apiKey      *models.APIKey
+}
+
+// authenticateToken validates an opaque access token or JWT provided via Authorization header.
+func authenticateToken(c echo.Context, config AuthConfig, header string) (authentication, error) {
+	token, err := tokenFromHeader(header)
+	if err != nil {
+		logs.LogFromEchoContext(c).Trace().Err(err).Msg("Failed to extract access token from request")
+		return authentication{}, ErrAuthTokenInvalid
+	}
+
+	if _, err := uuid.Parse(token); err == nil {
+		accessToken, err := models.AccessTokens(
+			models.AccessTokenWhere.Token.EQ(token),
+			models.AccessTokenWhere.ValidUntil.GT(time.Now()),
+			qm.Load(models.AccessTokenRels.User),
+		).One(c.Request().Context(), config.DB)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				logs.LogFromEchoContext(c).Trace().Msg("Access token not found or expired")
+				return authentication{}, ErrAuthTokenInvalid
+			}
+
+			logs.LogFromEchoContext(c).Error().Err(err).Msg("Failed to load access token")
+			return authentication{}, err
+		}
+
+		return authentication{user: accessToken.R.User, accessToken: accessToken}, nil
+	}
+
+	if config.JWT == nil || !jwt.LooksLikeJWT(token) {
+		logs.LogFromEchoContext(c).Trace().Msg("Access token is neither a UUID nor an accepted JWT")
+		return authentication{}, ErrAuthTokenInvalid
+	}
+
+	claims, err := config.JWT.Parse(token)
+	if err != nil {
+		logs.LogFromEchoContext(c).Trace().Err(err).Msg("Failed to verify JWT")
+		return authentication{}, ErrAuthTokenInvalid
+	}
+
+	user := &models.User{
+		ID:       claims.Subject,
+		Username: null.NewString(claims.Username, len(claims.Username) > 0),
+		IsActive: true,
+		Scopes:   claims.Scopes,
+	}
+
+	return authentication{user: user, claims: claims}, nil
+}
+
+// authenticateAPIKey validates an API key provided via X-API-Key header. The user's scopes are
+// restricted to the scopes of the key, the key's last usage is recorded with minute precision.
+func authenticateAPIKey(c echo.Context, config AuthConfig, header string) (authentication, error) {
+	ctx := c.Request().Context()
+
+	prefix, secret, err := apikey.Parse(header)
+	if err != nil {
+		logs.LogFromEchoContext(c).Trace().Err(err).Msg("Failed to parse API key")
+		return authentication{}, ErrAuthTokenInvalid
+	}
+
+	key, err := models.APIKeys(
+		models.APIKeyWhere.Prefix.EQ(prefix),
+		qm.Load(models.APIKeyRels.User),
+	).One(ctx, config.DB)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logs.LogFromEchoContext(c).Trace().Msg("API key not found")
+			return authentication{}, ErrAuthTokenInvalid
+		}
+
+		logs.LogFromEchoContext(c).Error().Err(err).Msg("Failed to load API key")
+		return authentication{}, err
+	}
+
+	now := time.Now()
+	if !apikey.Verify(secret, key.SecretHash) || (key.ExpiresAt.Valid && !now.Before(key.ExpiresAt.Time)) {
+		logs.LogFromEchoContext(c).Trace().Str("apiKeyID", key.ID).Msg("API key secret mismatch or expired")
+		return authentication{}, ErrAuthTokenInvalid
+	}
+
+	if !key.LastUsedAt.Valid || now.Sub(key.LastUsedAt.Time) >= apiKeyLastUsedPrecision {
+		if _, err := models.APIKeys(
+			models.APIKeyWhere.ID.EQ(key.ID),
+			qm.Where("(last_used_at IS NULL OR last_used_at < ?)", now.Add(-apiKeyLastUsedPrecision)),
+		).UpdateAll(ctx, config.DB, models.M{models.APIKeyColumns.LastUsedAt: now}); err != nil {
+			// the request may still succeed
+			logs.LogFromEchoContext(c).Error().Err(err).Str("apiKeyID", key.ID).Msg("Failed to record API key usage")
+		}
+	}
+
+	// a copy, so the restricted scopes never end up being persisted
+	user := *key.R.User
+	user.R = nil
+	user.Scopes = make([]string, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		if slices.ContainsString(key.R.User.Scopes, scope) {
+			user.Scopes = append(user.Scopes, scope)
+		}
+	}
+
+	return authentication{user: &user, apiKey: key}, nil
+}
+
+// authenticatedContext stores the user and credentials in the context and adds the user ID to the context's logger.
+func authenticatedContext(ctx context.Context, a authentication) context.Context {
+	l := logs.LogFromContext(ctx).With().Str("userID", a.user.ID).Logger()
+	ctx = l.WithContext(ctx)
+
+	ctx = context.WithValue(ctx, logs.CTXKeyUser, a.user)
+	if a.accessToken != nil {
+		ctx = context.WithValue(ctx, logs.CTXKeyAccessToken, a.accessToken)
+	}
+	if a.claims != nil {
+		ctx = context.WithValue(ctx, logs.CTXKeyTokenClaims, a.claims)
+	}
+	if a.apiKey != nil {
+		ctx = context.WithValue(ctx, logs.CTXKeyAPIKey, a.apiKey)
+	}
+
+	return ctx
+}
+
+// tokenFromHeader extracts the access token from an Authorization header value.
+func tokenFromHeader(header string) (string, error) {
+	l := len(authScheme)
+	if len(header) <= l+1 || !strings.EqualFold(header[:l], authScheme) || header[l] != ' ' {
+		return "", errAuthMalformedHeader
+	}
+
+	token := strings.TrimSpace(header[l+1:])
+	if len(token) == 0 {
+		return "", errAuthMalformedHeader
+	}
+
+	return token, nil
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/labstack/echo/v4"
+)
+
+// DBSession tracks the database writes of each request (see db.WithSession), so its reads are sent to the primary
+// database instead of read replicas for a while after writing.
+func DBSession() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(db.WithSession(req.Context())))
+
+			return next(c)
+		}
+	}
+}
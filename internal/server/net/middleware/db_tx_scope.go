@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/labstack/echo/v4"
+)
+
+// DBTxScope tracks the transactions of each request (see db.WithTxScope), so transactions started by a handler
+// within another one are nested via savepoints and hooks can be registered within plain db.TxFns.
+func DBTxScope() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(db.WithTxScope(req.Context())))
+
+			return next(c)
+		}
+	}
+}
@@ -4,13 +4,26 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"expvar"
+	"fmt"
+	"net"
 	"net/http"
 	"net/http/pprof"
 	"runtime"
 	"strings"
-
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/cleanup"
+	"github.com/driif/echo-go-starter/internal/jobs"
+	"github.com/driif/echo-go-starter/internal/mailer"
+	"github.com/driif/echo-go-starter/internal/mailer/transport"
+	"github.com/driif/echo-go-starter/internal/outbox"
+	"github.com/driif/echo-go-starter/internal/scheduler"
 	"github.com/driif/echo-go-starter/internal/server/config"
 	mdwr "github.com/driif/echo-go-starter/internal/server/net/middleware"
+	"github.com/driif/echo-go-starter/pkg/auth/jwt"
+	"github.com/driif/echo-go-starter/pkg/auth/oidc"
+	"github.com/driif/echo-go-starter/pkg/db"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/rs/zerolog/log"
@@ -19,6 +32,9 @@ import (
 	_ "github.com/lib/pq"
 )
 
+// oidcClientTimeout limits requests to OpenID Connect providers.
+const oidcClientTimeout = 10 * time.Second
+
 // Server is the main struct for the server
 type Server struct {
 	// Code here
@@ -26,7 +42,21 @@ type Server struct {
 	Echo   *echo.Echo
 	Router *Router
 	DB     *sql.DB
-	//Mailer *mailer.Mailer
+	// DBRouter routes reads to the configured read replicas (see db.Router), using DB only if there are none.
+	DBRouter *db.Router
+	Mailer   *mailer.Mailer
+	// JWT verifies (and with config.TokenStrategyJWT issues) signed access tokens, nil if no secrets are configured.
+	JWT *jwt.KeySet
+	// OIDC holds the configured external identity providers by name.
+	OIDC map[string]*oidc.Provider
+	// Cleanup periodically deletes expired tokens while the server is running, nil if disabled.
+	Cleanup *cleanup.Cleaner
+	// Jobs processes background jobs while the server is running, handlers are registered by name.
+	Jobs *jobs.Worker
+	// Scheduler runs periodic tasks once cluster-wide while the server is running, tasks are registered by name.
+	Scheduler *scheduler.Scheduler
+	// Outbox publishes messages written within transactions via outbox.Enqueue, handlers are registered by topic.
+	Outbox *outbox.Relay
 	//Push *push.Service
 }
 
@@ -35,9 +65,9 @@ type Router struct {
 	Routes     []*echo.Route
 	Root       *echo.Group
 	Management *echo.Group
+	V1Auth     *echo.Group
 	V1User     *echo.Group
-	V1Room     *echo.Group
-	V1Msg      *echo.Group
+	V1Admin    *echo.Group
 }
 
 // New creates a new server
@@ -56,36 +86,208 @@ func New(config config.Server) *Server {
 // Checks if the server is ready to serve requests
 func (s *Server) Ready() bool {
 	return s.DB != nil &&
+		s.DBRouter != nil &&
 		s.Echo != nil &&
-		s.Router != nil
+		s.Router != nil &&
+		s.Mailer != nil
 }
 
 // InitDB initializes the database connection
 func (s *Server) InitDB(ctx context.Context) error {
-	db, err := sql.Open("postgres", s.Config.Database.ConnectionString())
+	db, err := openDB(s.Config.Database)
 	if err != nil {
 		return err
 	}
 
-	if s.Config.Database.MaxOpenConns > 0 {
-		db.SetMaxOpenConns(s.Config.Database.MaxOpenConns)
+	if err := db.PingContext(ctx); err != nil {
+		return err
 	}
-	if s.Config.Database.MaxIdleConns > 0 {
-		db.SetMaxIdleConns(s.Config.Database.MaxIdleConns)
+
+	s.DB = db
+
+	return nil
+}
+
+// InitDBRouter connects to the configured read replicas and routes reads between them and the primary database.
+// Replicas failing their initial health check are skipped until they recover.
+func (s *Server) InitDBRouter(ctx context.Context) error {
+	replicas := make([]*sql.DB, 0, len(s.Config.Database.Replicas.Hosts))
+	for _, host := range s.Config.Database.Replicas.Hosts {
+		if len(host) == 0 {
+			continue
+		}
+
+		conf, err := s.Config.Database.Replica(host)
+		if err != nil {
+			return err
+		}
+
+		replica, err := openDB(conf)
+		if err != nil {
+			return err
+		}
+
+		replicas = append(replicas, replica)
 	}
-	if s.Config.Database.ConnMaxLifetime > 0 {
-		db.SetConnMaxLifetime(s.Config.Database.ConnMaxLifetime)
+
+	s.DBRouter = db.NewRouter(s.DB, replicas, db.RouterOptions{
+		HealthCheckInterval: s.Config.Database.Replicas.HealthCheckInterval,
+		HealthCheckTimeout:  s.Config.Database.Replicas.HealthCheckTimeout,
+		PrimaryReadWindow:   s.Config.Database.Replicas.PrimaryReadWindow,
+	})
+
+	if len(replicas) > 0 {
+		s.DBRouter.CheckHealth(ctx)
+
+		total, healthy := s.DBRouter.Replicas()
+		log.Info().Int("replicas", total).Int("healthy", healthy).Msg("Routing reads to database replicas")
 	}
 
-	if err := db.PingContext(ctx); err != nil {
+	return nil
+}
+
+// newIPExtractor returns the IP extractor using the X-Forwarded-For header set by the trusted proxies (IPs or CIDR
+// ranges), or the remote address of the connection if there are none.
+func newIPExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	var ranges []echo.TrustOption
+	for _, proxy := range trustedProxies {
+		if len(proxy) == 0 {
+			continue
+		}
+
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		ranges = append(ranges, echo.TrustIPRange(ipNet))
+	}
+
+	if len(ranges) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	// only the configured ranges are trusted, not echo's defaults (loopback, link-local and private networks)
+	options := append([]echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}, ranges...)
+
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
+
+func openDB(conf config.Database) (*sql.DB, error) {
+	db, err := sql.Open("postgres", conf.ConnectionString())
+	if err != nil {
+		return nil, err
+	}
+
+	if conf.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(conf.MaxOpenConns)
+	}
+	if conf.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(conf.MaxIdleConns)
+	}
+	if conf.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(conf.ConnMaxLifetime)
+	}
+
+	return db, nil
+}
+
+// InitMailer initializes the mailer using the configured transport and parses all email templates
+func (s *Server) InitMailer() error {
+	switch s.Config.Mailer.Transporter {
+	case config.MailerTransporterSMTP:
+		s.Mailer = mailer.New(s.Config.Mailer, transport.NewSMTP(s.Config.SMTP))
+	default:
+		log.Warn().Msg("Initializing mock mailer, mails will not be delivered")
+		s.Mailer = mailer.New(s.Config.Mailer, transport.NewMock())
+	}
+
+	return s.Mailer.ParseTemplates()
+}
+
+// InitJWT derives the keys for signed access tokens. Without configured secrets, JWTs are neither issued nor accepted.
+func (s *Server) InitJWT() error {
+	if len(s.Config.Auth.JWT.Secrets) == 0 {
+		if s.Config.Auth.TokenStrategy == config.TokenStrategyJWT {
+			return errors.New("token strategy jwt requires SERVER_AUTH_JWT_SECRETS")
+		}
+
+		return nil
+	}
+
+	ks, err := jwt.NewKeySet(s.Config.Auth.JWT)
+	if err != nil {
 		return err
 	}
 
-	s.DB = db
+	s.JWT = ks
 
 	return nil
 }
 
+// InitOIDC sets up the configured OpenID Connect providers, their metadata is discovered on first use.
+func (s *Server) InitOIDC() error {
+	client := &http.Client{Timeout: oidcClientTimeout}
+
+	s.OIDC = make(map[string]*oidc.Provider, len(s.Config.Auth.OIDC.Providers))
+	for _, p := range s.Config.Auth.OIDC.Providers {
+		if len(p.Issuer) == 0 || len(p.ClientID) == 0 || len(p.RedirectURL) == 0 {
+			return fmt.Errorf("OIDC provider %q requires an issuer, client ID and redirect URL", p.Name)
+		}
+		if _, ok := s.OIDC[p.Name]; ok {
+			return fmt.Errorf("OIDC provider %q is configured multiple times", p.Name)
+		}
+
+		s.OIDC[p.Name] = oidc.NewProvider(p, client)
+	}
+
+	return nil
+}
+
+// InitCleanup sets up the periodic deletion of expired tokens, which is started together with the server.
+func (s *Server) InitCleanup() error {
+	if !s.Config.Cleanup.Enabled {
+		log.Warn().Msg("Periodic cleanup of expired tokens is disabled")
+		return nil
+	}
+
+	// a non-positive interval would rerun the cleanup immediately after each run
+	if s.Config.Cleanup.Interval <= 0 {
+		return errors.New("periodic cleanup requires a positive SERVER_CLEANUP_INTERVAL_SEC")
+	}
+
+	s.Cleanup = cleanup.New(s.DB, s.Config.Cleanup, cleanup.DefaultTargets(s.Config.Auth))
+
+	return nil
+}
+
+// InitJobs sets up the background job worker. Handlers have to be registered before the server is started,
+// jobs may be enqueued regardless of the worker being enabled.
+func (s *Server) InitJobs() {
+	s.Jobs = jobs.NewWorker(s.DB, s.Config.Jobs)
+}
+
+// InitScheduler sets up the scheduler of periodic tasks. Tasks have to be registered before the server is started.
+func (s *Server) InitScheduler() error {
+	var err error
+	s.Scheduler, err = scheduler.New(s.DB, s.Config.Scheduler)
+
+	return err
+}
+
+// InitOutbox sets up the relay of the transactional outbox. Handlers have to be registered before the server is
+// started, messages may be enqueued regardless of the relay being enabled.
+func (s *Server) InitOutbox() {
+	s.Outbox = outbox.NewRelay(s.DB, s.Config.Outbox)
+}
+
 // Initialize a new Echo server with Middleware Configs
 func (s *Server) Initialize() error {
 	s.Echo = echo.New()
@@ -99,6 +301,13 @@ func (s *Server) Initialize() error {
 		HideInternalServerErrorDetails: s.Config.Echo.HideInternalServerErrorDetails,
 	})
 
+	// client IPs are used to throttle authentication attempts, headers are only trusted if set by known proxies
+	ipExtractor, err := newIPExtractor(s.Config.Echo.TrustedProxies)
+	if err != nil {
+		return err
+	}
+	s.Echo.IPExtractor = ipExtractor
+
 	// ---
 	// General middleware
 	if s.Config.Echo.EnableTrailingSlashMiddleware {
@@ -173,6 +382,11 @@ func (s *Server) Initialize() error {
 		log.Warn().Msg("Disabling logger middleware due to environment config")
 	}
 
+	// requests track their writes to read them from the primary database
+	s.Echo.Use(mdwr.DBSession())
+	// requests track their transactions to nest the ones started within
+	s.Echo.Use(mdwr.DBTxScope())
+
 	if s.Config.Echo.EnableCORSMiddleware {
 		s.Echo.Use(middleware.CORS())
 	} else {
@@ -199,6 +413,7 @@ func (s *Server) Initialize() error {
 
 		s.Echo.GET("/debug/pprof", echo.WrapHandler(http.HandlerFunc(pprof.Index)), pprofAuthMiddleware)
 		s.Echo.Any("/debug/pprof/*", echo.WrapHandler(http.DefaultServeMux), pprofAuthMiddleware)
+		s.Echo.GET("/debug/vars", echo.WrapHandler(expvar.Handler()), pprofAuthMiddleware)
 
 		log.Warn().Bool("EnableManagementKeyAuth", s.Config.Pprof.EnableManagementKeyAuth).Msg("Pprof http handlers are avaible at /debug/pprof")
 
@@ -217,6 +432,37 @@ func (s *Server) Start() error {
 	if !s.Ready() {
 		return errors.New("server is not ready")
 	}
+
+	s.DBRouter.Start()
+
+	if s.Cleanup != nil {
+		s.Cleanup.Start()
+	}
+
+	if s.Jobs != nil {
+		if s.Config.Jobs.Enabled {
+			s.Jobs.Start()
+		} else {
+			log.Warn().Msg("Background job worker is disabled")
+		}
+	}
+
+	if s.Scheduler != nil {
+		if s.Config.Scheduler.Enabled {
+			s.Scheduler.Start()
+		} else {
+			log.Warn().Msg("Scheduler of periodic tasks is disabled")
+		}
+	}
+
+	if s.Outbox != nil {
+		if s.Config.Outbox.Enabled {
+			s.Outbox.Start()
+		} else {
+			log.Warn().Msg("Outbox relay is disabled")
+		}
+	}
+
 	// Code here
 	return s.Echo.Start(s.Config.Echo.ListenAddress)
 }
@@ -225,6 +471,39 @@ func (s *Server) Start() error {
 func (s *Server) Shutdown(ctx context.Context) error {
 	log.Warn().Msg("Shutting down server")
 
+	if s.Cleanup != nil {
+		log.Debug().Msg("Stopping periodic cleanup")
+		s.Cleanup.Stop()
+	}
+
+	if s.Scheduler != nil {
+		log.Debug().Msg("Stopping scheduler")
+		s.Scheduler.Stop()
+	}
+
+	if s.Outbox != nil {
+		log.Debug().Msg("Stopping outbox relay")
+		s.Outbox.Stop()
+	}
+
+	// running jobs are drained before closing the database connection they rely on
+	if s.Jobs != nil {
+		log.Debug().Msg("Draining background jobs")
+
+		if err := s.Jobs.Shutdown(ctx); err != nil {
+			log.Error().Err(err).Msg("Failed to drain background jobs")
+		}
+	}
+
+	if s.DBRouter != nil {
+		log.Debug().Msg("Closing database replica connections")
+		s.DBRouter.Stop()
+
+		if err := s.DBRouter.Close(); err != nil {
+			log.Error().Err(err).Msg("Failed to close database replica connections")
+		}
+	}
+
 	if s.DB != nil {
 		log.Debug().Msg("Closing database connection")
 
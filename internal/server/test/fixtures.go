@@ -3,9 +3,33 @@ package test
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/pkg/auth/apikey"
 	"github.com/driif/echo-go-starter/pkg/structs"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+const (
+	// PlainTestUserPassword is the password of all fixture users.
+	PlainTestUserPassword = "password"
+	// HashedTestUserPassword is PlainTestUserPassword hashed using the default (argon2id) hashing config.
+	HashedTestUserPassword = "$argon2id$v=19$m=65536,t=3,p=4$BRrGnPAXowMamwMe1KAdTQ$+fLla3McM+f6/BdHar5meo08Lmvr27UKgbAFPGWCRe0"
+	// BcryptTestUserPassword is PlainTestUserPassword hashed using bcrypt, which is rehashed on login.
+	BcryptTestUserPassword = "$2a$10$eJgAjuDQl1EPLg/ar6814OUXZzwI81etleEI2mwMnHnGNmILASjFG"
+
+	// TestUserTOTPSecret is the TOTP secret of UserMFA, codes are generated via totp.Code.
+	TestUserTOTPSecret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+
+	// PlainAdmin1APIKeyAdmin is the API key of Admin1 restricted to the admin and app scopes.
+	PlainAdmin1APIKeyAdmin = apikey.Scheme + "_964bdb286384_dfdd401d6db100c8c4a3b74d26c7f5159cd92076a67ac3a51fa87c3d4d5c0fd9"
+	// PlainAdmin1APIKeyApp is the API key of Admin1 restricted to the app scope.
+	PlainAdmin1APIKeyApp = apikey.Scheme + "_8ab388bab74a_1531aa63efc6d36e43d15ea8efb7a7c5503f08885c24317468351ec01adfa905"
+	// PlainUser1APIKeyStale is the API key of User1 still listing the admin scope, which User1 doesn't possess.
+	PlainUser1APIKeyStale = apikey.Scheme + "_2f09abb1d7a0_842eb528811cc403e354590aea8ebe3226e61992a46aaf3e1e1f8ef836ff73fb"
 )
 
 // Insertable represents a common IntFromerface for all model instances so they may be inserted via the Inserts() func
@@ -16,13 +40,159 @@ type Insertable interface {
 // FixtureMap definition which fixtures are available through Fixtures().
 // Mind the declaration order! The fields get inserted exactly in the order they are declared.
 type FixtureMap struct {
+	User1               *models.User
+	User1AppUserProfile *models.AppUserProfile
+	User1AccessToken1   *models.AccessToken
+	User1APIKeyStale    *models.APIKey
+
+	// User2's password is hashed using bcrypt instead of the configured argon2id.
+	User2               *models.User
+	User2AppUserProfile *models.AppUserProfile
+
+	UserDeactivated               *models.User
+	UserDeactivatedAppUserProfile *models.AppUserProfile
+
+	// UserMFA has 2FA enabled using TestUserTOTPSecret.
+	UserMFA               *models.User
+	UserMFAAppUserProfile *models.AppUserProfile
+	UserMFAAccessToken1   *models.AccessToken
+	UserMFATOTP           *models.UserTotp
+
+	Admin1                  *models.User
+	Admin1AccessToken1      *models.AccessToken
+	Admin1APIKeyAdmin       *models.APIKey
+	Admin1APIKeyApp         *models.APIKey
+	SuperAdmin1             *models.User
+	SuperAdmin1AccessToken1 *models.AccessToken
 }
 
 // Fixtures returns a function wrapping our fixtures, which tests are allowed to manipulate.
 // Each test (which may run concurrently) receives a fresh copy, preventing side effects between test runs.
 func Fixtures() FixtureMap {
+	now := time.Now()
 	f := FixtureMap{}
 
+	f.User1 = &models.User{
+		ID:       "f6ede5d8-e22a-4ca5-aa12-67821865a3e5",
+		Username: null.StringFrom("user1@example.com"),
+		Password: null.StringFrom(HashedTestUserPassword),
+		Scopes:   types.StringArray{"app"},
+		IsActive: true,
+	}
+
+	f.User1AppUserProfile = &models.AppUserProfile{
+		UserID: f.User1.ID,
+	}
+
+	f.User1AccessToken1 = &models.AccessToken{
+		Token:      "1653e5e6-4a9b-4b14-9e2c-9a6d1f6d0a1e",
+		ValidUntil: now.Add(10 * 365 * 24 * time.Hour),
+		UserID:     f.User1.ID,
+	}
+
+	f.User1APIKeyStale = &models.APIKey{
+		ID:         "2d1bcdd3-44f3-48cb-808c-56bd95ffef5a",
+		UserID:     f.User1.ID,
+		Name:       "Stale admin key",
+		Prefix:     "2f09abb1d7a0",
+		SecretHash: apikey.Hash("842eb528811cc403e354590aea8ebe3226e61992a46aaf3e1e1f8ef836ff73fb"),
+		Scopes:     types.StringArray{"app", "admin"},
+	}
+
+	f.User2 = &models.User{
+		ID:       "5231e26d-ac6a-4055-9ea0-70880319c9bd",
+		Username: null.StringFrom("user2@example.com"),
+		Password: null.StringFrom(BcryptTestUserPassword),
+		Scopes:   types.StringArray{"app"},
+		IsActive: true,
+	}
+
+	f.User2AppUserProfile = &models.AppUserProfile{
+		UserID: f.User2.ID,
+	}
+
+	f.UserDeactivated = &models.User{
+		ID:       "c24915b2-89d1-4c13-a187-91e5088ef54a",
+		Username: null.StringFrom("userdeactivated@example.com"),
+		Password: null.StringFrom(HashedTestUserPassword),
+		Scopes:   types.StringArray{"app"},
+		IsActive: false,
+	}
+
+	f.UserDeactivatedAppUserProfile = &models.AppUserProfile{
+		UserID: f.UserDeactivated.ID,
+	}
+
+	f.UserMFA = &models.User{
+		ID:       "13e7fe96-62b0-41dd-af44-666d0a53c894",
+		Username: null.StringFrom("usermfa@example.com"),
+		Password: null.StringFrom(HashedTestUserPassword),
+		Scopes:   types.StringArray{"app"},
+		IsActive: true,
+	}
+
+	f.UserMFAAppUserProfile = &models.AppUserProfile{
+		UserID: f.UserMFA.ID,
+	}
+
+	f.UserMFAAccessToken1 = &models.AccessToken{
+		Token:      "393e38e0-c35a-4079-96b2-9198ed732f71",
+		ValidUntil: now.Add(10 * 365 * 24 * time.Hour),
+		UserID:     f.UserMFA.ID,
+	}
+
+	f.UserMFATOTP = &models.UserTotp{
+		UserID:      f.UserMFA.ID,
+		Secret:      TestUserTOTPSecret,
+		ConfirmedAt: null.TimeFrom(now),
+	}
+
+	f.Admin1 = &models.User{
+		ID:       "38405a36-5e06-434f-9b69-dbd2faaab788",
+		Username: null.StringFrom("admin1@example.com"),
+		Password: null.StringFrom(HashedTestUserPassword),
+		Scopes:   types.StringArray{"app", "admin"},
+		IsActive: true,
+	}
+
+	f.Admin1AccessToken1 = &models.AccessToken{
+		Token:      "174c9b6f-a7ff-4c75-a7f3-755d1027085e",
+		ValidUntil: now.Add(10 * 365 * 24 * time.Hour),
+		UserID:     f.Admin1.ID,
+	}
+
+	f.Admin1APIKeyAdmin = &models.APIKey{
+		ID:         "92a13ff6-f64a-4943-b9c1-f10d09b7b7aa",
+		UserID:     f.Admin1.ID,
+		Name:       "Admin key",
+		Prefix:     "964bdb286384",
+		SecretHash: apikey.Hash("dfdd401d6db100c8c4a3b74d26c7f5159cd92076a67ac3a51fa87c3d4d5c0fd9"),
+		Scopes:     types.StringArray{"app", "admin"},
+	}
+
+	f.Admin1APIKeyApp = &models.APIKey{
+		ID:         "c0b5cb9c-7041-4049-9131-7318ebd49373",
+		UserID:     f.Admin1.ID,
+		Name:       "App key",
+		Prefix:     "8ab388bab74a",
+		SecretHash: apikey.Hash("1531aa63efc6d36e43d15ea8efb7a7c5503f08885c24317468351ec01adfa905"),
+		Scopes:     types.StringArray{"app"},
+	}
+
+	f.SuperAdmin1 = &models.User{
+		ID:       "b2c10204-a499-4a42-96bd-3e839016cfc3",
+		Username: null.StringFrom("superadmin1@example.com"),
+		Password: null.StringFrom(HashedTestUserPassword),
+		Scopes:   types.StringArray{"app", "admin", "superadmin"},
+		IsActive: true,
+	}
+
+	f.SuperAdmin1AccessToken1 = &models.AccessToken{
+		Token:      "7df0162c-1dfb-46bf-9bac-4fd47933870a",
+		ValidUntil: now.Add(10 * 365 * 24 * time.Hour),
+		UserID:     f.SuperAdmin1.ID,
+	}
+
 	return f
 }
 
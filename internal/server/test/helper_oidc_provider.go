@@ -0,0 +1,290 @@
+package test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/pkg/auth/oidc"
+	jwtgo "github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+)
+
+const (
+	OIDCProviderClientID     = "test-client"
+	OIDCProviderClientSecret = "test-client-secret"
+	OIDCProviderRedirectURL  = "http://localhost:3000/auth/oidc/callback"
+	oidcProviderKeyID        = "test-key"
+)
+
+// OIDCUser is the identity the fake provider authenticates on authorization.
+type OIDCUser struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type oidcAuthorization struct {
+	user          OIDCUser
+	nonce         string
+	codeChallenge string
+	redirectURI   string
+}
+
+// OIDCProvider is an in-process OpenID Connect provider supporting discovery, the authorization code flow with
+// PKCE (S256 only) and RS256 signed ID tokens. Authorization immediately succeeds for the configured User.
+type OIDCProvider struct {
+	Server *httptest.Server
+	// User is authenticated by subsequent authorizations.
+	User OIDCUser
+
+	t   *testing.T
+	key *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]oidcAuthorization
+}
+
+// NewOIDCProvider starts a fake provider, which is shut down after the test.
+func NewOIDCProvider(t *testing.T) *OIDCProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate OIDC provider key: %v", err)
+	}
+
+	p := &OIDCProvider{
+		User: OIDCUser{
+			Subject:       uuid.New().String(),
+			Email:         "oidc-user@example.com",
+			EmailVerified: true,
+			Name:          "OIDC User",
+		},
+		t:     t,
+		key:   key,
+		codes: make(map[string]oidcAuthorization),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(oidc.DiscoveryPath, p.handleDiscovery)
+	mux.HandleFunc("/authorize", p.handleAuthorize)
+	mux.HandleFunc("/token", p.handleToken)
+	mux.HandleFunc("/jwks", p.handleJWKS)
+
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Server.Close)
+
+	return p
+}
+
+// Issuer returns the provider's issuer URL.
+func (p *OIDCProvider) Issuer() string {
+	return p.Server.URL
+}
+
+// Config returns a provider config for the fake provider using the given name.
+func (p *OIDCProvider) Config(name string) oidc.ProviderConfig {
+	return oidc.ProviderConfig{
+		Name:         name,
+		Issuer:       p.Issuer(),
+		ClientID:     OIDCProviderClientID,
+		ClientSecret: OIDCProviderClientSecret,
+		RedirectURL:  OIDCProviderRedirectURL,
+		Scopes:       []string{"openid", "email", "profile"},
+	}
+}
+
+// Register adds the fake provider to the server using the given name, e.g. within test.E2e.
+func (p *OIDCProvider) Register(s *server.Server, name string) {
+	config := p.Config(name)
+
+	s.Config.Auth.OIDC.Providers = append(s.Config.Auth.OIDC.Providers, config)
+	if s.OIDC == nil {
+		s.OIDC = make(map[string]*oidc.Provider)
+	}
+	s.OIDC[name] = oidc.NewProvider(config, p.Server.Client())
+}
+
+// Authorize simulates the user authenticating at the authorization URL, returning the code and state
+// the provider redirects back with.
+func (p *OIDCProvider) Authorize(authCodeURL string) (code string, state string) {
+	p.t.Helper()
+
+	u, err := url.Parse(authCodeURL)
+	if err != nil {
+		p.t.Fatalf("failed to parse authorization URL: %v", err)
+	}
+
+	redirect, err := p.authorize(u.Query())
+	if err != nil {
+		p.t.Fatalf("failed to authorize: %v", err)
+	}
+
+	q := redirect.Query()
+	return q.Get("code"), q.Get("state")
+}
+
+// SignIDToken signs arbitrary claims using the provider's key, e.g. to test the verification of invalid tokens.
+func (p *OIDCProvider) SignIDToken(claims jwtgo.MapClaims) string {
+	p.t.Helper()
+
+	token := jwtgo.NewWithClaims(jwtgo.SigningMethodRS256, claims)
+	token.Header["kid"] = oidcProviderKeyID
+
+	signed, err := token.SignedString(p.key)
+	if err != nil {
+		p.t.Fatalf("failed to sign ID token: %v", err)
+	}
+
+	return signed
+}
+
+// IDTokenClaims returns valid ID token claims of the user issued for the test client.
+func (p *OIDCProvider) IDTokenClaims(user OIDCUser, nonce string) jwtgo.MapClaims {
+	now := time.Now()
+
+	return jwtgo.MapClaims{
+		"iss":            p.Issuer(),
+		"sub":            user.Subject,
+		"aud":            OIDCProviderClientID,
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"iat":            now.Unix(),
+		"nonce":          nonce,
+		"email":          user.Email,
+		"email_verified": user.EmailVerified,
+		"name":           user.Name,
+	}
+}
+
+func (p *OIDCProvider) authorize(q url.Values) (*url.URL, error) {
+	if q.Get("client_id") != OIDCProviderClientID {
+		return nil, errors.New("unknown client_id")
+	}
+	if q.Get("response_type") != "code" {
+		return nil, errors.New("unsupported response_type")
+	}
+	if q.Get("code_challenge_method") != "S256" || len(q.Get("code_challenge")) == 0 {
+		return nil, errors.New("PKCE with S256 is required")
+	}
+	if !strings.Contains(" "+q.Get("scope")+" ", " openid ") {
+		return nil, errors.New("scope openid is required")
+	}
+
+	redirect, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil {
+		return nil, err
+	}
+
+	code := uuid.New().String()
+
+	p.mu.Lock()
+	p.codes[code] = oidcAuthorization{
+		user:          p.User,
+		nonce:         q.Get("nonce"),
+		codeChallenge: q.Get("code_challenge"),
+		redirectURI:   q.Get("redirect_uri"),
+	}
+	p.mu.Unlock()
+
+	rq := redirect.Query()
+	rq.Set("code", code)
+	rq.Set("state", q.Get("state"))
+	redirect.RawQuery = rq.Encode()
+
+	return redirect, nil
+}
+
+func (p *OIDCProvider) handleDiscovery(w http.ResponseWriter, _ *http.Request) {
+	writeOIDCJSON(w, http.StatusOK, oidc.Discovery{
+		Issuer:                           p.Issuer(),
+		AuthorizationEndpoint:            p.Issuer() + "/authorize",
+		TokenEndpoint:                    p.Issuer() + "/token",
+		JWKSURI:                          p.Issuer() + "/jwks",
+		IDTokenSigningAlgValuesSupported: []string{"RS256"},
+	})
+}
+
+func (p *OIDCProvider) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	redirect, err := p.authorize(r.URL.Query())
+	if err != nil {
+		writeOIDCJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request", "error_description": err.Error()})
+		return
+	}
+
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (p *OIDCProvider) handleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeOIDCJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok || clientID != OIDCProviderClientID || clientSecret != OIDCProviderClientSecret {
+		writeOIDCJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	code := r.PostForm.Get("code")
+
+	p.mu.Lock()
+	authorization, ok := p.codes[code]
+	// codes are single use
+	delete(p.codes, code)
+	p.mu.Unlock()
+
+	if !ok ||
+		r.PostForm.Get("grant_type") != "authorization_code" ||
+		r.PostForm.Get("redirect_uri") != authorization.redirectURI ||
+		oidc.CodeChallenge(r.PostForm.Get("code_verifier")) != authorization.codeChallenge {
+		writeOIDCJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	writeOIDCJSON(w, http.StatusOK, oidc.TokenResponse{
+		AccessToken: uuid.New().String(),
+		TokenType:   "Bearer",
+		IDToken:     p.SignIDToken(p.IDTokenClaims(authorization.user, authorization.nonce)),
+		ExpiresIn:   300,
+	})
+}
+
+func (p *OIDCProvider) handleJWKS(w http.ResponseWriter, _ *http.Request) {
+	writeOIDCJSON(w, http.StatusOK, oidc.JSONWebKeySet{
+		Keys: []oidc.JSONWebKey{
+			{
+				KeyType:   "RSA",
+				KeyID:     oidcProviderKeyID,
+				Use:       "sig",
+				Algorithm: "RS256",
+				N:         base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+				E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+			},
+		},
+	})
+}
+
+func writeOIDCJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
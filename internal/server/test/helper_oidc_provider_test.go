@@ -0,0 +1,46 @@
+package test_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/driif/echo-go-starter/internal/server/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCProviderAuthorizeRedirect(t *testing.T) {
+	p := test.NewOIDCProvider(t)
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", test.OIDCProviderClientID)
+	q.Set("redirect_uri", test.OIDCProviderRedirectURL)
+	q.Set("scope", "openid email")
+	q.Set("state", "state")
+	q.Set("nonce", "nonce")
+	q.Set("code_challenge", "challenge")
+	q.Set("code_challenge_method", "S256")
+
+	res, err := client.Get(p.Issuer() + "/authorize?" + q.Encode())
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusFound, res.StatusCode)
+
+	location, err := url.Parse(res.Header.Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:3000", location.Host)
+	assert.Equal(t, "state", location.Query().Get("state"))
+	assert.NotEmpty(t, location.Query().Get("code"))
+
+	q.Set("code_challenge_method", "plain")
+	res, err = client.Get(p.Issuer() + "/authorize?" + q.Encode())
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
@@ -10,7 +10,10 @@ import (
 	"testing"
 
 	"github.com/driif/echo-go-starter/internal/server"
+	"github.com/driif/echo-go-starter/internal/server/net/errs"
+	"github.com/driif/echo-go-starter/internal/server/net/middleware"
 	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
 )
 
 type GenericPayload map[string]interface{}
@@ -120,3 +123,23 @@ func HeadersWithConfigurableAuth(t *testing.T, scheme string, token string) http
 
 	return headers
 }
+
+func HeadersWithAPIKey(t *testing.T, key string) http.Header {
+	t.Helper()
+
+	headers := http.Header{}
+	headers.Set(middleware.HeaderAPIKey, key)
+
+	return headers
+}
+
+// RequireHTTPError asserts the response to carry the status code and type of the given error.
+func RequireHTTPError(t *testing.T, res *httptest.ResponseRecorder, httpErr *errs.HTTPError) {
+	t.Helper()
+
+	var response errs.HTTPError
+	ParseResponseBody(t, res, &response)
+	require.Equal(t, *httpErr.Code, res.Result().StatusCode)
+	require.NotNil(t, response.Type)
+	require.Equal(t, *httpErr.Type, *response.Type)
+}
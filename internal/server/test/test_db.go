@@ -26,6 +26,7 @@ type TestDB struct {
 // NewDbInstance creates a new test database instance
 func NewDBInstance(t *testing.T, conf config.Server) *TestDB {
 	t.Helper()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
 
 	db := &TestDB{
 		t: t,
@@ -101,7 +102,9 @@ func (db *TestDB) ApplyFixtures(t *testing.T) {
 		Dir: config.DatabaseMigrationFolder,
 	}
 
-	_, err := migrate.Exec(db.DB, "postgres", migrations, migrate.Up)
+	// use the same migration table as our live database (see dbconfig.yml)
+	ms := migrate.MigrationSet{TableName: config.DatabaseMigrationTable}
+	_, err := ms.Exec(db.DB, "postgres", migrations, migrate.Up)
 	if err != nil {
 		db.t.Fatal(err)
 	}
@@ -22,8 +22,38 @@ func E2e(t *testing.T, closure func(s *server.Server)) {
 	s := server.New(conf)
 	s.DB = testDB.DB
 
+	if err := s.InitDBRouter(context.Background()); err != nil {
+		t.Fatalf("failed to initialize database router: %v", err)
+	}
+
+	if err := s.InitMailer(); err != nil {
+		t.Fatalf("failed to initialize mailer: %v", err)
+	}
+
+	if err := s.InitJWT(); err != nil {
+		t.Fatalf("failed to initialize JWT keys: %v", err)
+	}
+
+	if err := s.InitOIDC(); err != nil {
+		t.Fatalf("failed to initialize OIDC providers: %v", err)
+	}
+
+	s.InitJobs()
+
+	s.InitOutbox()
+
+	if err := s.InitScheduler(); err != nil {
+		t.Fatalf("failed to initialize scheduler: %v", err)
+	}
+
+	if err := s.Initialize(); err != nil {
+		t.Fatalf("failed to initialize server: %v", err)
+	}
+
 	router.InitGroups(s)
-	router.AttachRoutes(s)
+	if err := router.AttachRoutes(s); err != nil {
+		t.Fatalf("failed to attach routes: %v", err)
+	}
 
 	closure(s)
 
@@ -0,0 +1,13 @@
+-- +migrate Up
+ALTER TABLE app_user_profiles
+    ADD COLUMN display_name varchar(255);
+
+ALTER TABLE app_user_profiles
+    ADD COLUMN locale varchar(35);
+
+-- +migrate Down
+ALTER TABLE app_user_profiles
+    DROP COLUMN IF EXISTS locale;
+
+ALTER TABLE app_user_profiles
+    DROP COLUMN IF EXISTS display_name;
@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE TABLE auth_attempts (
+    key text NOT NULL,
+    failures int NOT NULL DEFAULT 0,
+    last_failure_at timestamptz NOT NULL,
+    locked_until timestamptz,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    CONSTRAINT auth_attempts_pkey PRIMARY KEY (key)
+);
+
+CREATE INDEX idx_auth_attempts_last_failure_at ON auth_attempts USING btree (last_failure_at);
+
+-- +migrate Down
+DROP TABLE IF EXISTS auth_attempts;
@@ -0,0 +1,50 @@
+-- +migrate Up
+CREATE TABLE user_totps (
+    user_id uuid NOT NULL,
+    secret text NOT NULL,
+    confirmed_at timestamptz,
+    last_used_step bigint NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    CONSTRAINT user_totps_pkey PRIMARY KEY (user_id)
+);
+
+ALTER TABLE user_totps
+    ADD CONSTRAINT user_totps_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+CREATE TABLE mfa_recovery_codes (
+    id uuid NOT NULL DEFAULT uuid_generate_v4 (),
+    user_id uuid NOT NULL,
+    code_hash text NOT NULL,
+    used_at timestamptz,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    CONSTRAINT mfa_recovery_codes_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX idx_mfa_recovery_codes_fk_user_uid ON mfa_recovery_codes USING btree (user_id);
+
+ALTER TABLE mfa_recovery_codes
+    ADD CONSTRAINT mfa_recovery_codes_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+CREATE TABLE mfa_tokens (
+    token uuid NOT NULL DEFAULT uuid_generate_v4 (),
+    valid_until timestamptz NOT NULL,
+    user_id uuid NOT NULL,
+    attempts int NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    CONSTRAINT mfa_tokens_pkey PRIMARY KEY (token)
+);
+
+CREATE INDEX idx_mfa_tokens_fk_user_uid ON mfa_tokens USING btree (user_id);
+
+ALTER TABLE mfa_tokens
+    ADD CONSTRAINT mfa_tokens_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+-- +migrate Down
+DROP TABLE IF EXISTS mfa_tokens;
+
+DROP TABLE IF EXISTS mfa_recovery_codes;
+
+DROP TABLE IF EXISTS user_totps;
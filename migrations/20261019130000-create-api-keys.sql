@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE TABLE api_keys (
+    id uuid NOT NULL DEFAULT uuid_generate_v4 (),
+    user_id uuid NOT NULL,
+    name varchar(255) NOT NULL,
+    prefix varchar(32) NOT NULL,
+    secret_hash text NOT NULL,
+    scopes text[] NOT NULL,
+    expires_at timestamptz,
+    last_used_at timestamptz,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    CONSTRAINT api_keys_pkey PRIMARY KEY (id),
+    CONSTRAINT api_keys_prefix_key UNIQUE (prefix)
+);
+
+CREATE INDEX idx_api_keys_fk_user_uid ON api_keys USING btree (user_id);
+
+ALTER TABLE api_keys
+    ADD CONSTRAINT api_keys_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+-- +migrate Down
+DROP TABLE IF EXISTS api_keys;
@@ -0,0 +1,34 @@
+-- +migrate Up
+CREATE TABLE identities (
+    id uuid NOT NULL DEFAULT uuid_generate_v4 (),
+    user_id uuid NOT NULL,
+    provider varchar(255) NOT NULL,
+    subject text NOT NULL,
+    email text,
+    last_login_at timestamptz,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    CONSTRAINT identities_pkey PRIMARY KEY (id),
+    CONSTRAINT identities_provider_subject_key UNIQUE (provider, subject)
+);
+
+CREATE INDEX idx_identities_fk_user_uid ON identities USING btree (user_id);
+
+ALTER TABLE identities
+    ADD CONSTRAINT identities_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+CREATE TABLE oidc_auth_requests (
+    state text NOT NULL,
+    provider varchar(255) NOT NULL,
+    nonce text NOT NULL,
+    code_verifier text NOT NULL,
+    valid_until timestamptz NOT NULL,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    CONSTRAINT oidc_auth_requests_pkey PRIMARY KEY (state)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS oidc_auth_requests;
+
+DROP TABLE IF EXISTS identities;
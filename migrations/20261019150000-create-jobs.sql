@@ -0,0 +1,34 @@
+-- +migrate Up
+CREATE TABLE jobs (
+    id uuid NOT NULL DEFAULT uuid_generate_v4 (),
+    name varchar(255) NOT NULL,
+    payload jsonb NOT NULL,
+    state varchar(32) NOT NULL,
+    unique_key text,
+    attempts int NOT NULL DEFAULT 0,
+    max_attempts int NOT NULL,
+    run_at timestamptz NOT NULL,
+    locked_until timestamptz,
+    last_error text,
+    finished_at timestamptz,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    CONSTRAINT jobs_pkey PRIMARY KEY (id),
+    CONSTRAINT jobs_state_check CHECK (state IN ('pending', 'running', 'dead'))
+);
+
+-- pending and running jobs are deduplicated by their unique key
+CREATE UNIQUE INDEX idx_jobs_unique_key ON jobs USING btree (name, unique_key)
+WHERE
+    unique_key IS NOT NULL AND state IN ('pending', 'running');
+
+CREATE INDEX idx_jobs_pending_run_at ON jobs USING btree (run_at)
+WHERE
+    state = 'pending';
+
+CREATE INDEX idx_jobs_running_locked_until ON jobs USING btree (locked_until)
+WHERE
+    state = 'running';
+
+-- +migrate Down
+DROP TABLE IF EXISTS jobs;
@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE scheduled_tasks (
+    name varchar(255) NOT NULL,
+    last_run_at timestamptz,
+    last_duration_ms bigint,
+    last_error text,
+    last_succeeded_at timestamptz,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    CONSTRAINT scheduled_tasks_pkey PRIMARY KEY (name)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS scheduled_tasks;
@@ -0,0 +1,27 @@
+-- +migrate Up
+CREATE TABLE outbox_messages (
+    id bigserial NOT NULL,
+    topic varchar(255) NOT NULL,
+    aggregate_key varchar(255),
+    payload jsonb NOT NULL,
+    state varchar(32) NOT NULL DEFAULT 'pending',
+    attempts int NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL,
+    last_error text,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    CONSTRAINT outbox_messages_pkey PRIMARY KEY (id),
+    CONSTRAINT outbox_messages_state_check CHECK (state IN ('pending', 'dead'))
+);
+
+-- pending messages of an aggregate are published in order of their id, dead messages don't hold back later ones
+CREATE INDEX idx_outbox_messages_aggregate_key ON outbox_messages USING btree (aggregate_key, id)
+WHERE
+    state = 'pending';
+
+CREATE INDEX idx_outbox_messages_next_attempt_at ON outbox_messages USING btree (next_attempt_at, id)
+WHERE
+    state = 'pending';
+
+-- +migrate Down
+DROP TABLE IF EXISTS outbox_messages;
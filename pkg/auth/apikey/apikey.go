@@ -0,0 +1,74 @@
+package apikey
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/driif/echo-go-starter/pkg/strs"
+)
+
+const (
+	// Scheme is prepended to all keys, making them recognizable (e.g. by secret scanners).
+	Scheme = "egs"
+
+	prefixSize = 6
+	secretSize = 32
+)
+
+var (
+	ErrMalformedKey = errors.New("malformed API key")
+)
+
+// Key is a newly generated API key. Only Prefix and Hash are persisted, String is handed out once.
+type Key struct {
+	// Prefix identifies the key, it's stored in plain text and shown to users.
+	Prefix string
+	// Hash of the secret part.
+	Hash   string
+	secret string
+}
+
+// String returns the full key in the format `<scheme>_<prefix>_<secret>`.
+func (k Key) String() string {
+	return Scheme + "_" + k.Prefix + "_" + k.secret
+}
+
+// Generate creates a new random API key.
+func Generate() (Key, error) {
+	prefix, err := strs.GenerateRandomHexString(prefixSize)
+	if err != nil {
+		return Key{}, err
+	}
+
+	secret, err := strs.GenerateRandomHexString(secretSize)
+	if err != nil {
+		return Key{}, err
+	}
+
+	return Key{Prefix: prefix, Hash: Hash(secret), secret: secret}, nil
+}
+
+// Parse splits a key into its prefix and secret.
+func Parse(key string) (prefix string, secret string, err error) {
+	parts := strings.Split(strings.TrimSpace(key), "_")
+	if len(parts) != 3 || parts[0] != Scheme || len(parts[1]) != prefixSize*2 || len(parts[2]) != secretSize*2 {
+		return "", "", ErrMalformedKey
+	}
+
+	return parts[1], parts[2], nil
+}
+
+// Hash returns the hash of the secret to persist. Secrets are random with 256 bits of entropy,
+// so a fast hash suffices to protect them, keeping the verification cheap for every request.
+func Hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify compares the secret to the persisted hash in constant time.
+func Verify(secret string, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(Hash(secret)), []byte(hash)) == 1
+}
@@ -0,0 +1,50 @@
+package apikey_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/driif/echo-go-starter/pkg/auth/apikey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAndParse(t *testing.T) {
+	key, err := apikey.Generate()
+	require.NoError(t, err)
+
+	s := key.String()
+	assert.True(t, strings.HasPrefix(s, "egs_"+key.Prefix+"_"))
+	assert.NotContains(t, key.Hash, strings.Split(s, "_")[2])
+
+	prefix, secret, err := apikey.Parse(s)
+	require.NoError(t, err)
+	assert.Equal(t, key.Prefix, prefix)
+	assert.True(t, apikey.Verify(secret, key.Hash))
+	assert.False(t, apikey.Verify(secret+"0", key.Hash))
+
+	other, err := apikey.Generate()
+	require.NoError(t, err)
+	assert.NotEqual(t, key.Prefix, other.Prefix)
+	assert.NotEqual(t, key.Hash, other.Hash)
+	assert.False(t, apikey.Verify(secret, other.Hash))
+}
+
+func TestParseMalformed(t *testing.T) {
+	key, err := apikey.Generate()
+	require.NoError(t, err)
+	parts := strings.Split(key.String(), "_")
+
+	for _, s := range []string{
+		"",
+		"egs",
+		parts[1] + "_" + parts[2],
+		"xyz_" + parts[1] + "_" + parts[2],
+		"egs_" + parts[1] + "_" + parts[2][1:],
+		"egs_" + parts[1][1:] + "_" + parts[2],
+		key.String() + "_",
+	} {
+		_, _, err := apikey.Parse(s)
+		assert.ErrorIs(t, err, apikey.ErrMalformedKey, s)
+	}
+}
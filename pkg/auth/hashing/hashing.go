@@ -0,0 +1,194 @@
+package hashing
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Algorithm identifies a password hashing algorithm.
+type Algorithm string
+
+const (
+	AlgorithmArgon2id Algorithm = "argon2id"
+	AlgorithmBcrypt   Algorithm = "bcrypt"
+)
+
+func (a Algorithm) String() string {
+	return string(a)
+}
+
+var (
+	ErrUnknownAlgorithm = errors.New("unknown hashing algorithm")
+	ErrInvalidHash      = errors.New("invalid hash format")
+	ErrIncompatibleHash = errors.New("incompatible argon2 version")
+)
+
+// Argon2Params configures argon2id, see https://datatracker.ietf.org/doc/html/rfc9106#section-4.
+type Argon2Params struct {
+	// Time is the number of iterations.
+	Time uint32
+	// Memory in KiB.
+	Memory  uint32
+	Threads uint8
+	KeyLen  uint32
+	SaltLen uint32
+}
+
+// Config selects the algorithm used for new hashes and its costs.
+type Config struct {
+	Algorithm  Algorithm
+	Argon2     Argon2Params
+	BcryptCost int
+}
+
+// DefaultConfig uses argon2id with the second recommended option of RFC 9106 (64 MiB memory).
+var DefaultConfig = Config{
+	Algorithm: AlgorithmArgon2id,
+	Argon2: Argon2Params{
+		Time:    3,
+		Memory:  64 * 1024,
+		Threads: 4,
+		KeyLen:  32,
+		SaltLen: 16,
+	},
+	BcryptCost: bcrypt.DefaultCost,
+}
+
+// Hash hashes the password using the configured algorithm. The resulting string contains the algorithm and all
+// parameters required for verification, argon2id hashes use the PHC string format
+// (`$argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>`), bcrypt hashes the modular crypt format (`$2a$10$...`).
+func Hash(password string, config Config) (string, error) {
+	switch config.Algorithm {
+	case AlgorithmArgon2id:
+		return hashArgon2id(password, config.Argon2)
+	case AlgorithmBcrypt:
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), config.BcryptCost)
+		if err != nil {
+			return "", err
+		}
+		return string(hash), nil
+	default:
+		return "", ErrUnknownAlgorithm
+	}
+}
+
+// Verify checks whether the password matches the hash in constant time, independent of the configured algorithm.
+// Hashes which can't be parsed (e.g. of an unsupported legacy format) never match, the error reports why
+// (ErrUnknownAlgorithm, ErrInvalidHash or ErrIncompatibleHash). Callers should treat them as a mismatch.
+func Verify(password string, hash string) (bool, error) {
+	alg, err := Identify(hash)
+	if err != nil {
+		return false, err
+	}
+
+	switch alg {
+	case AlgorithmArgon2id:
+		params, salt, key, err := decodeArgon2id(hash)
+		if err != nil {
+			return false, err
+		}
+
+		other := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, params.KeyLen)
+		return subtle.ConstantTimeCompare(key, other) == 1, nil
+	default:
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		if err != nil {
+			if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+				return false, nil
+			}
+			return false, fmt.Errorf("%w: %v", ErrInvalidHash, err)
+		}
+		return true, nil
+	}
+}
+
+// NeedsRehash reports whether the hash should be replaced by a new one, as it either uses a different
+// algorithm than configured or weaker parameters. Unparsable hashes are reported as well, however they can't be
+// replaced on login as Verify never matches them, their users have to reset their password.
+func NeedsRehash(hash string, config Config) bool {
+	alg, err := Identify(hash)
+	if err != nil || alg != config.Algorithm {
+		return true
+	}
+
+	switch alg {
+	case AlgorithmArgon2id:
+		params, _, _, err := decodeArgon2id(hash)
+		if err != nil {
+			return true
+		}
+
+		return params.Time < config.Argon2.Time ||
+			params.Memory < config.Argon2.Memory ||
+			params.Threads < config.Argon2.Threads ||
+			params.KeyLen < config.Argon2.KeyLen
+	default:
+		cost, err := bcrypt.Cost([]byte(hash))
+		return err != nil || cost < config.BcryptCost
+	}
+}
+
+// Identify returns the algorithm used to create the hash.
+func Identify(hash string) (Algorithm, error) {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		return AlgorithmArgon2id, nil
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		return AlgorithmBcrypt, nil
+	default:
+		return "", ErrUnknownAlgorithm
+	}
+}
+
+func hashArgon2id(password string, params Argon2Params) (string, error) {
+	salt := make([]byte, params.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, params.KeyLen)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.Memory, params.Time, params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func decodeArgon2id(hash string) (params Argon2Params, salt []byte, key []byte, err error) {
+	// "", "argon2id", "v=19", "m=65536,t=3,p=4", "<salt>", "<key>"
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, ErrInvalidHash
+	}
+	if version != argon2.Version {
+		return params, nil, nil, ErrIncompatibleHash
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads); err != nil ||
+		params.Time == 0 || params.Threads == 0 {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return params, nil, nil, ErrInvalidHash
+	}
+	params.SaltLen = uint32(len(salt))
+
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(key) == 0 {
+		return params, nil, nil, ErrInvalidHash
+	}
+	params.KeyLen = uint32(len(key))
+
+	return params, salt, key, nil
+}
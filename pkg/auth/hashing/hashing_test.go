@@ -0,0 +1,120 @@
+package hashing_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/driif/echo-go-starter/pkg/auth/hashing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testConfig uses cheap parameters to keep our tests fast.
+var testConfig = hashing.Config{
+	Algorithm: hashing.AlgorithmArgon2id,
+	Argon2: hashing.Argon2Params{
+		Time:    1,
+		Memory:  1024,
+		Threads: 1,
+		KeyLen:  32,
+		SaltLen: 16,
+	},
+	BcryptCost: bcrypt.MinCost,
+}
+
+func TestHashArgon2id(t *testing.T) {
+	hash, err := hashing.Hash("t3stp4ssw0rd", testConfig)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	other, err := hashing.Hash("t3stp4ssw0rd", testConfig)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other, "salt must differ")
+
+	ok, err := hashing.Verify("t3stp4ssw0rd", hash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = hashing.Verify("wrong", hash)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	alg, err := hashing.Identify(hash)
+	require.NoError(t, err)
+	assert.Equal(t, hashing.AlgorithmArgon2id, alg)
+}
+
+func TestHashBcrypt(t *testing.T) {
+	config := testConfig
+	config.Algorithm = hashing.AlgorithmBcrypt
+
+	hash, err := hashing.Hash("t3stp4ssw0rd", config)
+	require.NoError(t, err)
+
+	ok, err := hashing.Verify("t3stp4ssw0rd", hash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = hashing.Verify("wrong", hash)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	alg, err := hashing.Identify(hash)
+	require.NoError(t, err)
+	assert.Equal(t, hashing.AlgorithmBcrypt, alg)
+}
+
+func TestHashUnknownAlgorithm(t *testing.T) {
+	config := testConfig
+	config.Algorithm = "md5"
+
+	_, err := hashing.Hash("t3stp4ssw0rd", config)
+	assert.ErrorIs(t, err, hashing.ErrUnknownAlgorithm)
+}
+
+func TestVerifyInvalidHash(t *testing.T) {
+	_, err := hashing.Verify("t3stp4ssw0rd", "t3stp4ssw0rd")
+	assert.ErrorIs(t, err, hashing.ErrUnknownAlgorithm)
+
+	_, err = hashing.Verify("t3stp4ssw0rd", "$argon2id$v=19$m=1024,t=1,p=1$c2FsdA")
+	assert.ErrorIs(t, err, hashing.ErrInvalidHash)
+
+	_, err = hashing.Verify("t3stp4ssw0rd", "$argon2id$v=16$m=1024,t=1,p=1$c2FsdA$a2V5")
+	assert.ErrorIs(t, err, hashing.ErrIncompatibleHash)
+
+	_, err = hashing.Verify("t3stp4ssw0rd", "$argon2id$v=19$m=1024,t=0,p=1$c2FsdA$a2V5")
+	assert.ErrorIs(t, err, hashing.ErrInvalidHash)
+
+	ok, err := hashing.Verify("t3stp4ssw0rd", "$2a$10$tooshort")
+	assert.ErrorIs(t, err, hashing.ErrInvalidHash)
+	assert.False(t, ok)
+}
+
+func TestNeedsRehash(t *testing.T) {
+	hash, err := hashing.Hash("t3stp4ssw0rd", testConfig)
+	require.NoError(t, err)
+	assert.False(t, hashing.NeedsRehash(hash, testConfig))
+
+	stronger := testConfig
+	stronger.Argon2.Time = 2
+	assert.True(t, hashing.NeedsRehash(hash, stronger))
+
+	weaker := testConfig
+	weaker.Argon2.Memory = 512
+	assert.False(t, hashing.NeedsRehash(hash, weaker))
+
+	bcryptConfig := testConfig
+	bcryptConfig.Algorithm = hashing.AlgorithmBcrypt
+	assert.True(t, hashing.NeedsRehash(hash, bcryptConfig))
+
+	legacy, err := hashing.Hash("t3stp4ssw0rd", bcryptConfig)
+	require.NoError(t, err)
+	assert.True(t, hashing.NeedsRehash(legacy, testConfig))
+	assert.False(t, hashing.NeedsRehash(legacy, bcryptConfig))
+
+	bcryptConfig.BcryptCost = bcrypt.MinCost + 1
+	assert.True(t, hashing.NeedsRehash(legacy, bcryptConfig))
+
+	assert.True(t, hashing.NeedsRehash("t3stp4ssw0rd", testConfig))
+}
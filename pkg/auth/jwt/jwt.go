@@ -0,0 +1,235 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	jwtgo "github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/hkdf"
+)
+
+// Algorithm identifies the signing algorithm of issued tokens.
+type Algorithm string
+
+const (
+	AlgorithmHS256 Algorithm = "HS256"
+	AlgorithmEdDSA Algorithm = "EdDSA"
+)
+
+func (a Algorithm) String() string {
+	return string(a)
+}
+
+// SecretMinLength is the minimum length of secrets keys are derived from.
+const SecretMinLength = 32
+
+var (
+	ErrUnknownAlgorithm = errors.New("unknown JWT signing algorithm")
+	ErrNoSecrets        = errors.New("no JWT secrets configured")
+	ErrWeakSecret       = fmt.Errorf("JWT secrets must be at least %d bytes long", SecretMinLength)
+	ErrUnknownKey       = errors.New("unknown JWT key ID")
+	ErrInvalidToken     = errors.New("invalid JWT")
+)
+
+// Config configures signed access tokens. Keys are derived from Secrets, the first secret signs new tokens
+// while all others are only used for verification, allowing to rotate secrets without invalidating tokens.
+type Config struct {
+	Algorithm Algorithm
+	Secrets   []string `json:"-"`
+	Issuer    string
+	Validity  time.Duration
+}
+
+// Claims of issued access tokens, the subject holds the user's ID.
+type Claims struct {
+	jwtgo.StandardClaims
+	Username string   `json:"username,omitempty"`
+	Scopes   []string `json:"scopes"`
+}
+
+// Key is a signing key derived from a secret.
+type Key struct {
+	ID        string
+	Algorithm Algorithm
+
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// DeriveKey derives a key for the algorithm from the secret via HKDF-SHA256.
+// The key ID is a hash of the algorithm and the public (EdDSA) or derived (HS256) key.
+func DeriveKey(algorithm Algorithm, secret string) (*Key, error) {
+	if len(secret) < SecretMinLength {
+		return nil, ErrWeakSecret
+	}
+
+	material := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, []byte(secret), nil, []byte("jwt:"+algorithm.String())), material); err != nil {
+		return nil, err
+	}
+
+	key := &Key{Algorithm: algorithm}
+	var id []byte
+	switch algorithm {
+	case AlgorithmHS256:
+		key.signKey = material
+		key.verifyKey = material
+		id = material
+	case AlgorithmEdDSA:
+		private := ed25519.NewKeyFromSeed(material)
+		public := private.Public().(ed25519.PublicKey)
+		key.signKey = private
+		key.verifyKey = public
+		id = public
+	default:
+		return nil, ErrUnknownAlgorithm
+	}
+
+	h := sha256.New()
+	h.Write([]byte(algorithm))
+	h.Write(id)
+	key.ID = base64.RawURLEncoding.EncodeToString(h.Sum(nil)[:12])
+
+	return key, nil
+}
+
+func (k *Key) method() jwtgo.SigningMethod {
+	if k.Algorithm == AlgorithmEdDSA {
+		return jwtgo.SigningMethodEdDSA
+	}
+
+	return jwtgo.SigningMethodHS256
+}
+
+// KeySet issues and verifies access tokens.
+type KeySet struct {
+	config Config
+	keys   []*Key
+}
+
+// NewKeySet derives all keys of the config.
+func NewKeySet(config Config) (*KeySet, error) {
+	if len(config.Secrets) == 0 {
+		return nil, ErrNoSecrets
+	}
+
+	ks := &KeySet{config: config, keys: make([]*Key, 0, len(config.Secrets))}
+	for _, secret := range config.Secrets {
+		key, err := DeriveKey(config.Algorithm, secret)
+		if err != nil {
+			return nil, err
+		}
+		ks.keys = append(ks.keys, key)
+	}
+
+	return ks, nil
+}
+
+// Validity returns the configured validity of issued tokens.
+func (ks *KeySet) Validity() time.Duration {
+	return ks.config.Validity
+}
+
+// Issue signs a new token for the user using the first key.
+func (ks *KeySet) Issue(userID string, username string, scopes []string, now time.Time) (string, *Claims, error) {
+	key := ks.keys[0]
+
+	claims := &Claims{
+		StandardClaims: jwtgo.StandardClaims{
+			Id:        uuid.NewString(),
+			Subject:   userID,
+			Issuer:    ks.config.Issuer,
+			IssuedAt:  now.Unix(),
+			NotBefore: now.Unix(),
+			ExpiresAt: now.Add(ks.config.Validity).Unix(),
+		},
+		Username: username,
+		Scopes:   scopes,
+	}
+
+	token := jwtgo.NewWithClaims(key.method(), claims)
+	token.Header["kid"] = key.ID
+
+	signed, err := token.SignedString(key.signKey)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return signed, claims, nil
+}
+
+// Parse verifies the token's signature using the key referenced by its `kid` header and validates its claims.
+func (ks *KeySet) Parse(token string) (*Claims, error) {
+	parser := &jwtgo.Parser{ValidMethods: []string{ks.keys[0].method().Alg()}}
+
+	claims := &Claims{}
+	_, err := parser.ParseWithClaims(token, claims, func(t *jwtgo.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		for _, key := range ks.keys {
+			if hmac.Equal([]byte(key.ID), []byte(kid)) {
+				return key.verifyKey, nil
+			}
+		}
+
+		return nil, ErrUnknownKey
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if claims.Issuer != ks.config.Issuer || len(claims.Subject) == 0 || claims.ExpiresAt == 0 {
+		return nil, fmt.Errorf("%w: unexpected claims", ErrInvalidToken)
+	}
+
+	return claims, nil
+}
+
+// LooksLikeJWT reports whether the token has the structure of a JWS in compact serialization.
+func LooksLikeJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+// JSONWebKey is a public key as defined by RFC 7517.
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+}
+
+// JSONWebKeySet is a set of public keys as defined by RFC 7517.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JWKS returns the public keys of the set, symmetric (HS256) keys are never published.
+func (ks *KeySet) JWKS() JSONWebKeySet {
+	set := JSONWebKeySet{Keys: make([]JSONWebKey, 0, len(ks.keys))}
+	for _, key := range ks.keys {
+		public, ok := key.verifyKey.(ed25519.PublicKey)
+		if !ok {
+			continue
+		}
+
+		set.Keys = append(set.Keys, JSONWebKey{
+			KeyType:   "OKP",
+			Curve:     "Ed25519",
+			X:         base64.RawURLEncoding.EncodeToString(public),
+			KeyID:     key.ID,
+			Algorithm: AlgorithmEdDSA.String(),
+			Use:       "sig",
+		})
+	}
+
+	return set
+}
@@ -0,0 +1,127 @@
+package jwt_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/driif/echo-go-starter/pkg/auth/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	secret1 = "4a5e0c7a8a7a4e0f9d6c3b2a1f0e9d8c"
+	secret2 = "b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6"
+)
+
+func newKeySet(t *testing.T, algorithm jwt.Algorithm, secrets ...string) *jwt.KeySet {
+	t.Helper()
+
+	ks, err := jwt.NewKeySet(jwt.Config{
+		Algorithm: algorithm,
+		Secrets:   secrets,
+		Issuer:    "test",
+		Validity:  15 * time.Minute,
+	})
+	require.NoError(t, err)
+
+	return ks
+}
+
+func TestIssueAndParse(t *testing.T) {
+	for _, algorithm := range []jwt.Algorithm{jwt.AlgorithmHS256, jwt.AlgorithmEdDSA} {
+		t.Run(algorithm.String(), func(t *testing.T) {
+			ks := newKeySet(t, algorithm, secret1)
+
+			token, issued, err := ks.Issue("f6ede5d8-e22a-4ca5-aa12-67821865a3e5", "user1@example.com", []string{"app"}, time.Now())
+			require.NoError(t, err)
+			assert.True(t, jwt.LooksLikeJWT(token))
+
+			claims, err := ks.Parse(token)
+			require.NoError(t, err)
+			assert.Equal(t, "f6ede5d8-e22a-4ca5-aa12-67821865a3e5", claims.Subject)
+			assert.Equal(t, "user1@example.com", claims.Username)
+			assert.Equal(t, []string{"app"}, claims.Scopes)
+			assert.Equal(t, "test", claims.Issuer)
+			assert.Equal(t, issued.Id, claims.Id)
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	ks := newKeySet(t, jwt.AlgorithmEdDSA, secret1)
+
+	expired, _, err := ks.Issue("f6ede5d8-e22a-4ca5-aa12-67821865a3e5", "", nil, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	_, err = ks.Parse(expired)
+	assert.ErrorIs(t, err, jwt.ErrInvalidToken)
+
+	other := newKeySet(t, jwt.AlgorithmEdDSA, secret2)
+	foreign, _, err := other.Issue("f6ede5d8-e22a-4ca5-aa12-67821865a3e5", "", nil, time.Now())
+	require.NoError(t, err)
+	_, err = ks.Parse(foreign)
+	assert.ErrorIs(t, err, jwt.ErrInvalidToken)
+
+	// tokens of another algorithm are rejected, even if signed with a known secret
+	hs := newKeySet(t, jwt.AlgorithmHS256, secret1)
+	hsToken, _, err := hs.Issue("f6ede5d8-e22a-4ca5-aa12-67821865a3e5", "", nil, time.Now())
+	require.NoError(t, err)
+	_, err = ks.Parse(hsToken)
+	assert.ErrorIs(t, err, jwt.ErrInvalidToken)
+
+	token, _, err := ks.Issue("f6ede5d8-e22a-4ca5-aa12-67821865a3e5", "", nil, time.Now())
+	require.NoError(t, err)
+	parts := strings.Split(token, ".")
+	_, err = ks.Parse(parts[0] + "." + parts[1] + ".AAAA")
+	assert.ErrorIs(t, err, jwt.ErrInvalidToken)
+
+	_, err = ks.Parse("not-a-jwt")
+	assert.ErrorIs(t, err, jwt.ErrInvalidToken)
+}
+
+func TestRotation(t *testing.T) {
+	old := newKeySet(t, jwt.AlgorithmEdDSA, secret1)
+	token, _, err := old.Issue("f6ede5d8-e22a-4ca5-aa12-67821865a3e5", "", nil, time.Now())
+	require.NoError(t, err)
+
+	// secret2 signs new tokens, secret1 is kept for verification only
+	rotated := newKeySet(t, jwt.AlgorithmEdDSA, secret2, secret1)
+	_, err = rotated.Parse(token)
+	require.NoError(t, err)
+
+	newToken, _, err := rotated.Issue("f6ede5d8-e22a-4ca5-aa12-67821865a3e5", "", nil, time.Now())
+	require.NoError(t, err)
+	_, err = old.Parse(newToken)
+	assert.ErrorIs(t, err, jwt.ErrInvalidToken)
+
+	jwks := rotated.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.NotEqual(t, jwks.Keys[0].KeyID, jwks.Keys[1].KeyID)
+	assert.Equal(t, old.JWKS().Keys[0], jwks.Keys[1])
+	assert.Equal(t, "OKP", jwks.Keys[0].KeyType)
+	assert.Equal(t, "Ed25519", jwks.Keys[0].Curve)
+}
+
+func TestDeriveKey(t *testing.T) {
+	k1, err := jwt.DeriveKey(jwt.AlgorithmHS256, secret1)
+	require.NoError(t, err)
+	k2, err := jwt.DeriveKey(jwt.AlgorithmHS256, secret1)
+	require.NoError(t, err)
+	assert.Equal(t, k1.ID, k2.ID, "derivation must be deterministic across replicas")
+
+	k3, err := jwt.DeriveKey(jwt.AlgorithmEdDSA, secret1)
+	require.NoError(t, err)
+	assert.NotEqual(t, k1.ID, k3.ID)
+
+	_, err = jwt.DeriveKey(jwt.AlgorithmHS256, "short")
+	assert.ErrorIs(t, err, jwt.ErrWeakSecret)
+
+	_, err = jwt.DeriveKey("RS256", secret1)
+	assert.ErrorIs(t, err, jwt.ErrUnknownAlgorithm)
+
+	_, err = jwt.NewKeySet(jwt.Config{Algorithm: jwt.AlgorithmHS256})
+	assert.ErrorIs(t, err, jwt.ErrNoSecrets)
+
+	assert.Empty(t, newKeySet(t, jwt.AlgorithmHS256, secret1).JWKS().Keys, "symmetric keys must never be published")
+}
@@ -0,0 +1,86 @@
+package lockout
+
+import (
+	"time"
+)
+
+// Policy configures how failed attempts are throttled. After FreeAttempts failures, each further attempt has to wait
+// for an exponentially growing delay (BaseDelay, 2*BaseDelay, 4*BaseDelay, ... capped at MaxDelay) after the last
+// failure. Reaching LockoutThreshold failures locks for LockoutDuration, every further failure renews the lock.
+// Failures are forgotten once no failure occurred for ResetAfter.
+type Policy struct {
+	FreeAttempts     int
+	BaseDelay        time.Duration
+	MaxDelay         time.Duration
+	LockoutThreshold int // 0 disables lockouts
+	LockoutDuration  time.Duration
+	ResetAfter       time.Duration // 0 never forgets failures
+}
+
+// State is the persisted record of failed attempts for a single key.
+type State struct {
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   time.Time
+}
+
+// Delay returns the delay required after the given number of failures.
+func (p Policy) Delay(failures int) time.Duration {
+	if failures <= p.FreeAttempts || p.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := p.FreeAttempts + 1; i < failures; i++ {
+		delay *= 2
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+
+	return delay
+}
+
+// Expired reports whether the failures of the state are old enough to be forgotten.
+func (p Policy) Expired(s State, now time.Time) bool {
+	return p.ResetAfter > 0 && !now.Before(s.LastFailureAt.Add(p.ResetAfter)) && !now.Before(s.LockedUntil)
+}
+
+// RetryAfter returns how long the next attempt has to wait, zero if it is allowed now.
+// locked is true if the wait is caused by a lockout instead of a progressive delay.
+func (p Policy) RetryAfter(s State, now time.Time) (wait time.Duration, locked bool) {
+	if now.Before(s.LockedUntil) {
+		return s.LockedUntil.Sub(now), true
+	}
+
+	if p.Expired(s, now) {
+		return 0, false
+	}
+
+	next := s.LastFailureAt.Add(p.Delay(s.Failures))
+	if now.Before(next) {
+		return next.Sub(now), false
+	}
+
+	return 0, false
+}
+
+// RegisterFailure returns the state after another failed attempt at now.
+func (p Policy) RegisterFailure(s State, now time.Time) State {
+	if p.Expired(s, now) {
+		s = State{}
+	}
+
+	s.Failures++
+	s.LastFailureAt = now
+
+	if p.LockoutThreshold > 0 && s.Failures >= p.LockoutThreshold {
+		s.LockedUntil = now.Add(p.LockoutDuration)
+	}
+
+	return s
+}
@@ -0,0 +1,113 @@
+package lockout_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/driif/echo-go-starter/pkg/auth/lockout"
+	"github.com/stretchr/testify/assert"
+)
+
+var testPolicy = lockout.Policy{
+	FreeAttempts:     3,
+	BaseDelay:        time.Second,
+	MaxDelay:         10 * time.Second,
+	LockoutThreshold: 8,
+	LockoutDuration:  15 * time.Minute,
+	ResetAfter:       time.Hour,
+}
+
+func TestDelay(t *testing.T) {
+	expected := []time.Duration{0, 0, 0, 0, time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for failures, want := range expected {
+		assert.Equal(t, want, testPolicy.Delay(failures), "failures=%d", failures)
+	}
+
+	assert.Equal(t, 10*time.Second, testPolicy.Delay(1000))
+
+	uncapped := testPolicy
+	uncapped.MaxDelay = 0
+	assert.Equal(t, 16*time.Second, uncapped.Delay(8))
+
+	noDelay := testPolicy
+	noDelay.BaseDelay = 0
+	assert.Equal(t, time.Duration(0), noDelay.Delay(7))
+}
+
+func TestProgressiveDelay(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	var s lockout.State
+	for i := 0; i < 3; i++ {
+		s = testPolicy.RegisterFailure(s, now)
+		wait, locked := testPolicy.RetryAfter(s, now)
+		assert.Zero(t, wait)
+		assert.False(t, locked)
+	}
+
+	s = testPolicy.RegisterFailure(s, now)
+	wait, locked := testPolicy.RetryAfter(s, now)
+	assert.Equal(t, time.Second, wait)
+	assert.False(t, locked)
+
+	wait, _ = testPolicy.RetryAfter(s, now.Add(400*time.Millisecond))
+	assert.Equal(t, 600*time.Millisecond, wait)
+
+	wait, _ = testPolicy.RetryAfter(s, now.Add(time.Second))
+	assert.Zero(t, wait)
+
+	s = testPolicy.RegisterFailure(s, now.Add(time.Second))
+	wait, _ = testPolicy.RetryAfter(s, now.Add(time.Second))
+	assert.Equal(t, 2*time.Second, wait)
+}
+
+func TestLockout(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	s := lockout.State{Failures: 7, LastFailureAt: now.Add(-time.Minute)}
+	s = testPolicy.RegisterFailure(s, now)
+	assert.Equal(t, 8, s.Failures)
+	assert.Equal(t, now.Add(15*time.Minute), s.LockedUntil)
+
+	wait, locked := testPolicy.RetryAfter(s, now.Add(5*time.Minute))
+	assert.Equal(t, 10*time.Minute, wait)
+	assert.True(t, locked)
+
+	// the lock has passed, but the progressive delay is long over as well
+	wait, locked = testPolicy.RetryAfter(s, now.Add(15*time.Minute))
+	assert.Zero(t, wait)
+	assert.False(t, locked)
+
+	// further failures renew the lock immediately
+	s = testPolicy.RegisterFailure(s, now.Add(16*time.Minute))
+	assert.Equal(t, now.Add(31*time.Minute), s.LockedUntil)
+
+	noLockout := testPolicy
+	noLockout.LockoutThreshold = 0
+	s = noLockout.RegisterFailure(lockout.State{Failures: 100, LastFailureAt: now}, now)
+	assert.True(t, s.LockedUntil.IsZero())
+}
+
+func TestReset(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	s := lockout.State{Failures: 6, LastFailureAt: now.Add(-2 * time.Hour)}
+	assert.True(t, testPolicy.Expired(s, now))
+
+	wait, _ := testPolicy.RetryAfter(s, now)
+	assert.Zero(t, wait)
+
+	s = testPolicy.RegisterFailure(s, now)
+	assert.Equal(t, 1, s.Failures)
+	assert.Equal(t, now, s.LastFailureAt)
+
+	// an active lock is never forgotten
+	short := testPolicy
+	short.ResetAfter = time.Minute
+	locked := lockout.State{Failures: 8, LastFailureAt: now.Add(-5 * time.Minute), LockedUntil: now.Add(10 * time.Minute)}
+	assert.False(t, short.Expired(locked, now))
+
+	never := testPolicy
+	never.ResetAfter = 0
+	assert.False(t, never.Expired(lockout.State{Failures: 1, LastFailureAt: now.Add(-24 * time.Hour)}, now))
+}
@@ -0,0 +1,93 @@
+package oidc
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// clockSkew tolerated when validating time based claims of ID tokens.
+const clockSkew = time.Minute
+
+// Claims of a verified ID token, including the standard profile and email claims.
+// https://openid.net/specs/openid-connect-core-1_0.html#StandardClaims
+type Claims struct {
+	Issuer          string   `json:"iss"`
+	Subject         string   `json:"sub"`
+	Audience        Audience `json:"aud"`
+	ExpiresAt       int64    `json:"exp"`
+	IssuedAt        int64    `json:"iat"`
+	NotBefore       int64    `json:"nbf,omitempty"`
+	Nonce           string   `json:"nonce"`
+	AuthorizedParty string   `json:"azp,omitempty"`
+	Email           string   `json:"email,omitempty"`
+	EmailVerified   Bool     `json:"email_verified,omitempty"`
+	Name            string   `json:"name,omitempty"`
+	Locale          string   `json:"locale,omitempty"`
+
+	now time.Time
+}
+
+// Valid checks the time based claims, it's called while parsing the token.
+func (c *Claims) Valid() error {
+	now := c.now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	if c.ExpiresAt == 0 || now.Add(-clockSkew).After(time.Unix(c.ExpiresAt, 0)) {
+		return errors.New("token is expired")
+	}
+	if c.NotBefore != 0 && now.Add(clockSkew).Before(time.Unix(c.NotBefore, 0)) {
+		return errors.New("token is not valid yet")
+	}
+	if c.IssuedAt != 0 && now.Add(clockSkew).Before(time.Unix(c.IssuedAt, 0)) {
+		return errors.New("token used before issued")
+	}
+
+	return nil
+}
+
+// Audience is either a single string or an array of strings.
+type Audience []string
+
+func (a *Audience) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(b, &multiple); err != nil {
+		return err
+	}
+
+	*a = multiple
+	return nil
+}
+
+// Bool accepts booleans encoded as strings, as sent by some providers.
+type Bool bool
+
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	var v bool
+	if err := json.Unmarshal(data, &v); err == nil {
+		*b = Bool(v)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+
+	*b = Bool(v)
+	return nil
+}
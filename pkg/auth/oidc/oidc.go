@@ -0,0 +1,368 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/driif/echo-go-starter/pkg/slices"
+	"github.com/driif/echo-go-starter/pkg/strs"
+	jwtgo "github.com/golang-jwt/jwt"
+)
+
+// DiscoveryPath is appended to the issuer to retrieve the provider's metadata.
+const DiscoveryPath = "/.well-known/openid-configuration"
+
+// jwksRefreshInterval limits refetching the provider's keys when encountering an unknown key ID.
+const jwksRefreshInterval = time.Minute
+
+// maxResponseSize limits the size of responses read from providers.
+const maxResponseSize = 1 << 20
+
+var (
+	ErrDiscoveryFailed = errors.New("OIDC discovery failed")
+	ErrExchangeFailed  = errors.New("OIDC code exchange failed")
+	ErrInvalidIDToken  = errors.New("invalid OIDC ID token")
+	ErrUnknownKey      = errors.New("unknown OIDC key ID")
+)
+
+// SupportedAlgorithms lists the accepted ID token signing algorithms, symmetric algorithms are deliberately excluded.
+var SupportedAlgorithms = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// ProviderConfig configures an external OpenID Connect provider, which is referenced by its Name.
+type ProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string `json:"-"`
+	// RedirectURL is registered with the provider and receives the authorization code, typically a frontend route.
+	RedirectURL string
+	Scopes      []string
+	// LinkByEmail links an identity to an existing user with the same username if the provider verified the email address.
+	// Only enable this for providers trusted to verify email addresses.
+	LinkByEmail bool
+}
+
+// Discovery is the subset of the provider metadata used.
+// https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderMetadata
+type Discovery struct {
+	Issuer                           string   `json:"issuer"`
+	AuthorizationEndpoint            string   `json:"authorization_endpoint"`
+	TokenEndpoint                    string   `json:"token_endpoint"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+}
+
+// TokenResponse is returned by the provider's token endpoint.
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// Provider performs the authorization code flow with PKCE against an OpenID Connect provider.
+// The provider's metadata and keys are fetched on first use and cached.
+type Provider struct {
+	Config ProviderConfig
+
+	client *http.Client
+
+	mu            sync.Mutex
+	discovery     *Discovery
+	keys          map[string]interface{}
+	keysFetchedAt time.Time
+}
+
+// NewProvider returns a provider using the client for all requests, http.DefaultClient if nil.
+func NewProvider(config ProviderConfig, client *http.Client) *Provider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &Provider{
+		Config: config,
+		client: client,
+	}
+}
+
+// Discover returns the provider's metadata, fetching it if not yet cached.
+func (p *Provider) Discover(ctx context.Context) (*Discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.discover(ctx)
+}
+
+func (p *Provider) discover(ctx context.Context) (*Discovery, error) {
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	var d Discovery
+	if err := p.getJSON(ctx, strings.TrimSuffix(p.Config.Issuer, "/")+DiscoveryPath, &d); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDiscoveryFailed, err)
+	}
+
+	// https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderConfigurationValidation
+	if d.Issuer != p.Config.Issuer {
+		return nil, fmt.Errorf("%w: issuer %q does not match configured issuer %q", ErrDiscoveryFailed, d.Issuer, p.Config.Issuer)
+	}
+	if len(d.AuthorizationEndpoint) == 0 || len(d.TokenEndpoint) == 0 || len(d.JWKSURI) == 0 {
+		return nil, fmt.Errorf("%w: missing endpoints", ErrDiscoveryFailed)
+	}
+
+	p.discovery = &d
+	return p.discovery, nil
+}
+
+// AuthCodeURL returns the URL the user is redirected to for authentication at the provider.
+func (p *Provider) AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	d, err := p.Discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(d.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid authorization endpoint: %v", ErrDiscoveryFailed, err)
+	}
+
+	scopes := p.Config.Scopes
+	if !slices.ContainsString(scopes, "openid") {
+		scopes = append([]string{"openid"}, scopes...)
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.Config.ClientID)
+	q.Set("redirect_uri", p.Config.RedirectURL)
+	q.Set("scope", strings.Join(scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// Exchange redeems the authorization code for tokens at the provider's token endpoint.
+func (p *Provider) Exchange(ctx context.Context, code string, codeVerifier string) (*TokenResponse, error) {
+	d, err := p.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.Config.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+
+	// public clients only identify themselves, confidential clients authenticate via client_secret_basic
+	confidential := len(p.Config.ClientSecret) > 0
+	if !confidential {
+		form.Set("client_id", p.Config.ClientID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if confidential {
+		req.SetBasicAuth(url.QueryEscape(p.Config.ClientID), url.QueryEscape(p.Config.ClientSecret))
+	}
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrExchangeFailed, err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrExchangeFailed, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: status %d: %s", ErrExchangeFailed, res.StatusCode, body)
+	}
+
+	var tokens TokenResponse
+	if err := json.Unmarshal(body, &tokens); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrExchangeFailed, err)
+	}
+	if len(tokens.IDToken) == 0 {
+		return nil, fmt.Errorf("%w: response is missing the ID token", ErrExchangeFailed)
+	}
+
+	return &tokens, nil
+}
+
+// VerifyIDToken verifies the signature and claims of the ID token, which must have been issued for this client
+// in response to the authentication request identified by the nonce.
+// https://openid.net/specs/openid-connect-core-1_0.html#IDTokenValidation
+func (p *Provider) VerifyIDToken(ctx context.Context, raw string, nonce string, now time.Time) (*Claims, error) {
+	d, err := p.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	algorithms := SupportedAlgorithms
+	if len(d.IDTokenSigningAlgValuesSupported) > 0 {
+		algorithms = intersectStrings(SupportedAlgorithms, d.IDTokenSigningAlgValuesSupported)
+	}
+
+	claims := &Claims{now: now}
+	parser := &jwtgo.Parser{ValidMethods: algorithms}
+	_, err = parser.ParseWithClaims(raw, claims, func(token *jwtgo.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.key(ctx, kid)
+	})
+	if err != nil {
+		var validationErr *jwtgo.ValidationError
+		if errors.As(err, &validationErr) && errors.Is(validationErr.Inner, ErrUnknownKey) {
+			return nil, ErrUnknownKey
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	if claims.Issuer != d.Issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidIDToken, claims.Issuer)
+	}
+	if !slices.ContainsString(claims.Audience, p.Config.ClientID) {
+		return nil, fmt.Errorf("%w: token was not issued for this client", ErrInvalidIDToken)
+	}
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.Config.ClientID {
+		return nil, fmt.Errorf("%w: unexpected authorized party %q", ErrInvalidIDToken, claims.AuthorizedParty)
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+	if len(claims.Subject) == 0 {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidIDToken)
+	}
+
+	return claims, nil
+}
+
+// key returns the provider's verification key with the ID, refetching the keys (rate-limited) if unknown.
+func (p *Provider) key(ctx context.Context, kid string) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+
+	if time.Since(p.keysFetchedAt) < jwksRefreshInterval {
+		return nil, ErrUnknownKey
+	}
+
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var set JSONWebKeySet
+	if err := p.getJSON(ctx, d.JWKSURI, &set); err != nil {
+		return nil, fmt.Errorf("failed to fetch OIDC keys: %w", err)
+	}
+
+	keys, err := set.PublicKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+
+	return nil, ErrUnknownKey
+}
+
+// lookupKey finds the key by ID, tokens without a key ID are only accepted if the provider has a single key.
+func (p *Provider) lookupKey(kid string) (interface{}, bool) {
+	if len(kid) == 0 && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+func (p *Provider) getJSON(ctx context.Context, u string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", res.StatusCode, u)
+	}
+
+	return json.NewDecoder(io.LimitReader(res.Body, maxResponseSize)).Decode(v)
+}
+
+// GeneratePKCE returns a random code verifier and its S256 code challenge.
+// https://datatracker.ietf.org/doc/html/rfc7636#section-4.1
+func GeneratePKCE() (verifier string, challenge string, err error) {
+	b, err := strs.GenerateRandomBytes(32)
+	if err != nil {
+		return "", "", err
+	}
+
+	verifier = base64.RawURLEncoding.EncodeToString(b)
+	return verifier, CodeChallenge(verifier), nil
+}
+
+// CodeChallenge derives the S256 code challenge of the verifier.
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// GenerateRandomString returns a random URL-safe string suitable as state or nonce.
+func GenerateRandomString() (string, error) {
+	b, err := strs.GenerateRandomBytes(32)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func intersectStrings(a []string, b []string) []string {
+	res := make([]string, 0, len(a))
+	for _, e := range a {
+		if slices.ContainsString(b, e) {
+			res = append(res, e)
+		}
+	}
+
+	return res
+}
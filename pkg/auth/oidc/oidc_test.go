@@ -0,0 +1,134 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/driif/echo-go-starter/internal/server/test"
+	"github.com/driif/echo-go-starter/pkg/auth/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeChallenge(t *testing.T) {
+	sum := sha256.Sum256([]byte("verifier"))
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(sum[:]), oidc.CodeChallenge("verifier"))
+
+	verifier, challenge, err := oidc.GeneratePKCE()
+	require.NoError(t, err)
+	assert.Len(t, verifier, 43)
+	assert.Equal(t, oidc.CodeChallenge(verifier), challenge)
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	ctx := context.Background()
+	fake := test.NewOIDCProvider(t)
+	p := oidc.NewProvider(fake.Config("test"), nil)
+
+	verifier, challenge, err := oidc.GeneratePKCE()
+	require.NoError(t, err)
+
+	authCodeURL, err := p.AuthCodeURL(ctx, "state-1", "nonce-1", challenge)
+	require.NoError(t, err)
+	assert.Contains(t, authCodeURL, fake.Issuer()+"/authorize?")
+
+	code, state := fake.Authorize(authCodeURL)
+	assert.Equal(t, "state-1", state)
+
+	tokens, err := p.Exchange(ctx, code, verifier)
+	require.NoError(t, err)
+
+	claims, err := p.VerifyIDToken(ctx, tokens.IDToken, "nonce-1", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, fake.User.Subject, claims.Subject)
+	assert.Equal(t, fake.User.Email, claims.Email)
+	assert.True(t, bool(claims.EmailVerified))
+	assert.Equal(t, fake.User.Name, claims.Name)
+
+	// codes are single use
+	_, err = p.Exchange(ctx, code, verifier)
+	assert.ErrorIs(t, err, oidc.ErrExchangeFailed)
+}
+
+func TestExchangeInvalidVerifier(t *testing.T) {
+	ctx := context.Background()
+	fake := test.NewOIDCProvider(t)
+	p := oidc.NewProvider(fake.Config("test"), nil)
+
+	_, challenge, err := oidc.GeneratePKCE()
+	require.NoError(t, err)
+
+	authCodeURL, err := p.AuthCodeURL(ctx, "state", "nonce", challenge)
+	require.NoError(t, err)
+
+	code, _ := fake.Authorize(authCodeURL)
+
+	_, err = p.Exchange(ctx, code, "not-the-verifier")
+	assert.ErrorIs(t, err, oidc.ErrExchangeFailed)
+}
+
+func TestVerifyIDTokenInvalid(t *testing.T) {
+	ctx := context.Background()
+	fake := test.NewOIDCProvider(t)
+	p := oidc.NewProvider(fake.Config("test"), nil)
+
+	tests := []struct {
+		name   string
+		modify func(claims map[string]interface{})
+		nonce  string
+	}{
+		{name: "nonce mismatch", modify: func(map[string]interface{}) {}, nonce: "other"},
+		{name: "expired", modify: func(c map[string]interface{}) { c["exp"] = time.Now().Add(-time.Hour).Unix() }},
+		{name: "missing expiry", modify: func(c map[string]interface{}) { delete(c, "exp") }},
+		{name: "issued in the future", modify: func(c map[string]interface{}) { c["iat"] = time.Now().Add(time.Hour).Unix() }},
+		{name: "other issuer", modify: func(c map[string]interface{}) { c["iss"] = "https://evil.example.com" }},
+		{name: "other audience", modify: func(c map[string]interface{}) { c["aud"] = "other-client" }},
+		{name: "multiple audiences without azp", modify: func(c map[string]interface{}) { c["aud"] = []string{test.OIDCProviderClientID, "other-client"} }},
+		{name: "missing subject", modify: func(c map[string]interface{}) { c["sub"] = "" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := fake.IDTokenClaims(fake.User, "nonce")
+			tt.modify(claims)
+
+			nonce := tt.nonce
+			if len(nonce) == 0 {
+				nonce = "nonce"
+			}
+
+			_, err := p.VerifyIDToken(ctx, fake.SignIDToken(claims), nonce, time.Now())
+			assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+		})
+	}
+
+	claims := fake.IDTokenClaims(fake.User, "nonce")
+	claims["aud"] = []string{test.OIDCProviderClientID, "other-client"}
+	claims["azp"] = test.OIDCProviderClientID
+	_, err := p.VerifyIDToken(ctx, fake.SignIDToken(claims), "nonce", time.Now())
+	assert.NoError(t, err)
+}
+
+func TestVerifyIDTokenForeignSignature(t *testing.T) {
+	ctx := context.Background()
+	fake := test.NewOIDCProvider(t)
+	other := test.NewOIDCProvider(t)
+	p := oidc.NewProvider(fake.Config("test"), nil)
+
+	claims := fake.IDTokenClaims(fake.User, "nonce")
+	_, err := p.VerifyIDToken(ctx, other.SignIDToken(claims), "nonce", time.Now())
+	assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+}
+
+func TestDiscoveryIssuerMismatch(t *testing.T) {
+	fake := test.NewOIDCProvider(t)
+
+	config := fake.Config("test")
+	config.Issuer += "/"
+
+	_, err := oidc.NewProvider(config, nil).Discover(context.Background())
+	assert.ErrorIs(t, err, oidc.ErrDiscoveryFailed)
+}
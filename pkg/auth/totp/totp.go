@@ -0,0 +1,151 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // RFC 6238 default, supported by all authenticator apps
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/driif/echo-go-starter/pkg/strs"
+)
+
+const (
+	// SecretSize is the size of generated secrets in bytes (160 bits, as recommended by RFC 4226).
+	SecretSize = 20
+	// Digits of generated codes.
+	Digits = 6
+	// Period is the validity of a single code.
+	Period = 30 * time.Second
+	// Skew is the number of periods before and after the current one, codes of which are accepted as well.
+	Skew = 1
+
+	recoveryCodeSize = 10
+)
+
+var (
+	ErrInvalidSecret = errors.New("invalid TOTP secret")
+
+	encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+// GenerateSecret returns a new random secret in base32 encoding (without padding).
+func GenerateSecret() (string, error) {
+	b, err := strs.GenerateRandomBytes(SecretSize)
+	if err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(b), nil
+}
+
+// URI returns the otpauth:// key URI used to enroll the secret in authenticator apps, usually rendered as QR code.
+// See https://github.com/google/google-authenticator/wiki/Key-Uri-Format.
+func URI(issuer string, account string, secret string) string {
+	u := url.URL{
+		Scheme: "otpauth",
+		Host:   "totp",
+		Path:   "/" + issuer + ":" + account,
+		RawQuery: url.Values{
+			"secret":    []string{secret},
+			"issuer":    []string{issuer},
+			"algorithm": []string{"SHA1"},
+			"digits":    []string{fmt.Sprint(Digits)},
+			"period":    []string{fmt.Sprint(int(Period.Seconds()))},
+		}.Encode(),
+	}
+
+	return u.String()
+}
+
+// Step returns the time step (counter) of t.
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period.Seconds())
+}
+
+// Code returns the code of the secret for the time step.
+func Code(secret string, step int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(key) == 0 {
+		return "", ErrInvalidSecret
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// dynamic truncation, RFC 4226 section 5.3
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", Digits, value%mod), nil
+}
+
+// Validate checks the code against the secret at t, accepting codes of Skew adjacent periods.
+// Only codes of time steps after lastUsedStep are accepted, preventing the reuse of codes.
+// Returns the matching time step, which should be persisted as lastUsedStep.
+func Validate(secret string, code string, t time.Time, lastUsedStep int64) (int64, bool, error) {
+	code = strings.TrimSpace(code)
+	if len(code) != Digits {
+		return 0, false, nil
+	}
+
+	current := Step(t)
+	for step := current - Skew; step <= current+Skew; step++ {
+		if step <= lastUsedStep {
+			continue
+		}
+
+		expected, err := Code(secret, step)
+		if err != nil {
+			return 0, false, err
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true, nil
+		}
+	}
+
+	return 0, false, nil
+}
+
+// GenerateRecoveryCodes returns n random one-time recovery codes formatted as `XXXXX-XXXXX`.
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		b, err := strs.GenerateRandomBytes(recoveryCodeSize * 5 / 8)
+		if err != nil {
+			return nil, err
+		}
+
+		c := encoding.EncodeToString(b)
+		codes = append(codes, c[:5]+"-"+c[5:])
+	}
+
+	return codes, nil
+}
+
+// HashRecoveryCode returns the hash of the recovery code to persist. Recovery codes are random with 50 bits
+// of entropy, so a fast hash suffices and allows looking up codes directly.
+// Case, whitespace and dashes are ignored.
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+	sum := sha256.Sum256([]byte(normalized))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,118 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/driif/echo-go-starter/pkg/auth/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Secret is the SHA1 secret used by the test vectors of RFC 6238 appendix B.
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCodeRFC6238(t *testing.T) {
+	// the RFC lists 8 digit codes, we use the last 6 digits
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for unix, expected := range vectors {
+		code, err := totp.Code(rfc6238Secret, totp.Step(time.Unix(unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, expected, code, "time %d", unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+
+	step, ok, err := totp.Validate(rfc6238Secret, "050471", now, 0)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, totp.Step(now), step)
+
+	// the code of the previous period is accepted as well
+	_, ok, err = totp.Validate(rfc6238Secret, "050471", now.Add(totp.Period), 0)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, ok, err = totp.Validate(rfc6238Secret, "050471", now.Add(2*totp.Period), 0)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// replays are rejected
+	_, ok, err = totp.Validate(rfc6238Secret, "050471", now, step)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = totp.Validate(rfc6238Secret, "000000", now, 0)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = totp.Validate(rfc6238Secret, "12345", now, 0)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = totp.Validate("not base32!", "123456", now, 0)
+	assert.ErrorIs(t, err, totp.ErrInvalidSecret)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	other, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+
+	_, ok, err := totp.Validate(secret, code, time.Now(), 0)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestURI(t *testing.T) {
+	uri := totp.URI("echo-go-starter", "user1@example.com", "JBSWY3DPEHPK3PXP")
+
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/echo-go-starter:user1@example.com", u.Path)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", u.Query().Get("secret"))
+	assert.Equal(t, "echo-go-starter", u.Query().Get("issuer"))
+	assert.Equal(t, "6", u.Query().Get("digits"))
+	assert.Equal(t, "30", u.Query().Get("period"))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := totp.GenerateRecoveryCodes(10)
+	require.NoError(t, err)
+	require.Len(t, codes, 10)
+
+	seen := map[string]bool{}
+	for _, code := range codes {
+		assert.Len(t, code, 11)
+		assert.Equal(t, 5, strings.Index(code, "-"))
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+
+	hash := totp.HashRecoveryCode(codes[0])
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, totp.HashRecoveryCode(" "+strings.ToLower(strings.ReplaceAll(codes[0], "-", ""))+" "))
+	assert.NotEqual(t, hash, totp.HashRecoveryCode(codes[1]))
+}
@@ -9,29 +9,66 @@ import (
 	"github.com/volatiletech/sqlboiler/v4/boil"
 )
 
-// TxFn is a function that can be executed in a transaction
+// TxFn is a function that can be executed in a transaction. It isn't passed a context carrying the transaction,
+// transactions it starts using the caller's context are nested if the context tracks its transactions
+// (see WithTxScope), e.g. request contexts. Otherwise, they're separate transactions.
 type TxFn func(boil.ContextExecutor) error
 
-// WithTransaction executes the given function in a transaction
+// TxCtxFn is a function that can be executed in a transaction, receiving a context carrying the transaction.
+// Transactions started using this context are nested within the transaction (see WithTransactionContext).
+type TxCtxFn func(ctx context.Context, exec boil.ContextExecutor) error
+
+// WithTransaction executes the given function in a transaction. If ctx carries a transaction or a transaction of
+// its scope is running, the function is executed within a savepoint of it (see WithConfiguredTransactionContext).
 func WithTransaction(ctx context.Context, db *sql.DB, fn TxFn) error {
 	return WithConfiguredTransaction(ctx, db, nil, fn)
 }
 
-// WithConfiguredTransaction executes the given function in a transaction with the given options
+// WithConfiguredTransaction executes the given function in a transaction with the given options.
+// Errors of the function as well as failing to commit are returned.
 func WithConfiguredTransaction(ctx context.Context, db *sql.DB, options *sql.TxOptions, fn TxFn) error {
+	return WithConfiguredTransactionContext(ctx, db, options, func(_ context.Context, exec boil.ContextExecutor) error {
+		return fn(exec)
+	})
+}
+
+// WithTransactionContext executes the given function in a transaction, passing a context carrying the transaction.
+func WithTransactionContext(ctx context.Context, db *sql.DB, fn TxCtxFn) error {
+	return WithConfiguredTransactionContext(ctx, db, nil, fn)
+}
+
+// WithConfiguredTransactionContext executes the given function in a transaction with the given options, passing a
+// context carrying the transaction. If ctx already carries a transaction or a transaction of its scope is running
+// (see WithTxScope), the function is executed within a savepoint of it instead: errors roll back to the savepoint,
+// leaving the outer transaction intact, and the options are ignored. Service functions can thus be composed without
+// knowing whether they're called within a transaction.
+// Hooks registered via OnCommit and OnRollback using the passed context (or ctx if it has a scope) run once the
+// outcome is known.
+func WithConfiguredTransactionContext(ctx context.Context, db *sql.DB, options *sql.TxOptions, fn TxCtxFn) (err error) {
+	if state, ok := txStateFromContext(ctx); ok {
+		return withSavepoint(ctx, state, fn)
+	}
+
 	tx, err := db.BeginTx(ctx, options)
 	if err != nil {
 		logs.LogFromContext(ctx).Warn().Err(err).Msg("Failed to start transaction")
 		return err
 	}
 
+	state := &txState{tx: tx}
+	restore := scopeFromContext(ctx).enter(state)
+
 	defer func() {
+		// hooks starting transactions using ctx start new ones
+		restore()
+
 		if p := recover(); p != nil {
 			logs.LogFromContext(ctx).Error().Interface("p", p).Msg("Recovered from panic, rolling back transaction and panicking again")
 
 			if txErr := tx.Rollback(); txErr != nil {
 				logs.LogFromContext(ctx).Warn().Err(txErr).Msg("Failed to roll back transaction after recovering from panic")
 			}
+			state.hooks.runRolledBack(ctx)
 
 			panic(p)
 		} else if err != nil {
@@ -40,15 +77,24 @@ func WithConfiguredTransaction(ctx context.Context, db *sql.DB, options *sql.TxO
 			if txErr := tx.Rollback(); txErr != nil {
 				logs.LogFromContext(ctx).Warn().Err(txErr).Msg("Failed to roll back transaction after receiving error")
 			}
+			state.hooks.runRolledBack(ctx)
 		} else {
+			if options == nil || !options.ReadOnly {
+				// recorded before committing, as the outcome of failed commits is unknown
+				MarkWrite(ctx)
+			}
+
 			err = tx.Commit()
 			if err != nil {
 				logs.LogFromContext(ctx).Warn().Err(err).Msg("Failed to commit transaction")
+				state.hooks.runRolledBack(ctx)
+			} else {
+				state.hooks.runCommitted(ctx)
 			}
 		}
 	}()
 
-	err = fn(tx)
+	err = fn(context.WithValue(ctx, txContextKey{}, state), tx)
 
 	return err
 }
@@ -24,3 +24,8 @@ func TestDBTypeConversions(t *testing.T) {
 	res2 = db.NullFloat32FromFloat64Ptr(nil)
 	assert.False(t, res2.Valid)
 }
+
+func TestEscapeLike(t *testing.T) {
+	assert.Equal(t, "max", db.EscapeLike("max"))
+	assert.Equal(t, `100\%\_off\\`, db.EscapeLike(`100%_off\`))
+}
@@ -0,0 +1,52 @@
+package db_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/lib/pq"
+)
+
+// txDriver is a database/sql driver only supporting transactions and statements without results, failing commits
+// with the queued errors and recording executed statements.
+type txDriver struct {
+	commitErrs []error
+	commits    int
+	rollbacks  int
+	statements []string
+}
+
+func (d *txDriver) Connect(context.Context) (driver.Conn, error) { return &txConn{d: d}, nil }
+func (d *txDriver) Driver() driver.Driver                        { return nil }
+
+type txConn struct {
+	d *txDriver
+}
+
+func (c *txConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *txConn) Close() error                        { return nil }
+func (c *txConn) Begin() (driver.Tx, error)           { return c, nil }
+
+func (c *txConn) Commit() error {
+	c.d.commits++
+	if len(c.d.commitErrs) > 0 {
+		err := c.d.commitErrs[0]
+		c.d.commitErrs = c.d.commitErrs[1:]
+		return err
+	}
+	return nil
+}
+
+func (c *txConn) Rollback() error {
+	c.d.rollbacks++
+	return nil
+}
+
+func (c *txConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.d.statements = append(c.d.statements, query)
+	return driver.RowsAffected(0), nil
+}
+
+var errSerializationFailure = &pq.Error{Code: db.PQErrSerializationFailure}
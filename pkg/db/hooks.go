@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/driif/echo-go-starter/pkg/logs"
+)
+
+var ErrNoTransaction = errors.New("context carries no transaction and no transaction of its scope is running")
+
+// Hook is run once the outcome of a transaction is known, receiving the context the transaction was started with.
+type Hook func(ctx context.Context)
+
+// txHooks collects the hooks registered within a transaction or savepoint.
+type txHooks struct {
+	mu         sync.Mutex
+	onCommit   []Hook
+	onRollback []Hook
+}
+
+// OnCommit registers a hook run after the transaction of ctx committed, e.g. to send a mail about a row inserted.
+// The transaction is the one carried by ctx or the running transaction of its scope (see WithTxScope), thus hooks
+// can be registered within a TxFn using its caller's context. Hooks registered within a savepoint rolled back are
+// discarded.
+func OnCommit(ctx context.Context, hook Hook) error {
+	state, ok := txStateFromContext(ctx)
+	if !ok {
+		return ErrNoTransaction
+	}
+
+	state.hooks.mu.Lock()
+	defer state.hooks.mu.Unlock()
+
+	state.hooks.onCommit = append(state.hooks.onCommit, hook)
+
+	return nil
+}
+
+// OnRollback registers a hook run after the transaction of ctx (or the savepoint it's nested in) rolled back,
+// including failing to commit. The transaction is looked up like for OnCommit.
+func OnRollback(ctx context.Context, hook Hook) error {
+	state, ok := txStateFromContext(ctx)
+	if !ok {
+		return ErrNoTransaction
+	}
+
+	state.hooks.mu.Lock()
+	defer state.hooks.mu.Unlock()
+
+	state.hooks.onRollback = append(state.hooks.onRollback, hook)
+
+	return nil
+}
+
+// merge moves the hooks of a released savepoint to its parent, so they run once the outcome of the parent is known.
+func (h *txHooks) merge(nested *txHooks) {
+	nested.mu.Lock()
+	onCommit, onRollback := nested.onCommit, nested.onRollback
+	nested.onCommit, nested.onRollback = nil, nil
+	nested.mu.Unlock()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.onCommit = append(h.onCommit, onCommit...)
+	h.onRollback = append(h.onRollback, onRollback...)
+}
+
+func (h *txHooks) runCommitted(ctx context.Context) {
+	h.mu.Lock()
+	hooks := h.onCommit
+	h.onCommit, h.onRollback = nil, nil
+	h.mu.Unlock()
+
+	runHooks(ctx, hooks, "commit")
+}
+
+func (h *txHooks) runRolledBack(ctx context.Context) {
+	h.mu.Lock()
+	hooks := h.onRollback
+	h.onCommit, h.onRollback = nil, nil
+	h.mu.Unlock()
+
+	runHooks(ctx, hooks, "rollback")
+}
+
+// runHooks runs the hooks in the order they were registered. A panicking hook is logged and doesn't prevent
+// running the remaining ones.
+func runHooks(ctx context.Context, hooks []Hook, outcome string) {
+	for _, hook := range hooks {
+		func() {
+			defer func() {
+				if p := recover(); p != nil {
+					logs.LogFromContext(ctx).Error().Interface("p", p).Str("outcome", outcome).Msg("Recovered from panic in transaction hook")
+				}
+			}()
+
+			hook(ctx)
+		}()
+	}
+}
@@ -0,0 +1,139 @@
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func TestTransactionHooks(t *testing.T) {
+	conn := sql.OpenDB(&txDriver{})
+	defer conn.Close()
+
+	var events []string
+	record := func(event string) db.Hook {
+		return func(context.Context) { events = append(events, event) }
+	}
+
+	err := db.WithTransactionContext(context.Background(), conn, func(ctx context.Context, _ boil.ContextExecutor) error {
+		require.NoError(t, db.OnCommit(ctx, record("commit 1")))
+		require.NoError(t, db.OnRollback(ctx, record("rollback 1")))
+		require.NoError(t, db.OnCommit(ctx, func(context.Context) { panic("hook panicked") }))
+		require.NoError(t, db.OnCommit(ctx, record("commit 2")))
+
+		assert.Empty(t, events)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"commit 1", "commit 2"}, events)
+
+	events = nil
+	cause := errors.New("insert failed")
+	err = db.WithTransactionContext(context.Background(), conn, func(ctx context.Context, _ boil.ContextExecutor) error {
+		require.NoError(t, db.OnCommit(ctx, record("commit")))
+		require.NoError(t, db.OnRollback(ctx, record("rollback")))
+		return cause
+	})
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, []string{"rollback"}, events)
+}
+
+func TestTransactionHooksCommitFailure(t *testing.T) {
+	conn := sql.OpenDB(&txDriver{commitErrs: []error{errSerializationFailure}})
+	defer conn.Close()
+
+	var events []string
+	err := db.WithTransactionContext(context.Background(), conn, func(ctx context.Context, _ boil.ContextExecutor) error {
+		require.NoError(t, db.OnCommit(ctx, func(context.Context) { events = append(events, "commit") }))
+		require.NoError(t, db.OnRollback(ctx, func(context.Context) { events = append(events, "rollback") }))
+		return nil
+	})
+	assert.True(t, db.IsRetryable(err))
+	assert.Equal(t, []string{"rollback"}, events)
+}
+
+func TestTransactionHooksPanic(t *testing.T) {
+	conn := sql.OpenDB(&txDriver{})
+	defer conn.Close()
+
+	rolledBack := false
+	assert.Panics(t, func() {
+		_ = db.WithTransactionContext(context.Background(), conn, func(ctx context.Context, _ boil.ContextExecutor) error {
+			require.NoError(t, db.OnRollback(ctx, func(context.Context) { rolledBack = true }))
+			panic("fn panicked")
+		})
+	})
+	assert.True(t, rolledBack)
+}
+
+func TestTransactionHooksSavepoint(t *testing.T) {
+	conn := sql.OpenDB(&txDriver{})
+	defer conn.Close()
+
+	var events []string
+	record := func(event string) db.Hook {
+		return func(context.Context) { events = append(events, event) }
+	}
+
+	err := db.WithTransactionContext(context.Background(), conn, func(ctx context.Context, _ boil.ContextExecutor) error {
+		err := db.WithTransactionContext(ctx, conn, func(ctx context.Context, _ boil.ContextExecutor) error {
+			require.NoError(t, db.OnCommit(ctx, record("released commit")))
+			return nil
+		})
+		require.NoError(t, err)
+
+		err = db.WithTransactionContext(ctx, conn, func(ctx context.Context, _ boil.ContextExecutor) error {
+			require.NoError(t, db.OnCommit(ctx, record("discarded commit")))
+			require.NoError(t, db.OnRollback(ctx, record("savepoint rollback")))
+			return errors.New("inner failed")
+		})
+		require.Error(t, err)
+		assert.Equal(t, []string{"savepoint rollback"}, events)
+
+		return db.OnCommit(ctx, record("outer commit"))
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"savepoint rollback", "released commit", "outer commit"}, events)
+}
+
+func TestTransactionHooksScope(t *testing.T) {
+	conn := sql.OpenDB(&txDriver{})
+	defer conn.Close()
+
+	ctx := db.WithTxScope(context.Background())
+
+	var events []string
+	record := func(event string) db.Hook {
+		return func(context.Context) { events = append(events, event) }
+	}
+
+	// TxFns register hooks using their caller's context
+	err := db.WithTransaction(ctx, conn, func(boil.ContextExecutor) error {
+		require.NoError(t, db.OnCommit(ctx, record("outer commit")))
+
+		err := db.WithTransaction(ctx, conn, func(boil.ContextExecutor) error {
+			require.NoError(t, db.OnCommit(ctx, record("discarded commit")))
+			require.NoError(t, db.OnRollback(ctx, record("savepoint rollback")))
+			return errors.New("inner failed")
+		})
+		require.Error(t, err)
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"savepoint rollback", "outer commit"}, events)
+
+	// the scope has no running transaction anymore
+	assert.ErrorIs(t, db.OnCommit(ctx, record("commit")), db.ErrNoTransaction)
+}
+
+func TestTransactionHooksWithoutTransaction(t *testing.T) {
+	assert.ErrorIs(t, db.OnCommit(context.Background(), func(context.Context) {}), db.ErrNoTransaction)
+	assert.ErrorIs(t, db.OnRollback(context.Background(), func(context.Context) {}), db.ErrNoTransaction)
+}
@@ -19,3 +19,11 @@ func ILike(val string, path ...string) qm.QueryMod {
 	// ! being inserted. On the contrary to other parts using PG queries, ? actually works with qm.Where.
 	return qm.Where(fmt.Sprintf("%s ILIKE ?", strings.Join(path, ".")), val)
 }
+
+// EscapeLike escapes all LIKE/ILIKE wildcard characters (`%`, `_` and `\`) within the value provided,
+// allowing user input to be safely enclosed in wildcards (e.g. `"%" + EscapeLike(q) + "%"`).
+func EscapeLike(val string) string {
+	return likeReplacer.Replace(val)
+}
+
+var likeReplacer = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
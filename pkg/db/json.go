@@ -1,10 +1,12 @@
 package db
 
 import (
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/lib/pq"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -12,6 +14,29 @@ import (
 
 const (
 	whereJSONMaxLevel = 10
+	whereJSONTag      = "where"
+)
+
+// Operators supported by WhereJSON via the `where` struct tag.
+const (
+	JSONOpEq  = "eq"
+	JSONOpNeq = "neq"
+	JSONOpGt  = "gt"
+	JSONOpGte = "gte"
+	JSONOpLt  = "lt"
+	JSONOpLte = "lte"
+	// JSONOpILike compares strings using ILIKE, the value is applied directly (see ILike).
+	JSONOpILike = "ilike"
+	// JSONOpExists checks the key to exist (true) or not to exist (false), requires a bool field.
+	JSONOpExists = "exists"
+	// JSONOpOr combines the conditions of a struct field (or of each element of a slice of structs) using OR.
+	JSONOpOr = "or"
+)
+
+var (
+	ErrEmptyJSONFilter = errors.New("filter resulted in empty query")
+
+	timeType = reflect.TypeOf(time.Time{})
 )
 
 // WhereJSON constructs a QueryMod for querying a JSONB column.
@@ -27,19 +52,29 @@ const (
 // At the moment, the root level `filter` value must either be a struct or a string.
 // WhereJSON will panic should it encounter a type it cannot process or the filter
 // provided results in an empty QueryMod - this allows for easier call chaining
-// at the expense of panics in case of incorrect filters being passed.
+// at the expense of panics in case of incorrect filters being passed. Use
+// TryWhereJSON to receive an error instead.
 //
 // WhereJSON should support all basic types as well as pointers and array/slices
 // of those out of the box, given the Postgres driver can handle their serialization.
-// nil pointers are skipped automatically.
-// At the moment, struct fields are only supported for composition purposes: if a
-// struct is encountered, WhereJSON recursively traverses it (up to 10 levels deep)
-// and adds all eligible fields to the top level query.
+// nil pointers are skipped automatically, as are invalid `null` package types
+// (e.g. null.String, null.Int or null.Time) - valid ones are compared by their value.
+// time.Time values are compared as timestamps.
+//
+// Embedded and untagged struct fields are used for composition purposes: WhereJSON
+// recursively traverses them (up to 10 levels deep) and adds all eligible fields to
+// the current level of the query. Struct fields with a `json` tag query the nested
+// object instead, e.g. `users.profile#>>'{address,city}' = ?`.
 // Should an array or slice be encountered, their values will be added using the
-// `<@` JSONB operator, checking whether all entries existx at the top level within
+// `<@` JSONB operator, checking whether all entries exist at the top level within
 // the JSON column.
-// At the time of writing, no support for special database/HTTP types such as the
-// `null` or `strfmt` packages exists - use their respective base types instead.
+//
+// By default, values are compared for equality. The `where` struct tag selects
+// another operator: `neq`, `gt`, `gte`, `lt`, `lte` (numbers and time.Time values
+// are cast accordingly), `ilike` (strings only) and `exists` (bool fields, checking
+// whether the key exists or not). Struct fields tagged with `where:"or"` combine their
+// fields using `OR` instead, slices of structs tagged with `where:"or"` match if any
+// of the structs matches (combining the fields of each struct using `AND`).
 //
 // Whilst WhereJSON was designed to be used with Postgres' JSONB column type, the
 // current implementation also supports the JSON type as long as the filter struct
@@ -47,79 +82,323 @@ const (
 // at some point in the future, so it is advised to use the JSONB data type unless
 // your requirements do not allow for it.
 func WhereJSON(table string, column string, filter interface{}) qm.QueryMod {
-	qms := whereJSON(table, column, filter, 0)
+	q, err := TryWhereJSON(table, column, filter)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// TryWhereJSON constructs a QueryMod for querying a JSONB column like WhereJSON,
+// returning an error instead of panicking if the filter cannot be processed or
+// results in an empty query (ErrEmptyJSONFilter).
+func TryWhereJSON(table string, column string, filter interface{}) (qm.QueryMod, error) {
+	if filter == nil {
+		return nil, errors.New("invalid filter type nil")
+	}
+
+	if s, ok := filter.(string); ok {
+		return qm.Expr(qm.Where(fmt.Sprintf("%s.%s::text = ?", table, column), s)), nil
+	}
+
+	rv := reflect.ValueOf(filter)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	qms, err := whereJSON(jsonPath{table: table, column: column}, rv, 0)
+	if err != nil {
+		return nil, err
+	}
 	if len(qms) == 0 {
-		panic(errors.New("filter resulted in empty query"))
+		return nil, ErrEmptyJSONFilter
 	}
-	return qm.Expr(qms...)
+
+	return qm.Expr(qms...), nil
 }
 
-func whereJSON(table string, column string, filter interface{}, level int) []qm.QueryMod {
+// WhereJSONKey constructs a QueryMod comparing a single (nested) key of a JSONB column using one of the
+// operators supported by WhereJSON's `where` struct tag (apart from JSONOpOr), e.g. path []string{"address", "city"}
+// and JSONOpEq result in `users.profile#>>'{"address","city"}' = ?`. The value must be a string, number, bool
+// or time.Time (a bool for JSONOpExists).
+func WhereJSONKey(table string, column string, path []string, op string, value interface{}) (qm.QueryMod, error) {
+	if len(path) == 0 {
+		return nil, errors.New("path must contain at least one key")
+	}
+	if op == JSONOpOr {
+		return nil, fmt.Errorf("operator %q requires a filter struct, use WhereJSON", op)
+	}
+
+	return whereJSONValue(jsonPath{table: table, column: column, keys: path}, op, value)
+}
+
+// jsonPath is the path of a (nested) key within the JSON column.
+type jsonPath struct {
+	table  string
+	column string
+	keys   []string
+}
+
+func (p jsonPath) with(key string) jsonPath {
+	keys := make([]string, 0, len(p.keys)+1)
+	return jsonPath{table: p.table, column: p.column, keys: append(append(keys, p.keys...), key)}
+}
+
+// text returns the expression selecting the value as text.
+func (p jsonPath) text() string {
+	if len(p.keys) == 1 {
+		return fmt.Sprintf("%s.%s->>'%s'", p.table, p.column, escapeJSONKey(p.keys[0]))
+	}
+	return fmt.Sprintf("%s.%s#>>'%s'", p.table, p.column, p.array())
+}
+
+// json returns the expression selecting the value as JSON.
+func (p jsonPath) json() string {
+	if len(p.keys) == 1 {
+		return fmt.Sprintf("%s.%s->'%s'", p.table, p.column, escapeJSONKey(p.keys[0]))
+	}
+	return fmt.Sprintf("%s.%s#>'%s'", p.table, p.column, p.array())
+}
+
+// array returns the keys as Postgres text array literal, e.g. `{address,city}`.
+func (p jsonPath) array() string {
+	keys := make([]string, 0, len(p.keys))
+	for _, k := range p.keys {
+		keys = append(keys, `"`+strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(escapeJSONKey(k))+`"`)
+	}
+	return "{" + strings.Join(keys, ",") + "}"
+}
+
+func escapeJSONKey(k string) string {
+	return strings.ReplaceAll(k, "'", "''")
+}
+
+func whereJSON(path jsonPath, rv reflect.Value, level int) ([]qm.QueryMod, error) {
 	if level >= whereJSONMaxLevel {
-		panic(fmt.Errorf("whereJSON reached maximum recursion (%d/%d)", level, whereJSONMaxLevel))
+		return nil, fmt.Errorf("whereJSON reached maximum recursion (%d/%d)", level, whereJSONMaxLevel)
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("invalid filter type %v", rv.Kind())
 	}
 
 	qms := make([]qm.QueryMod, 0)
 
-	rt := reflect.TypeOf(filter)
-	switch rt.Kind() {
-	case reflect.Struct:
-		rv := reflect.ValueOf(filter)
-		for i := 0; i < rt.NumField(); i++ {
-			f := rt.Field(i)
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
 
-			// skip unexported fields as we cannot retrieve their values
-			if len(f.PkgPath) != 0 {
-				continue
-			}
+		// skip unexported fields as we cannot retrieve their values
+		if len(f.PkgPath) != 0 {
+			continue
+		}
+
+		k := strings.Split(f.Tag.Get("json"), ",")[0]
+		if k == "-" {
+			continue
+		}
 
-			k := strings.Split(f.Tag.Get("json"), ",")[0]
-			if k == "-" {
+		op := f.Tag.Get(whereJSONTag)
+		if len(op) == 0 {
+			op = JSONOpEq
+		}
+
+		fs := rv.Field(i)
+		if fs.Kind() == reflect.Ptr {
+			if fs.IsNil() {
 				continue
 			}
+			fs = fs.Elem()
+		}
 
-			fs := rv.Field(i)
-			if fs.Kind() != reflect.Struct && k == "" {
-				continue
+		fieldPath := path
+		if len(k) > 0 && !f.Anonymous {
+			fieldPath = path.with(k)
+		}
+
+		if op == JSONOpOr {
+			q, err := whereJSONOr(fieldPath, fs, level)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", f.Name, err)
 			}
+			if q != nil {
+				qms = append(qms, q)
+			}
+			continue
+		}
 
-			isArray := false
-			var v interface{}
-			switch fs.Kind() {
-			case reflect.Struct:
-				qms = append(qms, whereJSON(table, column, fs.Interface(), level+1)...)
-				continue
-			case reflect.Ptr:
-				if !fs.IsValid() || fs.IsNil() {
-					continue
+		var v interface{}
+		switch fs.Kind() {
+		case reflect.Struct:
+			var isValue bool
+			var err error
+			if v, isValue, err = jsonStructValue(fs); err != nil {
+				return nil, fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			if !isValue {
+				if op != JSONOpEq {
+					return nil, fmt.Errorf("field %s: operator %q is not supported for structs", f.Name, op)
 				}
-				if fs.Elem().Kind() == reflect.Array ||
-					fs.Elem().Kind() == reflect.Slice {
-					isArray = true
+				nested, err := whereJSON(fieldPath, fs, level+1)
+				if err != nil {
+					return nil, err
 				}
-				v = fs.Elem().Interface()
-			case reflect.Array,
-				reflect.Slice:
-				if !fs.IsValid() || fs.IsNil() {
+				qms = append(qms, nested...)
+				continue
+			}
+			if v == nil {
+				// invalid null type
+				continue
+			}
+		case reflect.Array,
+			reflect.Slice:
+			if k == "" || (fs.Kind() == reflect.Slice && fs.IsNil()) {
+				continue
+			}
+			if op != JSONOpEq {
+				return nil, fmt.Errorf("field %s: operator %q is not supported for arrays", f.Name, op)
+			}
+			qms = append(qms, qm.Where(fmt.Sprintf("%s <@ to_jsonb(?::text[])", fieldPath.json()), pq.Array(fs.Interface())))
+			continue
+		default:
+			v = fs.Interface()
+		}
+
+		if k == "" {
+			continue
+		}
+
+		q, err := whereJSONValue(fieldPath, op, v)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		qms = append(qms, q)
+	}
+
+	return qms, nil
+}
+
+// whereJSONOr combines the conditions of the struct (or each struct of the slice) using OR.
+func whereJSONOr(path jsonPath, rv reflect.Value, level int) (qm.QueryMod, error) {
+	var groups []qm.QueryMod
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		qms, err := whereJSON(path, rv, level+1)
+		if err != nil {
+			return nil, err
+		}
+		groups = qms
+	case reflect.Array,
+		reflect.Slice:
+		for i := 0; i < rv.Len(); i++ {
+			elem := rv.Index(i)
+			if elem.Kind() == reflect.Ptr {
+				if elem.IsNil() {
 					continue
 				}
-				isArray = true
-				v = fs.Interface()
-			default:
-				v = fs.Interface()
+				elem = elem.Elem()
 			}
 
-			if isArray {
-				qms = append(qms, qm.Where(fmt.Sprintf("%s.%s->'%s' <@ to_jsonb(?::text[])", table, column, k), pq.Array(v)))
-			} else {
-				qms = append(qms, qm.Where(fmt.Sprintf("%s.%s->>'%s' = ?", table, column, k), v))
+			qms, err := whereJSON(path, elem, level+1)
+			if err != nil {
+				return nil, err
+			}
+			if len(qms) > 0 {
+				groups = append(groups, qm.Expr(qms...))
 			}
 		}
+	default:
+		return nil, fmt.Errorf("operator %q requires a struct or slice of structs, got %v", JSONOpOr, rv.Kind())
+	}
+
+	if len(groups) == 0 {
+		return nil, nil
+	}
+
+	return CombineWithOr(groups)[0], nil
+}
+
+// jsonStructValue returns the value of structs compared as a whole (time.Time and driver.Valuer
+// implementations such as the `null` package types), isValue is false for structs to traverse.
+func jsonStructValue(rv reflect.Value) (v interface{}, isValue bool, err error) {
+	if rv.Type() == timeType {
+		return rv.Interface(), true, nil
+	}
+
+	valuer, ok := rv.Interface().(driver.Valuer)
+	if !ok {
+		return nil, false, nil
+	}
+
+	v, err = valuer.Value()
+	if err != nil {
+		return nil, true, err
+	}
+
+	return v, true, nil
+}
+
+func whereJSONValue(path jsonPath, op string, v interface{}) (qm.QueryMod, error) {
+	if op == JSONOpExists {
+		exists, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("operator %q requires a bool, got %T", op, v)
+		}
+		if exists {
+			return qm.Where(path.json() + " IS NOT NULL"), nil
+		}
+		return qm.Where(path.json() + " IS NULL"), nil
+	}
+
+	var cast string
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		cast = "numeric"
+	case reflect.Bool:
+		cast = "boolean"
 	case reflect.String:
-		qms = append(qms, qm.Where(fmt.Sprintf("%s.%s::text = ?", table, column), filter))
 	default:
-		panic(fmt.Errorf("invalid filter type %v", rt.Kind()))
+		if _, ok := v.(time.Time); !ok {
+			return nil, fmt.Errorf("invalid filter type %T", v)
+		}
+		cast = "timestamptz"
+	}
+
+	var cmp string
+	switch op {
+	case JSONOpEq:
+		cmp = "="
+	case JSONOpNeq:
+		cmp = "<>"
+	case JSONOpGt:
+		cmp = ">"
+	case JSONOpGte:
+		cmp = ">="
+	case JSONOpLt:
+		cmp = "<"
+	case JSONOpLte:
+		cmp = "<="
+	case JSONOpILike:
+		if _, ok := v.(string); !ok {
+			return nil, fmt.Errorf("operator %q requires a string, got %T", op, v)
+		}
+		cmp = "ILIKE"
+	default:
+		return nil, fmt.Errorf("unknown operator %q", op)
+	}
+
+	if cast == "boolean" && op != JSONOpEq && op != JSONOpNeq {
+		return nil, fmt.Errorf("operator %q is not supported for bool", op)
+	}
+
+	// equality of numbers and booleans is checked textually, other comparisons require casting the text value
+	expr := path.text()
+	if cast == "timestamptz" || (len(cast) > 0 && op != JSONOpEq && op != JSONOpNeq) {
+		expr = fmt.Sprintf("(%s)::%s", expr, cast)
 	}
 
-	return qms
+	return qm.Where(fmt.Sprintf("%s %s ?", expr, cmp), v), nil
 }
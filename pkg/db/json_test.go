@@ -0,0 +1,167 @@
+package db_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	models "github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+func buildJSONQuery(t *testing.T, filter interface{}) (string, []interface{}) {
+	t.Helper()
+
+	q, err := db.TryWhereJSON("users", "profile", filter)
+	require.NoError(t, err)
+
+	return queries.BuildQuery(models.NewQuery(qm.Select("*"), qm.From("users"), q))
+}
+
+func TestWhereJSONOperators(t *testing.T) {
+	registeredAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	type filter struct {
+		MinAge     int       `json:"age" where:"gte"`
+		MaxAge     *int      `json:"-"`
+		Name       string    `json:"name" where:"ilike"`
+		Country    string    `json:"country" where:"neq"`
+		Registered time.Time `json:"registeredAt" where:"lt"`
+		Verified   bool      `json:"verifiedAt" where:"exists"`
+		Deleted    bool      `json:"deletedAt" where:"exists"`
+		Premium    bool      `json:"premium"`
+	}
+
+	sql, args := buildJSONQuery(t, filter{
+		MinAge:     18,
+		Name:       "%max%",
+		Country:    "Austria",
+		Registered: registeredAt,
+		Verified:   true,
+	})
+
+	assert.Equal(t, `SELECT * FROM "users" WHERE ((users.profile->>'age')::numeric >= $1 AND users.profile->>'name' ILIKE $2 AND users.profile->>'country' <> $3 AND (users.profile->>'registeredAt')::timestamptz < $4 AND users.profile->'verifiedAt' IS NOT NULL AND users.profile->'deletedAt' IS NULL AND users.profile->>'premium' = $5);`, sql)
+	assert.Equal(t, []interface{}{18, "%max%", "Austria", registeredAt, false}, args)
+}
+
+func TestWhereJSONNullTypes(t *testing.T) {
+	type filter struct {
+		Nickname null.String `json:"nickname"`
+		Age      null.Int    `json:"age" where:"lt"`
+		Birthday null.Time   `json:"birthday"`
+		Invalid  null.String `json:"invalid"`
+	}
+
+	birthday := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	sql, args := buildJSONQuery(t, filter{
+		Nickname: null.StringFrom("maxi"),
+		Age:      null.IntFrom(30),
+		Birthday: null.TimeFrom(birthday),
+	})
+
+	assert.Equal(t, `SELECT * FROM "users" WHERE (users.profile->>'nickname' = $1 AND (users.profile->>'age')::numeric < $2 AND (users.profile->>'birthday')::timestamptz = $3);`, sql)
+	assert.Equal(t, []interface{}{"maxi", int64(30), birthday}, args)
+}
+
+func TestWhereJSONNestedPaths(t *testing.T) {
+	type geo struct {
+		Lat float64 `json:"lat" where:"gt"`
+	}
+	type address struct {
+		City string   `json:"city"`
+		Geo  geo      `json:"geo"`
+		Tags []string `json:"tags"`
+	}
+	type filter struct {
+		Address address `json:"address"`
+		Weird   string  `json:"it's"`
+	}
+
+	sql, args := buildJSONQuery(t, filter{Address: address{City: "Vienna", Geo: geo{Lat: 48.2}, Tags: []string{"home"}}, Weird: "x"})
+
+	assert.Equal(t, `SELECT * FROM "users" WHERE (users.profile#>>'{"address","city"}' = $1 AND (users.profile#>>'{"address","geo","lat"}')::numeric > $2 AND users.profile#>'{"address","tags"}' <@ to_jsonb($3::text[]) AND users.profile->>'it''s' = $4);`, sql)
+	require.Len(t, args, 4)
+	assert.Equal(t, "Vienna", args[0])
+	assert.Equal(t, 48.2, args[1])
+	assert.Equal(t, "x", args[3])
+}
+
+func TestWhereJSONOrGroups(t *testing.T) {
+	type location struct {
+		Country string  `json:"country"`
+		City    *string `json:"city"`
+	}
+	type filter struct {
+		Either struct {
+			Nickname string `json:"nickname"`
+			Name     string `json:"name" where:"ilike"`
+		} `where:"or"`
+		Locations []location `json:"location" where:"or"`
+	}
+
+	vienna := "Vienna"
+	f := filter{Locations: []location{{Country: "Austria", City: &vienna}, {Country: "Germany"}}}
+	f.Either.Nickname = "maxi"
+	f.Either.Name = "max%"
+
+	sql, args := buildJSONQuery(t, f)
+
+	assert.Equal(t, `SELECT * FROM "users" WHERE ((users.profile->>'nickname' = $1 OR users.profile->>'name' ILIKE $2) AND ((users.profile#>>'{"location","country"}' = $3 AND users.profile#>>'{"location","city"}' = $4) OR (users.profile#>>'{"location","country"}' = $5)));`, sql)
+	assert.Equal(t, []interface{}{"maxi", "max%", "Austria", "Vienna", "Germany"}, args)
+}
+
+func TestTryWhereJSONErrors(t *testing.T) {
+	_, err := db.TryWhereJSON("users", "profile", struct {
+		Ignored string
+	}{})
+	assert.True(t, errors.Is(err, db.ErrEmptyJSONFilter))
+
+	invalid := []interface{}{
+		nil,
+		42,
+		struct {
+			Active bool `json:"active" where:"gt"`
+		}{},
+		struct {
+			Age int `json:"age" where:"ilike"`
+		}{},
+		struct {
+			Age int `json:"age" where:"exists"`
+		}{},
+		struct {
+			Age int `json:"age" where:"between"`
+		}{},
+		struct {
+			Scopes []string `json:"scopes" where:"lt"`
+		}{},
+		struct {
+			Age int `json:"age" where:"or"`
+		}{},
+	}
+
+	for _, filter := range invalid {
+		_, err := db.TryWhereJSON("users", "profile", filter)
+		assert.Error(t, err, "%#v", filter)
+		assert.Panics(t, func() { db.WhereJSON("users", "profile", filter) })
+	}
+}
+
+func TestWhereJSONKey(t *testing.T) {
+	q, err := db.WhereJSONKey("users", "profile", []string{"address", "city"}, db.JSONOpILike, "%vienna%")
+	require.NoError(t, err)
+
+	sql, args := queries.BuildQuery(models.NewQuery(qm.Select("*"), qm.From("users"), q))
+	assert.Equal(t, `SELECT * FROM "users" WHERE (users.profile#>>'{"address","city"}' ILIKE $1);`, sql)
+	assert.Equal(t, []interface{}{"%vienna%"}, args)
+
+	_, err = db.WhereJSONKey("users", "profile", nil, db.JSONOpEq, "x")
+	assert.Error(t, err)
+	_, err = db.WhereJSONKey("users", "profile", []string{"age"}, db.JSONOpOr, 1)
+	assert.Error(t, err)
+	_, err = db.WhereJSONKey("users", "profile", []string{"tags"}, db.JSONOpEq, []string{"a"})
+	assert.Error(t, err)
+}
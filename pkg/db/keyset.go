@@ -0,0 +1,265 @@
+package db
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// SortColumn is a column a keyset paginated query is ordered by.
+type SortColumn struct {
+	// Column is the (trusted) column name used within the query, e.g. models.UserTableColumns.CreatedAt.
+	Column string
+	Desc   bool
+}
+
+// Keyset paginates queries by the values of the sort columns of the last (or first) row of the previous page,
+// which stays stable while rows are inserted and doesn't need to skip rows like OFFSET. The sort columns must not
+// contain NULL values and the last column must be unique, e.g. the primary key.
+type Keyset struct {
+	Columns []SortColumn
+	Limit   int
+}
+
+// CursorPage is the paginated JSON envelope of keyset paginated lists.
+type CursorPage[T any] struct {
+	Items []T `json:"items"`
+	// HasMore reports whether more items follow in the direction the page was fetched.
+	HasMore bool `json:"hasMore"`
+	// NextCursor fetches the items following this page, null if there are none.
+	NextCursor null.String `json:"nextCursor"`
+	// PrevCursor fetches the items preceding this page, null on the first page.
+	PrevCursor null.String `json:"prevCursor"`
+}
+
+// MapCursorPage converts the items of the page, e.g. from models to response types.
+func MapCursorPage[T any, R any](page CursorPage[T], fn func(T) R) CursorPage[R] {
+	items := make([]R, 0, len(page.Items))
+	for _, item := range page.Items {
+		items = append(items, fn(item))
+	}
+
+	return CursorPage[R]{
+		Items:      items,
+		HasMore:    page.HasMore,
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+	}
+}
+
+// cursor is encoded as base64 JSON, it's opaque to clients.
+type cursor struct {
+	// Columns identifies the sort columns the cursor was created for.
+	Columns string `json:"c"`
+	// Values of the sort columns of the row the page starts after (or ends before).
+	Values   []interface{} `json:"v"`
+	Backward bool          `json:"b,omitempty"`
+}
+
+func (k Keyset) columns() string {
+	columns := make([]string, 0, len(k.Columns))
+	for _, c := range k.Columns {
+		if c.Desc {
+			columns = append(columns, "-"+c.Column)
+		} else {
+			columns = append(columns, c.Column)
+		}
+	}
+
+	return strings.Join(columns, ",")
+}
+
+func (k Keyset) encodeCursor(values []interface{}, backward bool) (string, error) {
+	b, err := json.Marshal(cursor{Columns: k.columns(), Values: values, Backward: backward})
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (k Keyset) decodeCursor(s string) (*cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	// numbers are kept as strings to not lose precision, Postgres infers their types from the compared columns
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var c cursor
+	if err := dec.Decode(&c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.Columns != k.columns() || len(c.Values) != len(k.Columns) {
+		return nil, ErrInvalidCursor
+	}
+
+	// only scalars are passed as query args, the sort columns must not contain NULL values
+	for _, v := range c.Values {
+		switch v.(type) {
+		case string, json.Number, bool:
+		default:
+			return nil, ErrInvalidCursor
+		}
+	}
+
+	return &c, nil
+}
+
+// QueryMods returns the query mods ordering and limiting the query to the page after (or before) the cursor,
+// the first page if the cursor is empty. One row more than the limit is fetched to detect further rows.
+// Returns ErrInvalidCursor if the cursor is malformed or was created for other sort columns.
+func (k Keyset) QueryMods(cursorParam string) ([]qm.QueryMod, error) {
+	if len(k.Columns) == 0 {
+		return nil, errors.New("keyset requires at least one sort column")
+	}
+
+	var c *cursor
+	if len(cursorParam) > 0 {
+		var err error
+		if c, err = k.decodeCursor(cursorParam); err != nil {
+			return nil, err
+		}
+	}
+
+	backward := c != nil && c.Backward
+	mods := make([]qm.QueryMod, 0, 3)
+
+	if c != nil {
+		mods = append(mods, k.where(c.Values, backward))
+	}
+
+	order := make([]string, 0, len(k.Columns))
+	for _, col := range k.Columns {
+		// pages before the cursor are fetched in reverse and flipped afterwards
+		if col.Desc != backward {
+			order = append(order, col.Column+" DESC")
+		} else {
+			order = append(order, col.Column+" ASC")
+		}
+	}
+
+	mods = append(mods, qm.OrderBy(strings.Join(order, ", ")), qm.Limit(k.Limit+1))
+
+	return mods, nil
+}
+
+// where compares the sort columns against the cursor values. If all columns are sorted in the same direction,
+// a row comparison is used (e.g. `(created_at, id) > (?, ?)`), otherwise the equivalent expansion
+// (e.g. `created_at < ? OR (created_at = ? AND id > ?)`).
+func (k Keyset) where(values []interface{}, backward bool) qm.QueryMod {
+	after := func(col SortColumn) bool {
+		return col.Desc == backward
+	}
+
+	uniform := true
+	for _, col := range k.Columns[1:] {
+		if col.Desc != k.Columns[0].Desc {
+			uniform = false
+			break
+		}
+	}
+
+	if uniform {
+		columns := make([]string, 0, len(k.Columns))
+		for _, col := range k.Columns {
+			columns = append(columns, col.Column)
+		}
+
+		op := "<"
+		if after(k.Columns[0]) {
+			op = ">"
+		}
+
+		return qm.Where(fmt.Sprintf("(%s) %s (%s)", strings.Join(columns, ", "), op, strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ")), values...)
+	}
+
+	var (
+		clauses []string
+		args    []interface{}
+	)
+	for i, col := range k.Columns {
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, k.Columns[j].Column+" = ?")
+			args = append(args, values[j])
+		}
+
+		op := "<"
+		if after(col) {
+			op = ">"
+		}
+		parts = append(parts, fmt.Sprintf("%s %s ?", col.Column, op))
+		args = append(args, values[i])
+
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	return qm.Where("("+strings.Join(clauses, " OR ")+")", args...)
+}
+
+// Paginate builds the page from the rows fetched using QueryMods with the same cursor, values returns the values
+// of the sort columns of a row (in order).
+func Paginate[T any](k Keyset, cursorParam string, rows []T, values func(T) []interface{}) (CursorPage[T], error) {
+	var c *cursor
+	if len(cursorParam) > 0 {
+		var err error
+		if c, err = k.decodeCursor(cursorParam); err != nil {
+			return CursorPage[T]{}, err
+		}
+	}
+	backward := c != nil && c.Backward
+
+	hasMore := len(rows) > k.Limit
+	if hasMore {
+		rows = rows[:k.Limit]
+	}
+
+	if backward {
+		reversed := make([]T, len(rows))
+		for i, row := range rows {
+			reversed[len(rows)-1-i] = row
+		}
+		rows = reversed
+	}
+
+	if rows == nil {
+		rows = make([]T, 0)
+	}
+
+	page := CursorPage[T]{Items: rows, HasMore: hasMore}
+	if len(rows) == 0 {
+		return page, nil
+	}
+
+	hasNext := (!backward && hasMore) || backward
+	hasPrev := (backward && hasMore) || (!backward && c != nil)
+
+	if hasNext {
+		cur, err := k.encodeCursor(values(rows[len(rows)-1]), false)
+		if err != nil {
+			return CursorPage[T]{}, err
+		}
+		page.NextCursor = null.StringFrom(cur)
+	}
+
+	if hasPrev {
+		cur, err := k.encodeCursor(values(rows[0]), true)
+		if err != nil {
+			return CursorPage[T]{}, err
+		}
+		page.PrevCursor = null.StringFrom(cur)
+	}
+
+	return page, nil
+}
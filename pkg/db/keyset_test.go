@@ -0,0 +1,145 @@
+package db_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	models "github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+type keysetRow struct {
+	CreatedAt time.Time
+	ID        int
+}
+
+func keysetRowValues(r keysetRow) []interface{} {
+	return []interface{}{r.CreatedAt, r.ID}
+}
+
+func buildKeysetQuery(t *testing.T, k db.Keyset, cursor string) (string, []interface{}) {
+	t.Helper()
+
+	mods, err := k.QueryMods(cursor)
+	require.NoError(t, err)
+
+	return queries.BuildQuery(models.NewQuery(append([]qm.QueryMod{qm.Select("*"), qm.From("users")}, mods...)...))
+}
+
+func TestKeysetFirstPage(t *testing.T) {
+	k := db.Keyset{Columns: []db.SortColumn{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}}, Limit: 2}
+
+	sql, args := buildKeysetQuery(t, k, "")
+	assert.Equal(t, `SELECT * FROM "users" ORDER BY created_at DESC, id DESC LIMIT 3;`, sql)
+	assert.Empty(t, args)
+}
+
+func TestKeysetPages(t *testing.T) {
+	k := db.Keyset{Columns: []db.SortColumn{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}}, Limit: 2}
+	at := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	rows := []keysetRow{{at, 5}, {at, 4}, {at.Add(-time.Hour), 3}}
+
+	page, err := db.Paginate(k, "", rows, keysetRowValues)
+	require.NoError(t, err)
+	assert.Equal(t, rows[:2], page.Items)
+	assert.True(t, page.HasMore)
+	assert.False(t, page.PrevCursor.Valid)
+	require.True(t, page.NextCursor.Valid)
+
+	sql, args := buildKeysetQuery(t, k, page.NextCursor.String)
+	assert.Equal(t, `SELECT * FROM "users" WHERE ((created_at, id) < ($1, $2)) ORDER BY created_at DESC, id DESC LIMIT 3;`, sql)
+	require.Len(t, args, 2)
+	assert.Equal(t, at.Format(time.RFC3339Nano), args[0])
+	assert.Equal(t, json.Number("4"), args[1])
+
+	// the last page
+	page, err = db.Paginate(k, page.NextCursor.String, rows[2:], keysetRowValues)
+	require.NoError(t, err)
+	assert.Equal(t, rows[2:], page.Items)
+	assert.False(t, page.HasMore)
+	assert.False(t, page.NextCursor.Valid)
+	require.True(t, page.PrevCursor.Valid)
+
+	// going back fetches in reverse order
+	sql, _ = buildKeysetQuery(t, k, page.PrevCursor.String)
+	assert.Equal(t, `SELECT * FROM "users" WHERE ((created_at, id) > ($1, $2)) ORDER BY created_at ASC, id ASC LIMIT 3;`, sql)
+
+	page, err = db.Paginate(k, page.PrevCursor.String, []keysetRow{rows[1], rows[0]}, keysetRowValues)
+	require.NoError(t, err)
+	assert.Equal(t, rows[:2], page.Items)
+	assert.False(t, page.HasMore)
+	assert.True(t, page.NextCursor.Valid)
+	assert.False(t, page.PrevCursor.Valid)
+}
+
+func TestKeysetMixedOrder(t *testing.T) {
+	k := db.Keyset{Columns: []db.SortColumn{{Column: "username"}, {Column: "created_at", Desc: true}, {Column: "id"}}, Limit: 10}
+
+	page, err := db.Paginate(k, "", []keysetRow{{}}, func(keysetRow) []interface{} {
+		return []interface{}{"max@example.com", "2026-10-19T12:00:00Z", "f6ede5d8-e22a-4ca5-aa12-67821865a3e5"}
+	})
+	require.NoError(t, err)
+	assert.False(t, page.NextCursor.Valid)
+
+	cursor, err := db.Paginate(k, "", make([]keysetRow, 11), func(keysetRow) []interface{} {
+		return []interface{}{"max@example.com", "2026-10-19T12:00:00Z", "f6ede5d8-e22a-4ca5-aa12-67821865a3e5"}
+	})
+	require.NoError(t, err)
+	require.True(t, cursor.NextCursor.Valid)
+
+	sql, args := buildKeysetQuery(t, k, cursor.NextCursor.String)
+	assert.Equal(t, `SELECT * FROM "users" WHERE (((username > $1) OR (username = $2 AND created_at < $3) OR (username = $4 AND created_at = $5 AND id > $6))) ORDER BY username ASC, created_at DESC, id ASC LIMIT 11;`, sql)
+	assert.Equal(t, []interface{}{
+		"max@example.com",
+		"max@example.com", "2026-10-19T12:00:00Z",
+		"max@example.com", "2026-10-19T12:00:00Z", "f6ede5d8-e22a-4ca5-aa12-67821865a3e5",
+	}, args)
+}
+
+func TestKeysetInvalidCursor(t *testing.T) {
+	k := db.Keyset{Columns: []db.SortColumn{{Column: "created_at", Desc: true}, {Column: "id"}}, Limit: 1}
+	other := db.Keyset{Columns: []db.SortColumn{{Column: "username"}, {Column: "id"}}, Limit: 1}
+
+	page, err := db.Paginate(other, "", make([]keysetRow, 2), func(keysetRow) []interface{} {
+		return []interface{}{"max@example.com", 1}
+	})
+	require.NoError(t, err)
+
+	invalid := []string{"not base64!", "bm90IGpzb24", page.NextCursor.String}
+	for _, values := range []string{
+		`["2026-10-19T12:00:00Z"]`,
+		`["2026-10-19T12:00:00Z", 1, 2]`,
+		`["2026-10-19T12:00:00Z", null]`,
+		`["2026-10-19T12:00:00Z", {"id": 1}]`,
+		`[["2026-10-19T12:00:00Z"], 1]`,
+	} {
+		invalid = append(invalid, base64.RawURLEncoding.EncodeToString([]byte(`{"c":"-created_at,id","v":`+values+`}`)))
+	}
+
+	for _, cursor := range invalid {
+		_, err := k.QueryMods(cursor)
+		assert.ErrorIs(t, err, db.ErrInvalidCursor)
+
+		_, err = db.Paginate(k, cursor, []keysetRow{}, keysetRowValues)
+		assert.ErrorIs(t, err, db.ErrInvalidCursor)
+	}
+
+	valid := base64.RawURLEncoding.EncodeToString([]byte(`{"c":"-created_at,id","v":["2026-10-19T12:00:00Z",1]}`))
+	_, err = k.QueryMods(valid)
+	assert.NoError(t, err)
+}
+
+func TestMapCursorPage(t *testing.T) {
+	page := db.MapCursorPage(db.CursorPage[int]{Items: []int{1, 2}, HasMore: true}, func(i int) string {
+		return string(rune('a' + i))
+	})
+
+	assert.Equal(t, []string{"b", "c"}, page.Items)
+	assert.True(t, page.HasMore)
+}
@@ -0,0 +1,33 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// PQErrUniqueViolation is the SQLSTATE raised on unique constraint violations.
+	PQErrUniqueViolation pq.ErrorCode = "23505"
+)
+
+// IsPQError checks whether err wraps a *pq.Error with one of the SQLSTATE codes provided.
+func IsPQError(err error, codes ...pq.ErrorCode) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+
+	for _, code := range codes {
+		if pqErr.Code == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsUniqueViolation checks whether err was caused by a unique constraint violation.
+func IsUniqueViolation(err error) bool {
+	return IsPQError(err, PQErrUniqueViolation)
+}
@@ -0,0 +1,21 @@
+package db_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPQError(t *testing.T) {
+	err := fmt.Errorf("failed to insert: %w", &pq.Error{Code: db.PQErrUniqueViolation})
+
+	assert.True(t, db.IsUniqueViolation(err))
+	assert.True(t, db.IsPQError(err, "40001", db.PQErrUniqueViolation))
+	assert.False(t, db.IsPQError(err, "40001"))
+	assert.False(t, db.IsUniqueViolation(errors.New("some error")))
+	assert.False(t, db.IsUniqueViolation(nil))
+}
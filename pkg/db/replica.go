@@ -0,0 +1,384 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/lib/pq"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+var ErrReadOnlyExecutor = errors.New("read-only executor cannot execute statements")
+
+// Plain reads start with SELECT (or WITH), writes and locking reads (e.g. FOR UPDATE) have to be sent to the primary.
+var (
+	readQueryRegex    = regexp.MustCompile(`(?is)^\s*(SELECT|WITH)\s`)
+	writeKeywordRegex = regexp.MustCompile(`(?is)\b(INSERT|UPDATE|DELETE|MERGE|FOR\s+(NO\s+KEY\s+)?UPDATE|FOR\s+(KEY\s+)?SHARE)\b`)
+)
+
+// IsReadQuery reports whether the query is a plain (non-locking) read, which may be sent to a replica.
+func IsReadQuery(query string) bool {
+	return readQueryRegex.MatchString(query) && !writeKeywordRegex.MatchString(query)
+}
+
+type sessionContextKey struct{}
+type replicaReadsContextKey struct{}
+
+// session tracks the last write of a request.
+type session struct {
+	lastWrite atomic.Int64
+}
+
+// WithSession returns a context tracking writes (see MarkWrite), e.g. per request, so reads of the same session are
+// sent to the primary for a while after writing (see RouterOptions.PrimaryReadWindow).
+func WithSession(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sessionContextKey{}, &session{})
+}
+
+// MarkWrite records a write within the session of the context, a noop if the context carries no session.
+// Writes via the Router and committed transactions (see WithTransactionContext) are recorded automatically.
+func MarkWrite(ctx context.Context) {
+	if s, ok := ctx.Value(sessionContextKey{}).(*session); ok {
+		s.lastWrite.Store(time.Now().UnixNano())
+	}
+}
+
+// wroteWithin reports whether the session of the context recorded a write within the window.
+func wroteWithin(ctx context.Context, window time.Duration) bool {
+	s, ok := ctx.Value(sessionContextKey{}).(*session)
+	if !ok {
+		return false
+	}
+
+	lastWrite := s.lastWrite.Load()
+	return lastWrite > 0 && time.Since(time.Unix(0, lastWrite)) < window
+}
+
+// WithReplicaReads marks the context, allowing the Router to send its read queries to replicas.
+func WithReplicaReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replicaReadsContextKey{}, true)
+}
+
+func replicaReads(ctx context.Context) bool {
+	v, _ := ctx.Value(replicaReadsContextKey{}).(bool)
+	return v
+}
+
+// RouterOptions configure the health checks of the replicas and reading your own writes.
+type RouterOptions struct {
+	// HealthCheckInterval between pings of all replicas, health checks are disabled if not positive.
+	HealthCheckInterval time.Duration
+	// HealthCheckTimeout limits a single ping.
+	HealthCheckTimeout time.Duration
+	// PrimaryReadWindow sends the reads of a session to the primary for the duration after it wrote,
+	// covering the replication lag. Only writes via the Router and committed transactions are recorded: writes
+	// using the primary *sql.DB directly, e.g. m.Insert(ctx, s.DB, ...), have to be recorded via MarkWrite.
+	PrimaryReadWindow time.Duration
+}
+
+type replica struct {
+	db      *sql.DB
+	index   int
+	healthy atomic.Bool
+}
+
+// Router sends read queries to read replicas (round-robin, skipping unhealthy ones) and everything else to the
+// primary. Reads are only sent to replicas via the ReadOnly executor or using contexts marked by WithReplicaReads,
+// unless they are executed within a transaction or the session wrote recently. Replicas failing to connect are
+// marked unhealthy until their next successful health check and the query is retried on the primary.
+//
+// Router implements boil.ContextExecutor, so it can be passed to models instead of the primary *sql.DB.
+type Router struct {
+	primary  *sql.DB
+	replicas []*replica
+	options  RouterOptions
+	next     atomic.Uint64
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewRouter returns a router for the primary and its replicas, which are considered healthy until checked.
+func NewRouter(primary *sql.DB, replicas []*sql.DB, options RouterOptions) *Router {
+	r := &Router{primary: primary, options: options}
+
+	for i, db := range replicas {
+		rep := &replica{db: db, index: i}
+		rep.healthy.Store(true)
+		r.replicas = append(r.replicas, rep)
+	}
+
+	return r
+}
+
+// Primary returns the primary database.
+func (r *Router) Primary() *sql.DB {
+	return r.primary
+}
+
+// Replicas returns the number of configured and currently healthy replicas.
+func (r *Router) Replicas() (total int, healthy int) {
+	for _, rep := range r.replicas {
+		if rep.healthy.Load() {
+			healthy++
+		}
+	}
+
+	return len(r.replicas), healthy
+}
+
+// Reader returns the database reads of the context are sent to: the primary within transactions, within the
+// PrimaryReadWindow after a write of the session or if no replica is healthy, the next healthy replica otherwise.
+func (r *Router) Reader(ctx context.Context) *sql.DB {
+	if rep := r.reader(ctx); rep != nil {
+		return rep.db
+	}
+
+	return r.primary
+}
+
+func (r *Router) reader(ctx context.Context) *replica {
+	if len(r.replicas) == 0 || InTransaction(ctx) || wroteWithin(ctx, r.options.PrimaryReadWindow) {
+		return nil
+	}
+
+	n := len(r.replicas)
+	start := int(r.next.Add(1) % uint64(n))
+	for i := 0; i < n; i++ {
+		rep := r.replicas[(start+i)%n]
+		if rep.healthy.Load() {
+			return rep
+		}
+	}
+
+	return nil
+}
+
+// ReadOnly returns an executor sending its read queries to replicas (see Reader) and refusing to execute statements.
+// Queries other than plain reads are sent to the primary.
+func (r *Router) ReadOnly() boil.ContextExecutor {
+	return readOnlyExecutor{router: r}
+}
+
+// Exec executes the statement on the primary.
+func (r *Router) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return r.primary.Exec(query, args...)
+}
+
+// Query executes the query on the primary, as no context is available to route it.
+func (r *Router) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return r.primary.Query(query, args...)
+}
+
+// QueryRow executes the query on the primary, as no context is available to route it.
+func (r *Router) QueryRow(query string, args ...interface{}) *sql.Row {
+	return r.primary.QueryRow(query, args...)
+}
+
+// ExecContext executes the statement on the primary, recording a write within the session of the context.
+func (r *Router) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	MarkWrite(ctx)
+	return r.primary.ExecContext(ctx, query, args...)
+}
+
+// QueryContext sends read queries of contexts marked via WithReplicaReads to a replica, falling back to the primary
+// if it fails to connect. Other queries are sent to the primary, recording a write unless it's a read.
+func (r *Router) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if rep := r.replicaFor(ctx, query); rep != nil {
+		rows, err := rep.db.QueryContext(ctx, query, args...)
+		if err == nil || !r.failed(ctx, rep, err) {
+			return rows, err
+		}
+	}
+
+	return r.primary.QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext routes the query like QueryContext.
+func (r *Router) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if rep := r.replicaFor(ctx, query); rep != nil {
+		row := rep.db.QueryRowContext(ctx, query, args...)
+		if err := row.Err(); err == nil || !r.failed(ctx, rep, err) {
+			return row
+		}
+	}
+
+	return r.primary.QueryRowContext(ctx, query, args...)
+}
+
+// replicaFor returns the replica the query is sent to, nil for the primary.
+func (r *Router) replicaFor(ctx context.Context, query string) *replica {
+	if !IsReadQuery(query) {
+		MarkWrite(ctx)
+		return nil
+	}
+
+	if !replicaReads(ctx) {
+		return nil
+	}
+
+	return r.reader(ctx)
+}
+
+// failed marks the replica unhealthy if it failed to connect, reporting whether to fall back to the primary.
+func (r *Router) failed(ctx context.Context, rep *replica, err error) bool {
+	if !IsConnectionError(err) {
+		return false
+	}
+
+	if rep.healthy.CompareAndSwap(true, false) {
+		logs.LogFromContext(ctx).Warn().Err(err).Int("replica", rep.index).Msg("Read replica failed, falling back to primary")
+	}
+
+	return true
+}
+
+// IsConnectionError reports whether the error was caused by the connection to the database rather than the query:
+// bad connections, network errors, connection exceptions (class 08) and the server shutting down or not accepting
+// connections yet (57P01, 57P02, 57P03). Other errors, including errors caused by the context and unknown errors,
+// are not connection errors.
+func IsConnectionError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "57P01", "57P02", "57P03": // admin_shutdown, crash_shutdown, cannot_connect_now
+			return true
+		}
+		return pqErr.Code.Class() == "08"
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// CheckHealth pings all replicas, marking them (un)healthy.
+func (r *Router) CheckHealth(ctx context.Context) {
+	for _, rep := range r.replicas {
+		pingCtx := ctx
+		cancel := func() {}
+		if r.options.HealthCheckTimeout > 0 {
+			pingCtx, cancel = context.WithTimeout(ctx, r.options.HealthCheckTimeout)
+		}
+
+		err := rep.db.PingContext(pingCtx)
+		cancel()
+
+		if err != nil {
+			if rep.healthy.CompareAndSwap(true, false) {
+				logs.LogFromContext(ctx).Warn().Err(err).Int("replica", rep.index).Msg("Read replica is unhealthy")
+			}
+			continue
+		}
+
+		if rep.healthy.CompareAndSwap(false, true) {
+			logs.LogFromContext(ctx).Info().Int("replica", rep.index).Msg("Read replica is healthy again")
+		}
+	}
+}
+
+// Start checks the health of the replicas periodically in the background until Stop is called.
+func (r *Router) Start() {
+	if len(r.replicas) == 0 || r.options.HealthCheckInterval <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(r.options.HealthCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.CheckHealth(ctx)
+			}
+		}
+	}()
+}
+
+// Stop cancels the health checks and waits for the background goroutine to exit.
+func (r *Router) Stop() {
+	r.mu.Lock()
+	cancel := r.cancel
+	r.cancel = nil
+	r.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	r.wg.Wait()
+}
+
+// Close closes the replicas, the primary is left open.
+func (r *Router) Close() error {
+	errs := make([]error, 0, len(r.replicas))
+	for _, rep := range r.replicas {
+		if err := rep.db.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// readOnlyExecutor sends its read queries to replicas, see Router.ReadOnly.
+type readOnlyExecutor struct {
+	router *Router
+}
+
+func (e readOnlyExecutor) Exec(string, ...interface{}) (sql.Result, error) {
+	return nil, ErrReadOnlyExecutor
+}
+
+func (e readOnlyExecutor) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return e.QueryContext(context.Background(), query, args...)
+}
+
+func (e readOnlyExecutor) QueryRow(query string, args ...interface{}) *sql.Row {
+	return e.QueryRowContext(context.Background(), query, args...)
+}
+
+func (e readOnlyExecutor) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return nil, ErrReadOnlyExecutor
+}
+
+func (e readOnlyExecutor) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return e.router.QueryContext(WithReplicaReads(ctx), query, args...)
+}
+
+func (e readOnlyExecutor) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return e.router.QueryRowContext(WithReplicaReads(ctx), query, args...)
+}
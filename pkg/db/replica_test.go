@@ -0,0 +1,304 @@
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// nodeDriver is a database/sql driver answering every query with its name, failing queries and pings with err.
+type nodeDriver struct {
+	name string
+
+	mu      sync.Mutex
+	err     error
+	queries int
+	execs   int
+}
+
+func (d *nodeDriver) Connect(context.Context) (driver.Conn, error) { return &nodeConn{d: d}, nil }
+func (d *nodeDriver) Driver() driver.Driver                        { return nil }
+
+func (d *nodeDriver) fail(err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.err = err
+}
+
+func (d *nodeDriver) counts() (queries int, execs int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.queries, d.execs
+}
+
+type nodeConn struct {
+	d *nodeDriver
+}
+
+func (c *nodeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *nodeConn) Close() error                        { return nil }
+func (c *nodeConn) Begin() (driver.Tx, error)           { return c, nil }
+func (c *nodeConn) Commit() error                       { return nil }
+func (c *nodeConn) Rollback() error                     { return nil }
+
+func (c *nodeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return c, nil
+}
+
+func (c *nodeConn) Ping(context.Context) error {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	return c.d.err
+}
+
+func (c *nodeConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.execs++
+	return driver.RowsAffected(1), nil
+}
+
+func (c *nodeConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	if c.d.err != nil {
+		return nil, c.d.err
+	}
+	c.d.queries++
+	return &nodeRows{name: c.d.name}, nil
+}
+
+type nodeRows struct {
+	name string
+	done bool
+}
+
+func (r *nodeRows) Columns() []string { return []string{"name"} }
+func (r *nodeRows) Close() error      { return nil }
+
+func (r *nodeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.name
+	return nil
+}
+
+func newTestRouter(t *testing.T, options db.RouterOptions, replicas ...string) (*db.Router, *nodeDriver, []*nodeDriver) {
+	t.Helper()
+
+	primary := &nodeDriver{name: "primary"}
+	primaryDB := sql.OpenDB(primary)
+	t.Cleanup(func() { primaryDB.Close() })
+
+	drivers := make([]*nodeDriver, 0, len(replicas))
+	dbs := make([]*sql.DB, 0, len(replicas))
+	for _, name := range replicas {
+		d := &nodeDriver{name: name}
+		drivers = append(drivers, d)
+		dbs = append(dbs, sql.OpenDB(d))
+	}
+
+	r := db.NewRouter(primaryDB, dbs, options)
+	t.Cleanup(func() { require.NoError(t, r.Close()) })
+
+	return r, primary, drivers
+}
+
+func queryNode(t *testing.T, ctx context.Context, exec boil.ContextExecutor, query string) string {
+	t.Helper()
+
+	var name string
+	require.NoError(t, exec.QueryRowContext(ctx, query).Scan(&name))
+
+	return name
+}
+
+const selectQuery = "SELECT * FROM users WHERE id = $1"
+
+func TestRouterRoundRobin(t *testing.T) {
+	r, _, _ := newTestRouter(t, db.RouterOptions{}, "replica-1", "replica-2")
+	ctx := context.Background()
+
+	// unmarked contexts are sent to the primary
+	assert.Equal(t, "primary", queryNode(t, ctx, r, selectQuery))
+
+	ro := r.ReadOnly()
+	first := queryNode(t, ctx, ro, selectQuery)
+	second := queryNode(t, ctx, ro, selectQuery)
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, first, queryNode(t, ctx, ro, selectQuery))
+
+	rows, err := r.QueryContext(db.WithReplicaReads(ctx), selectQuery)
+	require.NoError(t, err)
+	require.True(t, rows.Next())
+	var name string
+	require.NoError(t, rows.Scan(&name))
+	require.NoError(t, rows.Close())
+	assert.Equal(t, second, name)
+
+	// writes and locking reads are always sent to the primary
+	assert.Equal(t, "primary", queryNode(t, ctx, ro, "INSERT INTO users (id) VALUES ($1) RETURNING id"))
+	assert.Equal(t, "primary", queryNode(t, ctx, ro, "SELECT * FROM users WHERE id = $1 FOR UPDATE"))
+
+	_, err = ro.ExecContext(ctx, "DELETE FROM users")
+	assert.ErrorIs(t, err, db.ErrReadOnlyExecutor)
+}
+
+func TestRouterWithoutReplicas(t *testing.T) {
+	r, primary, _ := newTestRouter(t, db.RouterOptions{PrimaryReadWindow: time.Minute})
+	ctx := db.WithSession(context.Background())
+
+	assert.Equal(t, "primary", queryNode(t, ctx, r.ReadOnly(), selectQuery))
+	assert.Equal(t, r.Primary(), r.Reader(ctx))
+
+	_, err := r.ExecContext(ctx, "UPDATE users SET is_active = false")
+	require.NoError(t, err)
+
+	_, execs := primary.counts()
+	assert.Equal(t, 1, execs)
+}
+
+func TestIsReadQuery(t *testing.T) {
+	for _, query := range []string{
+		"SELECT * FROM users",
+		"  select count(*) from users",
+		"WITH recent AS (SELECT * FROM users) SELECT * FROM recent",
+	} {
+		assert.True(t, db.IsReadQuery(query), query)
+	}
+
+	for _, query := range []string{
+		"INSERT INTO users (id) VALUES ($1)",
+		"UPDATE users SET is_active = false",
+		"SELECT * FROM users FOR UPDATE SKIP LOCKED",
+		"SELECT * FROM users FOR NO KEY UPDATE",
+		"SELECT * FROM users FOR SHARE",
+		"WITH deleted AS (DELETE FROM users RETURNING *) SELECT * FROM deleted",
+		"SELECT pg_try_advisory_lock($1)\nFOR KEY SHARE",
+	} {
+		assert.False(t, db.IsReadQuery(query), query)
+	}
+}
+
+func TestRouterReadYourWrites(t *testing.T) {
+	r, _, _ := newTestRouter(t, db.RouterOptions{PrimaryReadWindow: 50 * time.Millisecond}, "replica")
+	ro := r.ReadOnly()
+
+	ctx := db.WithSession(context.Background())
+	other := db.WithSession(context.Background())
+	assert.Equal(t, "replica", queryNode(t, ctx, ro, selectQuery))
+
+	_, err := r.ExecContext(ctx, "UPDATE users SET is_active = false")
+	require.NoError(t, err)
+
+	assert.Equal(t, "primary", queryNode(t, ctx, ro, selectQuery))
+	assert.Equal(t, "replica", queryNode(t, other, ro, selectQuery))
+
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, "replica", queryNode(t, ctx, ro, selectQuery))
+
+	// committed transactions are recorded as writes, their reads are sent to the primary
+	err = db.WithTransactionContext(ctx, r.Primary(), func(ctx context.Context, _ boil.ContextExecutor) error {
+		assert.Equal(t, "primary", queryNode(t, ctx, ro, selectQuery))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "primary", queryNode(t, ctx, ro, selectQuery))
+
+	// read-only transactions are not
+	time.Sleep(60 * time.Millisecond)
+	err = db.WithConfiguredTransactionContext(ctx, r.Primary(), &sql.TxOptions{ReadOnly: true}, func(context.Context, boil.ContextExecutor) error {
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "replica", queryNode(t, ctx, ro, selectQuery))
+}
+
+func TestRouterFallback(t *testing.T) {
+	r, primary, replicas := newTestRouter(t, db.RouterOptions{HealthCheckTimeout: time.Second}, "replica-1", "replica-2")
+	ro := r.ReadOnly()
+	ctx := context.Background()
+
+	replicas[0].fail(driver.ErrBadConn)
+	replicas[1].fail(&pq.Error{Code: "57P03"}) // cannot_connect_now
+
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, "primary", queryNode(t, ctx, ro, selectQuery))
+	}
+
+	total, healthy := r.Replicas()
+	assert.Equal(t, 2, total)
+	assert.Equal(t, 0, healthy)
+
+	// query errors are returned as is
+	replicas[0].fail(nil)
+	replicas[1].fail(nil)
+	r.CheckHealth(ctx)
+	_, healthy = r.Replicas()
+	assert.Equal(t, 2, healthy)
+
+	syntaxErr := &pq.Error{Code: "42601"}
+	replicas[0].fail(syntaxErr)
+	replicas[1].fail(syntaxErr)
+
+	queries, _ := primary.counts()
+	_, err := ro.QueryContext(ctx, selectQuery)
+	assert.ErrorIs(t, err, syntaxErr)
+
+	after, _ := primary.counts()
+	assert.Equal(t, queries, after)
+	_, healthy = r.Replicas()
+	assert.Equal(t, 2, healthy)
+}
+
+func TestRouterHealthChecks(t *testing.T) {
+	r, _, replicas := newTestRouter(t, db.RouterOptions{HealthCheckInterval: 5 * time.Millisecond, HealthCheckTimeout: time.Second}, "replica")
+	ctx := context.Background()
+
+	r.Start()
+	defer r.Stop()
+
+	replicas[0].fail(errors.New("connection refused"))
+	require.Eventually(t, func() bool {
+		_, healthy := r.Replicas()
+		return healthy == 0
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, r.Primary(), r.Reader(ctx))
+
+	replicas[0].fail(nil)
+	require.Eventually(t, func() bool {
+		_, healthy := r.Replicas()
+		return healthy == 1
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "replica", queryNode(t, ctx, r.ReadOnly(), selectQuery))
+}
+
+func TestIsConnectionError(t *testing.T) {
+	assert.True(t, db.IsConnectionError(driver.ErrBadConn))
+	assert.True(t, db.IsConnectionError(&pq.Error{Code: "08006"}))
+	assert.True(t, db.IsConnectionError(&pq.Error{Code: "57P01"}))
+	assert.True(t, db.IsConnectionError(&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}))
+	assert.True(t, db.IsConnectionError(fmt.Errorf("query failed: %w", &net.DNSError{Err: "no such host", Name: "replica"})))
+
+	assert.False(t, db.IsConnectionError(nil))
+	assert.False(t, db.IsConnectionError(context.Canceled))
+	assert.False(t, db.IsConnectionError(sql.ErrNoRows))
+	assert.False(t, db.IsConnectionError(&pq.Error{Code: "23505"}))
+	assert.False(t, db.IsConnectionError(&pq.Error{Code: "57014"})) // query_canceled, e.g. statement_timeout
+	assert.False(t, db.IsConnectionError(errors.New("dial tcp: connection refused")))
+}
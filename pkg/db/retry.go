@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"math/rand"
+	"time"
+
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/lib/pq"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+const (
+	// PQErrSerializationFailure is the SQLSTATE raised if a transaction conflicts with a concurrent one,
+	// e.g. using the SERIALIZABLE or REPEATABLE READ isolation level.
+	PQErrSerializationFailure pq.ErrorCode = "40001"
+	// PQErrDeadlockDetected is the SQLSTATE raised on the transaction aborted to resolve a deadlock.
+	PQErrDeadlockDetected pq.ErrorCode = "40P01"
+)
+
+// IsRetryable checks whether err was caused by a serialization failure or deadlock, thus re-running the
+// transaction may succeed.
+func IsRetryable(err error) bool {
+	return IsPQError(err, PQErrSerializationFailure, PQErrDeadlockDetected)
+}
+
+// RetryOptions configure WithRetryingTransaction.
+type RetryOptions struct {
+	// MaxAttempts limits the executions of the transaction, including the first one.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, doubled for every further attempt up to MaxDelay.
+	// Every delay is randomly reduced by up to half to spread competing retries.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// NonIdempotent marks the TxFn as having side effects outside the transaction (e.g. sending a mail),
+	// thus it's never re-run.
+	NonIdempotent bool
+}
+
+// DefaultRetryOptions are sensible defaults for short transactions.
+var DefaultRetryOptions = RetryOptions{
+	MaxAttempts: 5,
+	BaseDelay:   10 * time.Millisecond,
+	MaxDelay:    time.Second,
+}
+
+// WithRetryingTransaction executes the given function in a transaction with the given options like
+// WithConfiguredTransaction, re-running it in a new transaction with backoff if it failed due to a serialization
+// failure or deadlock. The function must not have side effects outside the transaction unless marked as
+// RetryOptions.NonIdempotent. The error of the last attempt is returned.
+//
+// Within a transaction carried by ctx the function is executed once in a savepoint, as serialization failures and
+// deadlocks abort the outer transaction, which has to be retried as a whole.
+func WithRetryingTransaction(ctx context.Context, db *sql.DB, options *sql.TxOptions, retry RetryOptions, fn TxFn) error {
+	return WithRetryingTransactionContext(ctx, db, options, retry, func(_ context.Context, exec boil.ContextExecutor) error {
+		return fn(exec)
+	})
+}
+
+// WithRetryingTransactionContext is like WithRetryingTransaction, passing a context carrying the transaction.
+func WithRetryingTransactionContext(ctx context.Context, db *sql.DB, options *sql.TxOptions, retry RetryOptions, fn TxCtxFn) error {
+	if InTransaction(ctx) {
+		return WithConfiguredTransactionContext(ctx, db, options, fn)
+	}
+
+	log := logs.LogFromContext(ctx)
+
+	for attempt := 1; ; attempt++ {
+		err := WithConfiguredTransactionContext(ctx, db, options, fn)
+		if err == nil || !IsRetryable(err) {
+			return err
+		}
+
+		if retry.NonIdempotent {
+			log.Warn().Err(err).Msg("Refusing to retry transaction marked as non-idempotent")
+			return err
+		}
+
+		if attempt >= retry.MaxAttempts {
+			log.Warn().Err(err).Int("attempt", attempt).Msg("Giving up retrying transaction")
+			return err
+		}
+
+		delay := RetryDelay(attempt, retry, rand.Int63n)
+		log.Debug().Err(err).Int("attempt", attempt).Dur("retryIn", delay).Msg("Retrying transaction after serialization failure or deadlock")
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// RetryDelay returns the delay before retrying after the given (1-based) failed attempt. The exponential delay is
+// reduced by a random duration of up to half of it, drawn via randInt63n.
+func RetryDelay(attempt int, retry RetryOptions, randInt63n func(n int64) int64) time.Duration {
+	delay := retry.BaseDelay
+	for i := 1; i < attempt && delay < retry.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > retry.MaxDelay {
+		delay = retry.MaxDelay
+	}
+
+	if half := int64(delay / 2); half > 0 {
+		delay -= time.Duration(randInt63n(half))
+	}
+
+	return delay
+}
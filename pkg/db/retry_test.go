@@ -0,0 +1,128 @@
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+var testRetryOptions = db.RetryOptions{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+func TestWithRetryingTransaction(t *testing.T) {
+	d := &txDriver{}
+	conn := sql.OpenDB(d)
+	defer conn.Close()
+
+	calls := 0
+	err := db.WithRetryingTransaction(context.Background(), conn, nil, testRetryOptions, func(boil.ContextExecutor) error {
+		calls++
+		if calls == 1 {
+			return &pq.Error{Code: db.PQErrDeadlockDetected}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 1, d.rollbacks)
+	assert.Equal(t, 1, d.commits)
+}
+
+func TestWithRetryingTransactionCommitFailure(t *testing.T) {
+	d := &txDriver{commitErrs: []error{&pq.Error{Code: db.PQErrSerializationFailure}}}
+	conn := sql.OpenDB(d)
+	defer conn.Close()
+
+	calls := 0
+	err := db.WithRetryingTransaction(context.Background(), conn, nil, testRetryOptions, func(boil.ContextExecutor) error {
+		calls++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 2, d.commits)
+}
+
+func TestWithRetryingTransactionGivesUp(t *testing.T) {
+	conn := sql.OpenDB(&txDriver{})
+	defer conn.Close()
+
+	calls := 0
+	err := db.WithRetryingTransaction(context.Background(), conn, nil, testRetryOptions, func(boil.ContextExecutor) error {
+		calls++
+		return &pq.Error{Code: db.PQErrSerializationFailure}
+	})
+	assert.True(t, db.IsRetryable(err))
+	assert.Equal(t, testRetryOptions.MaxAttempts, calls)
+}
+
+func TestWithRetryingTransactionNotRetryable(t *testing.T) {
+	conn := sql.OpenDB(&txDriver{})
+	defer conn.Close()
+
+	cause := errors.New("validation failed")
+
+	calls := 0
+	err := db.WithRetryingTransaction(context.Background(), conn, nil, testRetryOptions, func(boil.ContextExecutor) error {
+		calls++
+		return cause
+	})
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, 1, calls)
+
+	nonIdempotent := testRetryOptions
+	nonIdempotent.NonIdempotent = true
+
+	calls = 0
+	err = db.WithRetryingTransaction(context.Background(), conn, nil, nonIdempotent, func(boil.ContextExecutor) error {
+		calls++
+		return &pq.Error{Code: db.PQErrSerializationFailure}
+	})
+	assert.True(t, db.IsRetryable(err))
+	assert.Equal(t, 1, calls)
+}
+
+func TestWithRetryingTransactionCanceled(t *testing.T) {
+	conn := sql.OpenDB(&txDriver{})
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	retry := testRetryOptions
+	retry.BaseDelay = time.Hour
+	retry.MaxDelay = time.Hour
+
+	err := db.WithRetryingTransaction(ctx, conn, nil, retry, func(boil.ContextExecutor) error {
+		cancel()
+		return &pq.Error{Code: db.PQErrSerializationFailure}
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRetryDelay(t *testing.T) {
+	retry := db.RetryOptions{BaseDelay: 10 * time.Millisecond, MaxDelay: time.Second}
+	none := func(int64) int64 { return 0 }
+
+	assert.Equal(t, 10*time.Millisecond, db.RetryDelay(1, retry, none))
+	assert.Equal(t, 40*time.Millisecond, db.RetryDelay(3, retry, none))
+	assert.Equal(t, time.Second, db.RetryDelay(20, retry, none))
+
+	var drawn int64
+	delay := db.RetryDelay(2, retry, func(n int64) int64 { drawn = n; return n - 1 })
+	assert.Equal(t, int64(10*time.Millisecond), drawn)
+	assert.Equal(t, 10*time.Millisecond+1, delay)
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, db.IsRetryable(&pq.Error{Code: db.PQErrSerializationFailure}))
+	assert.True(t, db.IsRetryable(&pq.Error{Code: db.PQErrDeadlockDetected}))
+	assert.False(t, db.IsRetryable(&pq.Error{Code: db.PQErrUniqueViolation}))
+	assert.False(t, db.IsRetryable(nil))
+}
@@ -0,0 +1,149 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+
+	"github.com/driif/echo-go-starter/pkg/logs"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+type txContextKey struct{}
+type txScopeContextKey struct{}
+
+// txState is the transaction carried by a context, depth counts the savepoints it's nested in.
+// Hooks are registered on the innermost savepoint.
+type txState struct {
+	tx    *sql.Tx
+	depth int
+	hooks txHooks
+}
+
+// txScope tracks the innermost running transaction started using a context, see WithTxScope.
+type txScope struct {
+	mu     sync.Mutex
+	active *txState
+}
+
+// WithTxScope returns a context tracking the transactions started using it, e.g. per request. While such a
+// transaction is running, transactions started using the context are nested within it, although the context
+// doesn't carry the transaction, e.g. a TxFn starting a transaction using its caller's context. Hooks can be
+// registered via OnCommit and OnRollback using the context as well.
+//
+// As transactions aren't safe for concurrent use, goroutines starting transactions concurrently must not share
+// a scope, derive a new one for each of them instead.
+func WithTxScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, txScopeContextKey{}, &txScope{})
+}
+
+func scopeFromContext(ctx context.Context) *txScope {
+	scope, _ := ctx.Value(txScopeContextKey{}).(*txScope)
+	return scope
+}
+
+// enter makes the transaction the innermost one of the scope, returning the function restoring the previous one.
+// A noop if the context carries no scope.
+func (s *txScope) enter(state *txState) func() {
+	if s == nil {
+		return func() {}
+	}
+
+	s.mu.Lock()
+	prev := s.active
+	s.active = state
+	s.mu.Unlock()
+
+	return func() {
+		s.mu.Lock()
+		s.active = prev
+		s.mu.Unlock()
+	}
+}
+
+func (s *txScope) current() *txState {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.active
+}
+
+// txStateFromContext returns the innermost running transaction of the context's scope, falling back to the
+// transaction carried by the context.
+func txStateFromContext(ctx context.Context) (*txState, bool) {
+	if state := scopeFromContext(ctx).current(); state != nil {
+		return state, true
+	}
+
+	state, ok := ctx.Value(txContextKey{}).(*txState)
+	return state, ok
+}
+
+// InTransaction reports whether ctx carries a transaction, e.g. as passed to a TxCtxFn, or a transaction of its
+// scope is running (see WithTxScope).
+func InTransaction(ctx context.Context) bool {
+	_, ok := txStateFromContext(ctx)
+	return ok
+}
+
+// Executor returns the transaction of ctx (see InTransaction) or db if there is none, e.g. for service functions
+// only reading data.
+func Executor(ctx context.Context, db *sql.DB) boil.ContextExecutor {
+	if state, ok := txStateFromContext(ctx); ok {
+		return state.tx
+	}
+
+	return db
+}
+
+// withSavepoint executes the function within a savepoint of the context's transaction. As transactions aren't safe
+// for concurrent use, nested functions must not run concurrently.
+func withSavepoint(ctx context.Context, state *txState, fn TxCtxFn) (err error) {
+	nested := &txState{tx: state.tx, depth: state.depth + 1}
+	name := fmt.Sprintf("sp_%d", nested.depth)
+
+	if _, err := state.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		logs.LogFromContext(ctx).Warn().Err(err).Str("savepoint", name).Msg("Failed to create savepoint")
+		return err
+	}
+
+	rollback := func() {
+		if _, txErr := state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); txErr != nil {
+			logs.LogFromContext(ctx).Warn().Err(txErr).Str("savepoint", name).Msg("Failed to roll back to savepoint")
+		}
+	}
+
+	restore := scopeFromContext(ctx).enter(nested)
+
+	defer func() {
+		restore()
+
+		if p := recover(); p != nil {
+			logs.LogFromContext(ctx).Error().Interface("p", p).Str("savepoint", name).Msg("Recovered from panic, rolling back to savepoint and panicking again")
+			rollback()
+			nested.hooks.runRolledBack(ctx)
+
+			panic(p)
+		} else if err != nil {
+			logs.LogFromContext(ctx).Debug().Err(err).Str("savepoint", name).Msg("Received error, rolling back to savepoint")
+			rollback()
+			nested.hooks.runRolledBack(ctx)
+		} else {
+			_, err = state.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+			if err != nil {
+				logs.LogFromContext(ctx).Warn().Err(err).Str("savepoint", name).Msg("Failed to release savepoint")
+				// the outer transaction is aborted, thus the hooks run once it rolled back
+			}
+			state.hooks.merge(&nested.hooks)
+		}
+	}()
+
+	err = fn(context.WithValue(ctx, txContextKey{}, nested), state.tx)
+
+	return err
+}
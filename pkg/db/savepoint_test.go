@@ -0,0 +1,164 @@
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func TestNestedTransaction(t *testing.T) {
+	d := &txDriver{}
+	conn := sql.OpenDB(d)
+	defer conn.Close()
+
+	assert.False(t, db.InTransaction(context.Background()))
+	assert.Equal(t, boil.ContextExecutor(conn), db.Executor(context.Background(), conn))
+
+	cause := errors.New("inner failed")
+
+	err := db.WithTransactionContext(context.Background(), conn, func(ctx context.Context, outer boil.ContextExecutor) error {
+		assert.True(t, db.InTransaction(ctx))
+		assert.Equal(t, outer, db.Executor(ctx, conn))
+
+		err := db.WithTransactionContext(ctx, conn, func(ctx context.Context, inner boil.ContextExecutor) error {
+			assert.Equal(t, outer, inner)
+
+			return db.WithTransactionContext(ctx, conn, func(context.Context, boil.ContextExecutor) error {
+				return nil
+			})
+		})
+		require.NoError(t, err)
+
+		err = db.WithTransactionContext(ctx, conn, func(context.Context, boil.ContextExecutor) error {
+			return cause
+		})
+		assert.ErrorIs(t, err, cause)
+
+		// the outer transaction stays usable after rolling back to the savepoint
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"SAVEPOINT sp_1",
+		"SAVEPOINT sp_2",
+		"RELEASE SAVEPOINT sp_2",
+		"RELEASE SAVEPOINT sp_1",
+		"SAVEPOINT sp_1",
+		"ROLLBACK TO SAVEPOINT sp_1",
+	}, d.statements)
+	assert.Equal(t, 1, d.commits)
+	assert.Equal(t, 0, d.rollbacks)
+}
+
+func TestNestedTransactionScope(t *testing.T) {
+	d := &txDriver{}
+	conn := sql.OpenDB(d)
+	defer conn.Close()
+
+	ctx := db.WithTxScope(context.Background())
+	assert.False(t, db.InTransaction(ctx))
+
+	cause := errors.New("inner failed")
+
+	// TxFns aren't passed the transaction's context, the caller's context tracks the running transaction instead
+	err := db.WithTransaction(ctx, conn, func(outer boil.ContextExecutor) error {
+		assert.True(t, db.InTransaction(ctx))
+		assert.Equal(t, outer, db.Executor(ctx, conn))
+
+		err := db.WithTransaction(ctx, conn, func(inner boil.ContextExecutor) error {
+			assert.Equal(t, outer, inner)
+
+			return db.WithTransactionContext(ctx, conn, func(context.Context, boil.ContextExecutor) error {
+				return nil
+			})
+		})
+		require.NoError(t, err)
+
+		err = db.WithTransaction(ctx, conn, func(boil.ContextExecutor) error {
+			return cause
+		})
+		assert.ErrorIs(t, err, cause)
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.False(t, db.InTransaction(ctx))
+
+	// once the outer transaction finished, a new one is started
+	require.NoError(t, db.WithTransaction(ctx, conn, func(boil.ContextExecutor) error { return nil }))
+
+	assert.Equal(t, []string{
+		"SAVEPOINT sp_1",
+		"SAVEPOINT sp_2",
+		"RELEASE SAVEPOINT sp_2",
+		"RELEASE SAVEPOINT sp_1",
+		"SAVEPOINT sp_1",
+		"ROLLBACK TO SAVEPOINT sp_1",
+	}, d.statements)
+	assert.Equal(t, 2, d.commits)
+	assert.Equal(t, 0, d.rollbacks)
+}
+
+func TestNestedTransactionSeparateScopes(t *testing.T) {
+	d := &txDriver{}
+	conn := sql.OpenDB(d)
+	defer conn.Close()
+
+	ctx := db.WithTxScope(context.Background())
+
+	err := db.WithTransaction(ctx, conn, func(boil.ContextExecutor) error {
+		// contexts without the scope (or with a scope of their own) start separate transactions
+		if err := db.WithTransaction(context.Background(), conn, func(boil.ContextExecutor) error { return nil }); err != nil {
+			return err
+		}
+
+		return db.WithTransaction(db.WithTxScope(ctx), conn, func(boil.ContextExecutor) error { return nil })
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, d.statements)
+	assert.Equal(t, 3, d.commits)
+}
+
+func TestNestedTransactionPanic(t *testing.T) {
+	d := &txDriver{}
+	conn := sql.OpenDB(d)
+	defer conn.Close()
+
+	err := db.WithTransactionContext(context.Background(), conn, func(ctx context.Context, _ boil.ContextExecutor) error {
+		assert.Panics(t, func() {
+			_ = db.WithTransactionContext(ctx, conn, func(context.Context, boil.ContextExecutor) error {
+				panic("inner panicked")
+			})
+		})
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"SAVEPOINT sp_1", "ROLLBACK TO SAVEPOINT sp_1"}, d.statements)
+}
+
+func TestNestedRetryingTransaction(t *testing.T) {
+	d := &txDriver{}
+	conn := sql.OpenDB(d)
+	defer conn.Close()
+
+	calls := 0
+	err := db.WithTransactionContext(context.Background(), conn, func(ctx context.Context, _ boil.ContextExecutor) error {
+		return db.WithRetryingTransactionContext(ctx, conn, nil, testRetryOptions, func(context.Context, boil.ContextExecutor) error {
+			calls++
+			return errSerializationFailure
+		})
+	})
+	assert.True(t, db.IsRetryable(err))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 1, d.rollbacks)
+}
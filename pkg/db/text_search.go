@@ -0,0 +1,194 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// Text search configurations shipped with Postgres, selecting the language specific stemming and stop words.
+// TSConfigSimple neither stems nor removes stop words, suitable for names or mixed languages.
+const (
+	TSConfigSimple  = "simple"
+	TSConfigEnglish = "english"
+	TSConfigGerman  = "german"
+)
+
+// TSWeight labels the lexemes of a column for ranking, A ranks highest.
+type TSWeight string
+
+const (
+	TSWeightA TSWeight = "A"
+	TSWeightB TSWeight = "B"
+	TSWeightC TSWeight = "C"
+	TSWeightD TSWeight = "D"
+)
+
+// TSColumn is a (trusted) column included in a tsvector, weighted for ranking (defaults to TSWeightD).
+type TSColumn struct {
+	Column string
+	Weight TSWeight
+}
+
+// TSVector returns the tsvector expression of the columns using the text search configuration, e.g.
+// `setweight(to_tsvector('english', coalesce(posts.title, ”)), 'A') || setweight(to_tsvector('english', coalesce(posts.body, ”)), 'D')`.
+// NULL columns are treated as empty.
+func TSVector(config string, columns ...TSColumn) string {
+	vectors := make([]string, 0, len(columns))
+	for _, c := range columns {
+		weight := c.Weight
+		if len(weight) == 0 {
+			weight = TSWeightD
+		}
+
+		vectors = append(vectors, fmt.Sprintf("setweight(to_tsvector(%s, coalesce(%s, '')), %s)",
+			pq.QuoteLiteral(config), c.Column, pq.QuoteLiteral(string(weight))))
+	}
+
+	return strings.Join(vectors, " || ")
+}
+
+// TSRankWeights are the weights of the lexeme labels used by ts_rank_cd, Postgres defaults to {0.1, 0.2, 0.4, 1.0}.
+type TSRankWeights struct {
+	D, C, B, A float32
+}
+
+// TextSearch matches user input against a tsvector. The configuration must match the one the tsvector was built with.
+type TextSearch struct {
+	// Config is the text search configuration, e.g. depending on the language of the content. Defaults to TSConfigSimple.
+	Config string
+	// Vector is the (trusted) tsvector searched, e.g. a generated column (see TSVectorColumn) or an expression built
+	// via TSVector.
+	Vector string
+	// Query is the user input, matching rows containing words starting with every word of it (see SearchStringToTSQuery).
+	Query string
+	// Weights used for ranking, Postgres' defaults are used if nil.
+	Weights *TSRankWeights
+	// Normalization of the rank, e.g. 1 divides the rank by 1 + the logarithm of the document length (see ts_rank_cd).
+	Normalization int
+}
+
+func (s TextSearch) config() string {
+	if len(s.Config) == 0 {
+		return pq.QuoteLiteral(TSConfigSimple)
+	}
+	return pq.QuoteLiteral(s.Config)
+}
+
+func (s TextSearch) tsQuery() string {
+	return SearchStringToTSQuery(&s.Query)
+}
+
+// Valid reports whether the query contains any searchable words.
+func (s TextSearch) Valid() bool {
+	return len(s.tsQuery()) > 0
+}
+
+// Where returns the query mod filtering rows matching the query, e.g. `posts.search @@ to_tsquery('english', ?)`.
+// If the query is not Valid, no rows are matched.
+func (s TextSearch) Where() qm.QueryMod {
+	if !s.Valid() {
+		return qm.Where("FALSE")
+	}
+
+	return qm.Where(fmt.Sprintf("%s @@ to_tsquery(%s, ?)", s.Vector, s.config()), s.tsQuery())
+}
+
+// Rank returns the ts_rank_cd expression ranking rows by the query (with the query bound as ? argument).
+func (s TextSearch) Rank() string {
+	var weights string
+	if s.Weights != nil {
+		weights = fmt.Sprintf("'{%g, %g, %g, %g}', ", s.Weights.D, s.Weights.C, s.Weights.B, s.Weights.A)
+	}
+
+	return fmt.Sprintf("ts_rank_cd(%s%s, to_tsquery(%s, ?), %d)", weights, s.Vector, s.config(), s.Normalization)
+}
+
+// OrderByRank returns the query mod ordering the best matching rows first, append a unique column to keep pages
+// stable, e.g. `qm.OrderBy(models.PostTableColumns.ID)`. Ignored if the query is not Valid.
+func (s TextSearch) OrderByRank() qm.QueryMod {
+	if !s.Valid() {
+		return qm.QueryModFunc(func(*queries.Query) {})
+	}
+
+	return qm.OrderBy(s.Rank()+" DESC", s.tsQuery())
+}
+
+// Headline returns the query mod selecting a snippet of the (trusted) text column with all matches highlighted
+// via ts_headline as alias, e.g. `ts_headline('english', posts.body, to_tsquery('english', '...'), '...') AS snippet`.
+// Options are passed to ts_headline, e.g. `StartSel=<mark>, StopSel=</mark>, MaxFragments=2`. Note that ts_headline
+// doesn't escape the text, escape it (apart from the selection markers) before rendering it as HTML.
+//
+// The query is inlined as literal, as sqlboiler doesn't support arguments within the selected columns.
+// Use together with qm.Select for the other columns and bind the result into a custom struct.
+func (s TextSearch) Headline(column string, alias string, options string) qm.QueryMod {
+	query := pq.QuoteLiteral(s.tsQuery())
+	if !s.Valid() {
+		// highlights nothing, still returning the snippet
+		query = "''"
+	}
+
+	return qm.Select(fmt.Sprintf("ts_headline(%s, %s, to_tsquery(%s, %s), %s) AS %s",
+		s.config(), column, s.config(), query, pq.QuoteLiteral(options), alias))
+}
+
+// TSVectorColumn describes a generated tsvector column, kept up to date by Postgres and indexed using GIN,
+// to be searched via TextSearch.
+type TSVectorColumn struct {
+	Table string
+	// Column is the name of the generated tsvector column, e.g. "search".
+	Column string
+	// Config is the text search configuration, defaults to TSConfigSimple.
+	Config string
+	// Columns are the (weighted) columns of the table included.
+	Columns []TSColumn
+}
+
+func (c TSVectorColumn) index() string {
+	return fmt.Sprintf("idx_%s_%s", c.Table, c.Column)
+}
+
+// Up returns the statements adding the column and its index.
+func (c TSVectorColumn) Up() []string {
+	config := c.Config
+	if len(config) == 0 {
+		config = TSConfigSimple
+	}
+
+	return []string{
+		fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s tsvector GENERATED ALWAYS AS (%s) STORED;", c.Table, c.Column, TSVector(config, c.Columns...)),
+		fmt.Sprintf("CREATE INDEX %s ON %s USING gin (%s);", c.index(), c.Table, c.Column),
+	}
+}
+
+// Down returns the statements dropping the index and the column.
+func (c TSVectorColumn) Down() []string {
+	return []string{
+		fmt.Sprintf("DROP INDEX IF EXISTS %s;", c.index()),
+		fmt.Sprintf("ALTER TABLE %s DROP COLUMN IF EXISTS %s;", c.Table, c.Column),
+	}
+}
+
+// Migration returns the sql-migrate migration, e.g. for use with migrate.MemoryMigrationSource.
+func (c TSVectorColumn) Migration(id string) *migrate.Migration {
+	return &migrate.Migration{Id: id, Up: c.Up(), Down: c.Down()}
+}
+
+// String renders the contents of the migration file, e.g. to be saved as `migrations/<timestamp>-add-<table>-<column>.sql`.
+func (c TSVectorColumn) String() string {
+	var b strings.Builder
+
+	b.WriteString("-- +migrate Up\n")
+	for _, stmt := range c.Up() {
+		b.WriteString(stmt + "\n\n")
+	}
+
+	b.WriteString("-- +migrate Down\n")
+	b.WriteString(strings.Join(c.Down(), "\n") + "\n")
+
+	return b.String()
+}
@@ -0,0 +1,90 @@
+package db_test
+
+import (
+	"testing"
+
+	models "github.com/driif/echo-go-starter/internal/models"
+	"github.com/driif/echo-go-starter/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+func TestTSVector(t *testing.T) {
+	assert.Equal(t,
+		`setweight(to_tsvector('english', coalesce(posts.title, '')), 'A') || setweight(to_tsvector('english', coalesce(posts.body, '')), 'D')`,
+		db.TSVector(db.TSConfigEnglish, db.TSColumn{Column: "posts.title", Weight: db.TSWeightA}, db.TSColumn{Column: "posts.body"}))
+}
+
+func TestTextSearch(t *testing.T) {
+	search := db.TextSearch{
+		Config:        db.TSConfigGerman,
+		Vector:        "posts.search",
+		Query:         "max's  muster",
+		Weights:       &db.TSRankWeights{D: 0.1, C: 0.2, B: 0.5, A: 1},
+		Normalization: 32,
+	}
+	require.True(t, search.Valid())
+
+	sql, args := queries.BuildQuery(models.NewQuery(
+		qm.Select("posts.id"),
+		search.Headline("posts.body", "snippet", "StartSel=<mark>, StopSel=</mark>"),
+		qm.From("posts"),
+		search.Where(),
+		search.OrderByRank(),
+		qm.OrderBy("posts.id"),
+		qm.Limit(10),
+	))
+
+	assert.Equal(t, `SELECT "posts"."id", ts_headline('german', posts.body, to_tsquery('german', '''maxs'':* & ''muster'':*'), 'StartSel=<mark>, StopSel=</mark>') AS snippet FROM "posts" WHERE (posts.search @@ to_tsquery('german', $1)) ORDER BY ts_rank_cd('{0.1, 0.2, 0.5, 1}', posts.search, to_tsquery('german', $2), 32) DESC, posts.id LIMIT 10;`, sql)
+	assert.Equal(t, []interface{}{"'maxs':* & 'muster':*", "'maxs':* & 'muster':*"}, args)
+}
+
+func TestTextSearchInvalid(t *testing.T) {
+	search := db.TextSearch{Vector: "posts.search", Query: " ' "}
+	require.False(t, search.Valid())
+
+	sql, args := queries.BuildQuery(models.NewQuery(
+		qm.Select("*"),
+		qm.From("posts"),
+		search.Where(),
+		search.OrderByRank(),
+	))
+
+	assert.Equal(t, `SELECT * FROM "posts" WHERE (FALSE);`, sql)
+	assert.Empty(t, args)
+}
+
+func TestTSVectorColumn(t *testing.T) {
+	c := db.TSVectorColumn{
+		Table:  "posts",
+		Column: "search",
+		Config: db.TSConfigEnglish,
+		Columns: []db.TSColumn{
+			{Column: "title", Weight: db.TSWeightA},
+			{Column: "body", Weight: db.TSWeightB},
+		},
+	}
+
+	m := c.Migration("20261019180000-add-posts-search.sql")
+	assert.Equal(t, "20261019180000-add-posts-search.sql", m.Id)
+	assert.Equal(t, []string{
+		`ALTER TABLE posts ADD COLUMN search tsvector GENERATED ALWAYS AS (setweight(to_tsvector('english', coalesce(title, '')), 'A') || setweight(to_tsvector('english', coalesce(body, '')), 'B')) STORED;`,
+		`CREATE INDEX idx_posts_search ON posts USING gin (search);`,
+	}, m.Up)
+	assert.Equal(t, []string{
+		`DROP INDEX IF EXISTS idx_posts_search;`,
+		`ALTER TABLE posts DROP COLUMN IF EXISTS search;`,
+	}, m.Down)
+
+	assert.Equal(t, `-- +migrate Up
+ALTER TABLE posts ADD COLUMN search tsvector GENERATED ALWAYS AS (setweight(to_tsvector('english', coalesce(title, '')), 'A') || setweight(to_tsvector('english', coalesce(body, '')), 'B')) STORED;
+
+CREATE INDEX idx_posts_search ON posts USING gin (search);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_posts_search;
+ALTER TABLE posts DROP COLUMN IF EXISTS search;
+`, c.String())
+}
@@ -7,19 +7,22 @@ import (
 
 var (
 	tsQueryWhiteSpaceRegex = regexp.MustCompile(`\s+`)
+	// tsQueryQuoteReplacer removes the characters ending or escaping within the quoted words, all other characters
+	// (including the tsquery operators `&|!():*<>`) are taken literally within quotes.
+	tsQueryQuoteReplacer = strings.NewReplacer("'", "", "\\", "")
 )
 
 // SearchStringToTSQuery returns a TSQuery string from user input.
 // The resulting query will match if every word matches a beginning of a word in the row.
-// This function will trim all leading and trailing as well as consecutive whitespaces and remove all single quotes before
-// transforming the input into TSQuery syntax.
+// This function will trim all leading and trailing as well as consecutive whitespaces and remove all single quotes and
+// backslashes before transforming the input into TSQuery syntax.
 // If no input was given (nil or empty string) or the value only contains invalid characters, an empty string will be returned.
 func SearchStringToTSQuery(s *string) string {
 	if s == nil || len(*s) == 0 {
 		return ""
 	}
 
-	v := strings.TrimSpace(strings.ReplaceAll(*s, "'", ""))
+	v := strings.TrimSpace(tsQueryQuoteReplacer.Replace(*s))
 	if len(v) == 0 {
 		return ""
 	}
@@ -10,6 +10,8 @@ type contextKey string
 const (
 	CTXKeyUser          contextKey = "user"
 	CTXKeyAccessToken   contextKey = "access_token"
+	CTXKeyTokenClaims   contextKey = "token_claims"
+	CTXKeyAPIKey        contextKey = "api_key"
 	CTXKeyRequestID     contextKey = "request_id"
 	CTXKeyDisableLogger contextKey = "disable_logger"
 	CTXKeyCacheControl  contextKey = "cache_control"
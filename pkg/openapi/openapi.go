@@ -0,0 +1,334 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrNoDocuments = errors.New("no OpenAPI documents found")
+)
+
+// Document is a (merged) OpenAPI document, preserving the key order of its source files.
+type Document struct {
+	root *yaml.Node
+}
+
+// LoadDir reads all `*.yml` and `*.yaml` files within the given directory (non-recursive)
+// and merges them into a single document, see Merge.
+func LoadDir(dir string) (*Document, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		switch strings.ToLower(filepath.Ext(e.Name())) {
+		case ".yml", ".yaml":
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+
+	return LoadFiles(files...)
+}
+
+// LoadFiles reads and merges the given OpenAPI documents in the order (by file name) provided.
+func LoadFiles(files ...string) (*Document, error) {
+	if len(files) == 0 {
+		return nil, ErrNoDocuments
+	}
+
+	sort.Strings(files)
+
+	docs := make([]*Document, 0, len(files))
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		doc, err := Parse(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse OpenAPI document %s: %w", filepath.Base(file), err)
+		}
+
+		doc.rewriteFileRefs(files)
+		docs = append(docs, doc)
+	}
+
+	return Merge(docs...)
+}
+
+// Parse parses a single OpenAPI document in YAML (or JSON) format.
+func Parse(b []byte) (*Document, error) {
+	var n yaml.Node
+	if err := yaml.Unmarshal(b, &n); err != nil {
+		return nil, err
+	}
+
+	if n.Kind != yaml.DocumentNode || len(n.Content) != 1 || n.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("document root is not a mapping")
+	}
+
+	return &Document{root: n.Content[0]}, nil
+}
+
+// Merge combines the documents provided into a new document.
+//
+// Top level keys (e.g. `openapi` or `info`) are taken from the first document defining them,
+// `paths` and all `components` sections are merged and must not contain duplicate keys,
+// `tags` are merged by their name.
+func Merge(docs ...*Document) (*Document, error) {
+	if len(docs) == 0 {
+		return nil, ErrNoDocuments
+	}
+
+	res := &Document{root: &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}}
+
+	for _, doc := range docs {
+		for i := 0; i+1 < len(doc.root.Content); i += 2 {
+			key, val := doc.root.Content[i], doc.root.Content[i+1]
+
+			existing := mappingValue(res.root, key.Value)
+			if existing == nil {
+				res.root.Content = append(res.root.Content, key, cloneNode(val))
+				continue
+			}
+
+			switch key.Value {
+			case "paths":
+				if err := mergeMapping(existing, val, "paths"); err != nil {
+					return nil, err
+				}
+			case "components":
+				for j := 0; j+1 < len(val.Content); j += 2 {
+					section, entries := val.Content[j], val.Content[j+1]
+
+					target := mappingValue(existing, section.Value)
+					if target == nil {
+						existing.Content = append(existing.Content, section, cloneNode(entries))
+						continue
+					}
+
+					if err := mergeMapping(target, entries, "components."+section.Value); err != nil {
+						return nil, err
+					}
+				}
+			case "tags":
+				mergeTags(existing, val)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// SetServerURL replaces all `servers` of the document with a single server using the given URL.
+func (d *Document) SetServerURL(url string) {
+	servers := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{
+		{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: "url"},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: url},
+		}},
+	}}
+
+	for i := 0; i+1 < len(d.root.Content); i += 2 {
+		if d.root.Content[i].Value == "servers" {
+			d.root.Content[i+1] = servers
+			return
+		}
+	}
+
+	// servers should follow `info` if no servers were defined in the source documents
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "servers"}
+	for i := 0; i+1 < len(d.root.Content); i += 2 {
+		if d.root.Content[i].Value == "info" {
+			content := make([]*yaml.Node, 0, len(d.root.Content)+2)
+			content = append(content, d.root.Content[:i+2]...)
+			content = append(content, key, servers)
+			d.root.Content = append(content, d.root.Content[i+2:]...)
+			return
+		}
+	}
+
+	d.root.Content = append(d.root.Content, key, servers)
+}
+
+// YAML returns the YAML representation of the document.
+func (d *Document) YAML() ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(d.root); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// MarshalJSON returns the JSON representation of the document.
+func (d *Document) MarshalJSON() ([]byte, error) {
+	v, err := nodeToInterface(d.root)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(v)
+}
+
+// rewriteFileRefs replaces references to other merged files (e.g. `users.yml#/components/schemas/User`)
+// with local references as all components will be available within the merged document.
+func (d *Document) rewriteFileRefs(files []string) {
+	names := make(map[string]struct{}, len(files))
+	for _, f := range files {
+		names[filepath.Base(f)] = struct{}{}
+	}
+
+	var walk func(n *yaml.Node)
+	walk = func(n *yaml.Node) {
+		if n.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				if n.Content[i].Value != "$ref" || n.Content[i+1].Kind != yaml.ScalarNode {
+					continue
+				}
+
+				ref := n.Content[i+1].Value
+				idx := strings.Index(ref, "#")
+				if idx <= 0 {
+					continue
+				}
+
+				if _, ok := names[filepath.Base(ref[:idx])]; ok {
+					n.Content[i+1].Value = ref[idx:]
+				}
+			}
+		}
+
+		for _, c := range n.Content {
+			walk(c)
+		}
+	}
+
+	walk(d.root)
+}
+
+// nodeToInterface converts the node into a JSON serializable value. Mapping keys are always
+// treated as strings, as OpenAPI documents commonly use unquoted status codes (e.g. `200:`).
+func nodeToInterface(n *yaml.Node) (interface{}, error) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil, nil
+		}
+		return nodeToInterface(n.Content[0])
+	case yaml.MappingNode:
+		m := make(map[string]interface{}, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			v, err := nodeToInterface(n.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			m[n.Content[i].Value] = v
+		}
+		return m, nil
+	case yaml.SequenceNode:
+		s := make([]interface{}, 0, len(n.Content))
+		for _, c := range n.Content {
+			v, err := nodeToInterface(c)
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, v)
+		}
+		return s, nil
+	case yaml.AliasNode:
+		return nodeToInterface(n.Alias)
+	default:
+		var v interface{}
+		if err := n.Decode(&v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+}
+
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+func mergeMapping(dst *yaml.Node, src *yaml.Node, path string) error {
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		return fmt.Errorf("cannot merge %s: not a mapping", path)
+	}
+
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		if mappingValue(dst, src.Content[i].Value) != nil {
+			return fmt.Errorf("duplicate key %s.%s", path, src.Content[i].Value)
+		}
+
+		dst.Content = append(dst.Content, src.Content[i], cloneNode(src.Content[i+1]))
+	}
+
+	return nil
+}
+
+func mergeTags(dst *yaml.Node, src *yaml.Node) {
+	for _, tag := range src.Content {
+		name := mappingValue(tag, "name")
+		if name == nil {
+			continue
+		}
+
+		exists := false
+		for _, t := range dst.Content {
+			if n := mappingValue(t, "name"); n != nil && n.Value == name.Value {
+				exists = true
+				break
+			}
+		}
+
+		if !exists {
+			dst.Content = append(dst.Content, cloneNode(tag))
+		}
+	}
+}
+
+func cloneNode(n *yaml.Node) *yaml.Node {
+	if n == nil {
+		return nil
+	}
+
+	c := *n
+	if len(n.Content) > 0 {
+		c.Content = make([]*yaml.Node, len(n.Content))
+		for i, child := range n.Content {
+			c.Content[i] = cloneNode(child)
+		}
+	}
+
+	return &c
+}
@@ -0,0 +1,66 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/driif/echo-go-starter/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDir(t *testing.T) {
+	doc, err := openapi.LoadDir("testdata")
+	require.NoError(t, err)
+
+	doc.SetServerURL("http://localhost:8080")
+
+	b, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	var res map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &res))
+
+	assert.Equal(t, "3.0.3", res["openapi"])
+	assert.Equal(t, "Test", res["info"].(map[string]interface{})["title"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"url": "http://localhost:8080"}}, res["servers"])
+	assert.Len(t, res["tags"], 2)
+
+	paths := res["paths"].(map[string]interface{})
+	assert.Contains(t, paths, "/a")
+	assert.Contains(t, paths, "/b")
+
+	schema := paths["/a"].(map[string]interface{})["get"].(map[string]interface{})["responses"].(map[string]interface{})["200"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"].(map[string]interface{})
+	assert.Equal(t, "#/components/schemas/B", schema["$ref"])
+
+	components := res["components"].(map[string]interface{})
+	assert.Len(t, components["schemas"], 2)
+	assert.Contains(t, components, "securitySchemes")
+}
+
+func TestYAMLKeepsOrder(t *testing.T) {
+	doc, err := openapi.Parse([]byte("openapi: 3.0.3\ninfo:\n  title: Test\n  version: 1.0.0\npaths: {}\n"))
+	require.NoError(t, err)
+
+	doc.SetServerURL("http://localhost:8080")
+
+	b, err := doc.YAML()
+	require.NoError(t, err)
+
+	assert.Equal(t, "openapi: 3.0.3\ninfo:\n  title: Test\n  version: 1.0.0\nservers:\n  - url: http://localhost:8080\npaths: {}\n", string(b))
+}
+
+func TestMergeDuplicatePath(t *testing.T) {
+	a, err := openapi.Parse([]byte("paths:\n  /a:\n    get: {}\n"))
+	require.NoError(t, err)
+	b, err := openapi.Parse([]byte("paths:\n  /a:\n    post: {}\n"))
+	require.NoError(t, err)
+
+	_, err = openapi.Merge(a, b)
+	assert.EqualError(t, err, "duplicate key paths./a")
+}
+
+func TestLoadDirEmpty(t *testing.T) {
+	_, err := openapi.LoadDir(t.TempDir())
+	assert.ErrorIs(t, err, openapi.ErrNoDocuments)
+}